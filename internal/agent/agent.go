@@ -1,55 +1,128 @@
 package agent
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
 )
 
 // LaunchOptions contains options for launching an agent
 type LaunchOptions struct {
-	AddDirs []string // Additional directories (for multi-repo projects)
-	Flags   []string // Extra flags to pass to the agent
+	AddDirs []string          // Additional directories (for multi-repo projects)
+	Flags   []string          // Extra flags to pass to the agent
+	Env     map[string]string // Extra environment variables (e.g. CLADE_NAME)
 }
 
 // Agent defines the interface for AI coding agents
 type Agent interface {
 	Launch(workdir string, opts LaunchOptions) error
 	Name() string
+	SupportsAddDirs() bool
 }
 
-// ClaudeAgent implements Agent for Claude Code
-type ClaudeAgent struct{}
-
-// Name returns the agent name
-func (c *ClaudeAgent) Name() string {
-	return "claude"
+// ExitError reports that the agent ran but exited with a non-zero status
+type ExitError struct {
+	Name string
+	Code int
 }
 
-// Launch starts Claude Code in the given directory
-func (c *ClaudeAgent) Launch(workdir string, opts LaunchOptions) error {
-	args := []string{}
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("%s exited with status %d", e.Name, e.Code)
+}
 
-	// Add additional directories
-	for _, dir := range opts.AddDirs {
-		args = append(args, "--add-dir", dir)
+// exitError converts a non-zero exit from the agent process into an ExitError
+func exitError(name string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Name: name, Code: exitErr.ExitCode()}
 	}
+	return err
+}
+
+// builtinSpecs are the agents clade knows how to launch without configuration
+var builtinSpecs = map[string]config.AgentSpec{
+	"claude": {Command: "claude", AddDirFlag: "--add-dir {dir}"},
+	"codex":  {Command: "codex", AddDirFlag: "--add-dir {dir}"},
+	"gemini": {Command: "gemini", AddDirFlag: "--include-directories {dir}"},
+	"aider":  {Command: "aider"},
+}
+
+// SpecAgent implements Agent from an AgentSpec
+type SpecAgent struct {
+	AgentName string
+	Spec      config.AgentSpec
+}
+
+// Name returns the agent name
+func (a *SpecAgent) Name() string {
+	return a.AgentName
+}
 
-	// Add extra flags
-	args = append(args, opts.Flags...)
+// SupportsAddDirs reports whether the agent can be given extra directories
+func (a *SpecAgent) SupportsAddDirs() bool {
+	return a.Spec.AddDirFlag != ""
+}
 
-	cmd := exec.Command("claude", args...)
+// Launch starts the agent in the given directory
+func (a *SpecAgent) Launch(workdir string, opts LaunchOptions) error {
+	cmd := exec.Command(a.command(), a.Args(opts)...)
 	cmd.Dir = workdir
+	cmd.Env = environ(opts.Env)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	return cmd.Run()
+	return exitError(a.Name(), cmd.Run())
+}
+
+func (a *SpecAgent) command() string {
+	if a.Spec.Command != "" {
+		return a.Spec.Command
+	}
+	return a.AgentName
+}
+
+// Args builds the agent's arguments: spec flags, one add-dir flag per extra
+// directory, then the extra flags from opts
+func (a *SpecAgent) Args(opts LaunchOptions) []string {
+	args := append([]string{}, a.Spec.Flags...)
+
+	if a.Spec.AddDirFlag != "" {
+		for _, dir := range opts.AddDirs {
+			args = append(args, expandAddDirFlag(a.Spec.AddDirFlag, dir)...)
+		}
+	}
+
+	return append(args, opts.Flags...)
+}
+
+// expandAddDirFlag fills {dir} in an add-dir template. Without a
+// placeholder, the directory follows the flag as its own argument.
+func expandAddDirFlag(template, dir string) []string {
+	fields := strings.Fields(template)
+	found := false
+	for i, field := range fields {
+		if strings.Contains(field, "{dir}") {
+			fields[i] = strings.ReplaceAll(field, "{dir}", dir)
+			found = true
+		}
+	}
+	if !found {
+		fields = append(fields, dir)
+	}
+	return fields
 }
 
 // GenericAgent implements Agent for any command-based agent
 type GenericAgent struct {
-	Command string // e.g., "cursor .", "code ."
+	Command    string // e.g., "cursor .", "code ."
+	AddDirFlag string // e.g. "--add-dir" or "-d {dir}"; empty if unsupported
 }
 
 // Name returns the command being used
@@ -57,6 +130,11 @@ func (g *GenericAgent) Name() string {
 	return g.Command
 }
 
+// SupportsAddDirs reports whether the agent can be given extra directories
+func (g *GenericAgent) SupportsAddDirs() bool {
+	return g.AddDirFlag != ""
+}
+
 // Launch starts the generic agent in the given directory
 func (g *GenericAgent) Launch(workdir string, opts LaunchOptions) error {
 	parts := strings.Fields(g.Command)
@@ -76,19 +154,68 @@ func (g *GenericAgent) Launch(workdir string, opts LaunchOptions) error {
 		}
 	}
 
+	if g.AddDirFlag != "" {
+		for _, dir := range opts.AddDirs {
+			args = append(args, expandAddDirFlag(g.AddDirFlag, dir)...)
+		}
+	}
+
 	cmd := exec.Command(parts[0], args...)
 	cmd.Dir = workdir
+	cmd.Env = environ(opts.Env)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	return cmd.Run()
+	return exitError(g.Name(), cmd.Run())
+}
+
+// NewAgent creates the agent configured in cfg. Specs from config take
+// precedence over the built-in ones; anything else is run as a generic
+// command, using agent_add_dir_flag or else the add-dir flag of the spec
+// its binary matches.
+func NewAgent(cfg *config.Config) Agent {
+	agentCmd := cfg.Agent
+	if agentCmd == "" {
+		agentCmd = "claude"
+	}
+	if spec, ok := cfg.Agents[agentCmd]; ok {
+		return &SpecAgent{AgentName: agentCmd, Spec: spec}
+	}
+	if spec, ok := builtinSpecs[agentCmd]; ok {
+		return &SpecAgent{AgentName: agentCmd, Spec: spec}
+	}
+
+	generic := &GenericAgent{Command: agentCmd, AddDirFlag: cfg.AgentAddDirFlag}
+	if generic.AddDirFlag == "" {
+		if fields := strings.Fields(agentCmd); len(fields) > 0 {
+			binary := filepath.Base(fields[0])
+			if spec, ok := cfg.Agents[binary]; ok {
+				generic.AddDirFlag = spec.AddDirFlag
+			} else if spec, ok := builtinSpecs[binary]; ok {
+				generic.AddDirFlag = spec.AddDirFlag
+			}
+		}
+	}
+	return generic
 }
 
-// NewAgent creates an agent based on the configured command
-func NewAgent(agentCmd string) Agent {
-	if agentCmd == "claude" || agentCmd == "" {
-		return &ClaudeAgent{}
+// environ returns the current environment plus extra, or nil (inherit the
+// environment unchanged) when there is nothing to add
+func environ(extra map[string]string) []string {
+	if len(extra) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := os.Environ()
+	for _, key := range keys {
+		env = append(env, key+"="+extra[key])
 	}
-	return &GenericAgent{Command: agentCmd}
+	return env
 }
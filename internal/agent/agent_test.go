@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestSpecAgentArgs(t *testing.T) {
+	opts := LaunchOptions{AddDirs: []string{"/src/web", "/src/lib"}, Flags: []string{"--verbose"}}
+	tests := []struct {
+		agent  string
+		agents map[string]config.AgentSpec
+		want   []string
+	}{
+		{"claude", nil, []string{"--add-dir", "/src/web", "--add-dir", "/src/lib", "--verbose"}},
+		{"codex", nil, []string{"--add-dir", "/src/web", "--add-dir", "/src/lib", "--verbose"}},
+		{"gemini", nil, []string{"--include-directories", "/src/web", "--include-directories", "/src/lib", "--verbose"}},
+		{"aider", nil, []string{"--verbose"}}, // no add-dir support
+		{
+			"aider",
+			map[string]config.AgentSpec{"aider": {Command: "aider", AddDirFlag: "--read={dir}", Flags: []string{"--no-auto-commits"}}},
+			[]string{"--no-auto-commits", "--read=/src/web", "--read=/src/lib", "--verbose"},
+		},
+	}
+	for _, tt := range tests {
+		a := NewAgent(&config.Config{Agent: tt.agent, Agents: tt.agents})
+		spec, ok := a.(*SpecAgent)
+		if !ok {
+			t.Fatalf("%s: got %T, want a SpecAgent", tt.agent, a)
+		}
+		if got := spec.Args(opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: args = %q, want %q", tt.agent, got, tt.want)
+		}
+		if spec.SupportsAddDirs() != (spec.Spec.AddDirFlag != "") {
+			t.Errorf("%s: SupportsAddDirs disagrees with the spec", tt.agent)
+		}
+	}
+}
+
+func TestNewAgentDefaultsToClaude(t *testing.T) {
+	a := NewAgent(&config.Config{})
+	if a.Name() != "claude" || !a.SupportsAddDirs() {
+		t.Errorf("got %s (add dirs %v), want claude with add-dir support", a.Name(), a.SupportsAddDirs())
+	}
+	if spec := a.(*SpecAgent); spec.command() != "claude" {
+		t.Errorf("command = %q, want claude", spec.command())
+	}
+}
+
+// fakeAgent writes an executable script that records its arguments and
+// environment into files in its directory, then exits with code
+func fakeAgent(t *testing.T, code int) (path, argsFile, envFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	argsFile = filepath.Join(dir, "args")
+	envFile = filepath.Join(dir, "env")
+	path = filepath.Join(dir, "fake-agent")
+	script := fmt.Sprintf("#!/bin/sh\nfor a in \"$@\"; do echo \"$a\"; done > %q\nenv > %q\nexit %d\n", argsFile, envFile, code)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path, argsFile, envFile
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestLaunchPassesCladeEnvironment(t *testing.T) {
+	t.Setenv("CLADE_KEEP_ME", "inherited")
+	bin, _, envFile := fakeAgent(t, 0)
+	env := map[string]string{
+		"CLADE_NAME":   "try-redis",
+		"CLADE_TYPE":   "experiment",
+		"CLADE_BRANCH": "exp/try-redis",
+		"CLADE_TICKET": "",
+		"CLADE_REPO":   "/src/api",
+	}
+
+	agents := []Agent{
+		&SpecAgent{AgentName: "fake", Spec: config.AgentSpec{Command: bin}},
+		&GenericAgent{Command: bin},
+	}
+	for _, a := range agents {
+		if err := a.Launch(t.TempDir(), LaunchOptions{Env: env}); err != nil {
+			t.Fatalf("%T.Launch: %v", a, err)
+		}
+		got := readLines(t, envFile)
+		for key, value := range env {
+			if !slices.Contains(got, key+"="+value) {
+				t.Errorf("%T: %s=%s missing from the agent's environment", a, key, value)
+			}
+		}
+		if !slices.Contains(got, "CLADE_KEEP_ME=inherited") {
+			t.Errorf("%T: the parent environment wasn't inherited", a)
+		}
+	}
+}
+
+func TestGenericAgentPassesAddDirs(t *testing.T) {
+	bin, argsFile, _ := fakeAgent(t, 0)
+	workdir := t.TempDir()
+	opts := LaunchOptions{AddDirs: []string{"/src/web", "/src/lib dir"}}
+
+	tests := []struct {
+		flag string
+		want []string
+	}{
+		{"--add-dir", []string{"--model", "x", workdir, "--add-dir", "/src/web", "--add-dir", "/src/lib dir"}},
+		{"-d {dir}", []string{"--model", "x", workdir, "-d", "/src/web", "-d", "/src/lib dir"}},
+		{"--dir={dir}", []string{"--model", "x", workdir, "--dir=/src/web", "--dir=/src/lib dir"}},
+		{"", []string{"--model", "x", workdir}},
+	}
+	for _, tt := range tests {
+		a := &GenericAgent{Command: bin + " --model x .", AddDirFlag: tt.flag}
+		if err := a.Launch(workdir, opts); err != nil {
+			t.Fatalf("Launch: %v", err)
+		}
+		if got := readLines(t, argsFile); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("flag %q: args = %q, want %q", tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestNewAgentGenericAddDirFlag(t *testing.T) {
+	tests := []struct {
+		cfg  config.Config
+		want string
+	}{
+		{config.Config{Agent: "my-agent --fast"}, ""},
+		{config.Config{Agent: "my-agent", AgentAddDirFlag: "-d"}, "-d"},
+		// Known binaries with extra arguments borrow their spec's flag
+		{config.Config{Agent: "/opt/bin/codex --full-auto"}, "--add-dir {dir}"},
+		{config.Config{Agent: "codex --full-auto", AgentAddDirFlag: "--dir"}, "--dir"},
+	}
+	for _, tt := range tests {
+		a, ok := NewAgent(&tt.cfg).(*GenericAgent)
+		if !ok {
+			t.Fatalf("%q: not a GenericAgent", tt.cfg.Agent)
+		}
+		if a.AddDirFlag != tt.want {
+			t.Errorf("%q: AddDirFlag = %q, want %q", tt.cfg.Agent, a.AddDirFlag, tt.want)
+		}
+	}
+}
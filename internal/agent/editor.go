@@ -4,67 +4,120 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 )
 
 // EditorOptions contains options for launching an editor
 type EditorOptions struct {
 	TmuxSplitDirection string // "horizontal" or "vertical"
+	TmuxSplitPercent   int    // editor pane size in split mode, 1-99 (0 = tmux default)
+	TmuxMode           string // "split" (default) or "window"
+	WindowName         string // tmux window name in "window" mode
 }
 
-// OpenEditor opens an editor/IDE alongside the agent session
+// editorSpec describes how to launch a known editor
+type editorSpec struct {
+	args     []string // command line; GUI editors get the workdir appended
+	terminal bool     // runs in a tmux split next to the agent
+}
+
+// knownEditors maps editor names to how they are launched
+var knownEditors = map[string]editorSpec{
+	"cursor":   {args: []string{"cursor"}},
+	"code":     {args: []string{"code"}},
+	"zed":      {args: []string{"zed"}},
+	"idea":     {args: []string{"idea"}},
+	"goland":   {args: []string{"goland"}},
+	"webstorm": {args: []string{"webstorm"}},
+	"nvim":     {args: []string{"nvim", "."}, terminal: true},
+	"neovim":   {args: []string{"nvim", "."}, terminal: true},
+	"vim":      {args: []string{"nvim", "."}, terminal: true},
+	"hx":       {args: []string{"hx", "."}, terminal: true},
+	"helix":    {args: []string{"hx", "."}, terminal: true},
+	"emacs":    {args: []string{"emacs", "-nw", "."}, terminal: true},
+}
+
+// DetectEditor picks an editor from $VISUAL, then $EDITOR, returning one of
+// the names OpenEditor knows. Other editors are ignored since they may need
+// a terminal of their own.
+func DetectEditor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		fields := strings.Fields(os.Getenv(env))
+		if len(fields) == 0 {
+			continue
+		}
+		if name := filepath.Base(fields[0]); knownEditors[name].args != nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// editorCommand returns the command line that opens editor on workdir, and
+// whether it is a terminal editor. Unknown editors are run as a command with
+// the workdir as their argument.
+func editorCommand(editor, workdir string) ([]string, bool) {
+	spec, ok := knownEditors[editor]
+	if !ok {
+		return []string{editor, workdir}, false
+	}
+	if spec.terminal {
+		return spec.args, true
+	}
+	return append(append([]string{}, spec.args...), workdir), false
+}
+
+// OpenEditor opens an editor/IDE alongside the agent session.
+// GUI editors start in the background; terminal editors open in a tmux split
+// or window.
 // Returns an error if the editor cannot be launched
 func OpenEditor(workdir string, editor string, opts EditorOptions) error {
-	switch editor {
-	case "cursor":
-		return openCursor(workdir)
-	case "code":
-		return openVSCode(workdir)
-	case "nvim", "neovim", "vim":
-		return openNvim(workdir, opts)
-	case "":
+	if editor == "" || editor == "none" {
 		return nil // No editor configured
-	default:
-		// Try to run as a generic command
-		return openGeneric(workdir, editor)
 	}
-}
 
-// openCursor opens Cursor IDE in the background
-func openCursor(workdir string) error {
-	cmd := exec.Command("cursor", workdir)
-	cmd.Dir = workdir
-	// Don't attach stdin/stdout - run in background
-	return cmd.Start()
-}
+	args, terminal := editorCommand(editor, workdir)
+	if terminal {
+		return openInTmux(workdir, args, opts)
+	}
 
-// openVSCode opens VS Code in the background
-func openVSCode(workdir string) error {
-	cmd := exec.Command("code", workdir)
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Dir = workdir
+	// Don't attach stdin/stdout - run in background
 	return cmd.Start()
 }
 
-// openNvim opens neovim in a tmux split pane
-func openNvim(workdir string, opts EditorOptions) error {
+// openInTmux runs a terminal editor in a tmux split pane or window
+func openInTmux(workdir string, args []string, opts EditorOptions) error {
 	if !inTmux() {
-		return fmt.Errorf("nvim requires tmux (run inside tmux for split panes)")
+		return fmt.Errorf("%s is a terminal editor and needs tmux (run inside tmux to open it next to the agent)", args[0])
 	}
 
-	splitFlag := "-h" // horizontal (side by side) is default
-	if opts.TmuxSplitDirection == "vertical" {
-		splitFlag = "-v"
-	}
-
-	cmd := exec.Command("tmux", "split-window", splitFlag, "-c", workdir, "nvim", ".")
+	cmd := exec.Command("tmux", tmuxArgs(workdir, args, opts)...)
 	cmd.Dir = workdir
 	return cmd.Start() // Use Start() instead of Run() to avoid blocking
 }
 
-// openGeneric tries to open an arbitrary editor command
-func openGeneric(workdir string, editor string) error {
-	cmd := exec.Command(editor, workdir)
-	cmd.Dir = workdir
-	return cmd.Start()
+// tmuxArgs builds the tmux arguments that open args in workdir
+func tmuxArgs(workdir string, args []string, opts EditorOptions) []string {
+	var tmux []string
+	if opts.TmuxMode == "window" {
+		tmux = []string{"new-window", "-c", workdir}
+		if opts.WindowName != "" {
+			tmux = append(tmux, "-n", opts.WindowName)
+		}
+	} else {
+		splitFlag := "-h" // horizontal (side by side) is default
+		if opts.TmuxSplitDirection == "vertical" {
+			splitFlag = "-v"
+		}
+		tmux = []string{"split-window", splitFlag, "-c", workdir}
+		if opts.TmuxSplitPercent > 0 && opts.TmuxSplitPercent < 100 {
+			tmux = append(tmux, "-l", fmt.Sprintf("%d%%", opts.TmuxSplitPercent))
+		}
+	}
+	return append(tmux, args...)
 }
 
 // inTmux checks if we're running inside a tmux session
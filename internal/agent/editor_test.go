@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectEditor(t *testing.T) {
+	tests := []struct {
+		visual, editor string
+		want           string
+	}{
+		{"", "nvim", "nvim"},
+		{"", "/usr/local/bin/vim", "vim"},
+		{"code --wait", "nvim", "code"},
+		{"cursor", "", "cursor"},
+		{"nano", "hx", "hx"}, // unknown $VISUAL falls through to $EDITOR
+		{"", "nano", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Setenv("VISUAL", tt.visual)
+		t.Setenv("EDITOR", tt.editor)
+		if got := DetectEditor(); got != tt.want {
+			t.Errorf("VISUAL=%q EDITOR=%q: got %q, want %q", tt.visual, tt.editor, got, tt.want)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	const dir = "/work/api"
+	tests := []struct {
+		editor   string
+		want     []string
+		terminal bool
+	}{
+		{"cursor", []string{"cursor", dir}, false},
+		{"code", []string{"code", dir}, false},
+		{"zed", []string{"zed", dir}, false},
+		{"idea", []string{"idea", dir}, false},
+		{"goland", []string{"goland", dir}, false},
+		{"webstorm", []string{"webstorm", dir}, false},
+		{"nvim", []string{"nvim", "."}, true},
+		{"vim", []string{"nvim", "."}, true},
+		{"hx", []string{"hx", "."}, true},
+		{"helix", []string{"hx", "."}, true},
+		{"emacs", []string{"emacs", "-nw", "."}, true},
+		{"subl", []string{"subl", dir}, false}, // unknown: run with the dir
+	}
+	for _, tt := range tests {
+		got, terminal := editorCommand(tt.editor, dir)
+		if !reflect.DeepEqual(got, tt.want) || terminal != tt.terminal {
+			t.Errorf("%s: got %q (terminal %v), want %q (terminal %v)", tt.editor, got, terminal, tt.want, tt.terminal)
+		}
+	}
+
+	// Appending the workdir must not grow the shared spec
+	editorCommand("code", "/a")
+	if got, _ := editorCommand("code", "/b"); !reflect.DeepEqual(got, []string{"code", "/b"}) {
+		t.Errorf("spec was mutated: %q", got)
+	}
+}
+
+func TestTerminalEditorNeedsTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	err := OpenEditor(t.TempDir(), "hx", EditorOptions{})
+	if err == nil || !strings.Contains(err.Error(), "needs tmux") {
+		t.Errorf("got %v, want an error explaining hx needs tmux", err)
+	}
+}
+
+func TestTmuxArgsModes(t *testing.T) {
+	const dir = "/work/api"
+	editor := []string{"nvim", "."}
+	tests := []struct {
+		name string
+		opts EditorOptions
+		want []string
+	}{
+		{"default split", EditorOptions{}, []string{"split-window", "-h", "-c", dir, "nvim", "."}},
+		{"vertical split", EditorOptions{TmuxSplitDirection: "vertical"}, []string{"split-window", "-v", "-c", dir, "nvim", "."}},
+		{"explicit split mode", EditorOptions{TmuxMode: "split", TmuxSplitDirection: "horizontal"}, []string{"split-window", "-h", "-c", dir, "nvim", "."}},
+		{"window", EditorOptions{TmuxMode: "window"}, []string{"new-window", "-c", dir, "nvim", "."}},
+		{"named window", EditorOptions{TmuxMode: "window", WindowName: "try-redis"}, []string{"new-window", "-c", dir, "-n", "try-redis", "nvim", "."}},
+		{"window ignores split direction", EditorOptions{TmuxMode: "window", TmuxSplitDirection: "vertical"}, []string{"new-window", "-c", dir, "nvim", "."}},
+	}
+	for _, tt := range tests {
+		if got := tmuxArgs(dir, editor, tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTmuxArgsSplitPercent(t *testing.T) {
+	const dir = "/work/api"
+	tests := []struct {
+		percent int
+		want    []string
+	}{
+		{70, []string{"split-window", "-h", "-c", dir, "-l", "70%", "hx", "."}},
+		{1, []string{"split-window", "-h", "-c", dir, "-l", "1%", "hx", "."}},
+		{99, []string{"split-window", "-h", "-c", dir, "-l", "99%", "hx", "."}},
+		{0, []string{"split-window", "-h", "-c", dir, "hx", "."}},   // tmux default
+		{100, []string{"split-window", "-h", "-c", dir, "hx", "."}}, // out of range
+	}
+	for _, tt := range tests {
+		got := tmuxArgs(dir, []string{"hx", "."}, EditorOptions{TmuxSplitPercent: tt.percent})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("percent %d: got %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+
+	// Window mode has no split to size
+	got := tmuxArgs(dir, []string{"hx", "."}, EditorOptions{TmuxMode: "window", TmuxSplitPercent: 70})
+	if want := []string{"new-window", "-c", dir, "hx", "."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("window mode: got %q, want %q", got, want)
+	}
+}
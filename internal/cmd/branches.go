@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	branchesRepoFlag   string
+	branchesPruneFlag  bool
+	branchesDryRunFlag bool
+)
+
+var branchesCmd = &cobra.Command{
+	Use:   "branches",
+	Short: "List exp/feat branches in a repo and what backs them",
+	Long: `List the local branches using the exp and feat prefixes in a repo.
+
+Each branch shows whether clade tracks it, whether a worktree has it checked
+out, and how far it is ahead of and behind the default branch. Branches with
+neither are orphaned, e.g. left behind by a cleanup that kept the branch.
+
+With --prune, orphaned branches that are merged into the default branch are
+deleted.
+
+Examples:
+  clade branches
+  clade branches -r api
+  clade branches --prune --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runBranches,
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+	branchesCmd.Flags().StringVarP(&branchesRepoFlag, "repo", "r", "", "Repository path or registered name (default: current repo)")
+	branchesCmd.Flags().BoolVar(&branchesPruneFlag, "prune", false, "Delete orphaned branches that are merged into the default branch")
+	branchesCmd.Flags().BoolVar(&branchesDryRunFlag, "dry-run", false, "With --prune, show what would be deleted")
+}
+
+// managedBranch is a prefixed branch and what it's backed by
+type managedBranch struct {
+	Name     string
+	Item     string // name of the clade item using it, if tracked
+	Worktree string // path where it's checked out, if anywhere
+	Ahead    int
+	Behind   int
+	Merged   bool
+}
+
+func (b *managedBranch) orphaned() bool {
+	return b.Item == "" && b.Worktree == ""
+}
+
+func runBranches(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	repoPath, err := resolveRepo(cfg, branchesRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	branches, err := managedBranches(cfg, state, repoPath)
+	if err != nil {
+		return err
+	}
+
+	base := defaultBaseRef(cfg, repoPath)
+	ui.Header("Branches in %s %s", git.GetRepoName(repoPath), ui.Dim("(vs "+base+")"))
+	if len(branches) == 0 {
+		ui.Detail("No branches starting with %s", strings.Join(branchPrefixes(cfg), " or "))
+		return nil
+	}
+
+	width := 0
+	for _, b := range branches {
+		if len(b.Name) > width {
+			width = len(b.Name)
+		}
+	}
+
+	var prunable []*managedBranch
+	for _, b := range branches {
+		var status string
+		switch {
+		case b.Item != "":
+			status = "tracked as " + b.Item
+		case b.Worktree != "":
+			status = ui.Yellow("untracked worktree at " + b.Worktree)
+		case b.Merged:
+			status = ui.Yellow("orphaned, merged")
+			prunable = append(prunable, b)
+		default:
+			status = ui.Yellow("orphaned")
+		}
+		fmt.Printf("  %s  %s %s\n",
+			ui.Cyan(fmt.Sprintf("%-*s", width, b.Name)),
+			status,
+			ui.Dim(fmt.Sprintf("(%d ahead, %d behind)", b.Ahead, b.Behind)),
+		)
+	}
+
+	if !branchesPruneFlag {
+		if len(prunable) > 0 {
+			fmt.Println()
+			ui.Detail("Delete the %d orphaned merged branch(es) with: clade branches --prune", len(prunable))
+		}
+		return nil
+	}
+
+	fmt.Println()
+	if len(prunable) == 0 {
+		ui.Info("No orphaned merged branches to prune")
+		return nil
+	}
+	if branchesDryRunFlag {
+		ui.Info("Would delete %d branch(es) (dry run)", len(prunable))
+		return nil
+	}
+	if !ui.Confirm(fmt.Sprintf("Delete %d orphaned merged branch(es)", len(prunable)), false) {
+		ui.Info("Prune cancelled")
+		return nil
+	}
+
+	deleted := 0
+	for _, b := range prunable {
+		if err := git.DeleteMergedBranch(repoPath, b.Name); err != nil {
+			// git only checks HEAD/upstream, so patch-equivalent merges can
+			// still be refused here
+			ui.Error("%s: %v (if it was squash-merged, run: git branch -D %s)", b.Name, err, b.Name)
+			continue
+		}
+		deleted++
+	}
+	ui.Success("Deleted %d branch(es)", deleted)
+	return nil
+}
+
+// managedBranches lists the repo's local branches using the exp/feat
+// prefixes, sorted by name
+func managedBranches(cfg *config.Config, state *config.State, repoPath string) ([]*managedBranch, error) {
+	names, err := git.ListLocalBranches(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	worktrees, err := git.WorktreeBranches(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := cleanPath(repoPath)
+	tracked := make(map[string]string)
+	for _, exp := range state.Experiments {
+		if cleanPath(exp.Repo) == repo {
+			tracked[exp.Branch] = exp.Name
+		}
+	}
+	for _, proj := range state.Projects {
+		for _, r := range proj.Repos {
+			if cleanPath(r.Source) == repo {
+				tracked[proj.RepoBranch(r)] = proj.Name
+			}
+		}
+	}
+
+	base := defaultBaseRef(cfg, repoPath)
+	prefixes := branchPrefixes(cfg)
+	var branches []*managedBranch
+	for _, name := range names {
+		if !hasAnyPrefix(name, prefixes) {
+			continue
+		}
+		b := &managedBranch{
+			Name:     name,
+			Item:     tracked[name],
+			Worktree: worktrees[name],
+		}
+		b.Ahead, b.Behind, _ = git.AheadBehind(repoPath, base, name)
+		if b.orphaned() {
+			b.Merged = branchMerged(cfg, repoPath, name)
+		}
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
+
+// branchPrefixes returns the configured exp and feat branch prefixes
+func branchPrefixes(cfg *config.Config) []string {
+	var prefixes []string
+	for _, prefix := range []string{cfg.ExpBranchPrefix, cfg.FeatBranchPrefix} {
+		if prefix != "" && !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
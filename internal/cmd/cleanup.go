@@ -4,16 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/files"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
-var cleanupForceFlag bool
+var (
+	cleanupForceFlag     bool
+	cleanupMergedFlag    bool
+	cleanupDryRunFlag    bool
+	cleanupRepoFlag      string
+	cleanupOlderThanFlag string
+)
+
+// cleanupListConfirmed is set once a list of items to remove was confirmed
+// as a whole (bulk cleanup, prune). Their branches are then deleted without
+// asking again, but dirty worktrees and unmerged branches still need --force.
+var cleanupListConfirmed bool
 
 var cleanupCmd = &cobra.Command{
 	Use:   "cleanup [name]",
@@ -23,7 +37,19 @@ var cleanupCmd = &cobra.Command{
 Examples:
   clade cleanup try-redis           # Clean up experiment
   clade cleanup my-project          # Clean up project
-  clade cleanup try-redis --force   # Skip confirmations`,
+  clade cleanup try-redis --force   # Skip confirmations
+  clade cleanup try-redis --merged  # Only if the branch is merged
+  clade cleanup --merged            # Clean up every merged experiment
+  clade cleanup --merged --dry-run  # Show what would be removed
+  clade cleanup --repo backend      # Clean up every experiment from a repo
+  clade cleanup --older-than 14d --dry-run  # Experiments unused for 14+ days
+
+With --repo, --merged, or --older-than and no --force, experiments with
+uncommitted changes or unmerged commits are skipped and listed. The filters
+combine, e.g. --merged --older-than 2w.
+
+With --merged, branches are checked against the default branch (local and
+remote) and deleted with "git branch -d". Unmerged ones are left alone.`,
 	Args:              cobra.MaximumNArgs(1),
 	RunE:              runCleanup,
 	ValidArgsFunction: completeCleanupNames,
@@ -32,6 +58,10 @@ Examples:
 func init() {
 	rootCmd.AddCommand(cleanupCmd)
 	cleanupCmd.Flags().BoolVarP(&cleanupForceFlag, "force", "f", false, "Skip confirmations")
+	cleanupCmd.Flags().BoolVar(&cleanupMergedFlag, "merged", false, "Only clean up if the branch is merged into the default branch")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRunFlag, "dry-run", false, "Show what would be removed without changing anything")
+	cleanupCmd.Flags().StringVarP(&cleanupRepoFlag, "repo", "r", "", "Clean up every experiment from this repo (name or path)")
+	cleanupCmd.Flags().StringVar(&cleanupOlderThanFlag, "older-than", "", "Clean up every experiment unused for this long, e.g. 14d, 2w, or 72h")
 }
 
 func runCleanup(cmd *cobra.Command, args []string) error {
@@ -51,31 +81,53 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	var targetName string
-	if len(args) > 0 {
-		targetName = args[0]
-	} else {
-		// Interactive picker combining experiments, projects, and scratches
-		type pickItem struct {
-			Name string
-			Type string // "experiment", "project", or "scratch"
+	var maxAge time.Duration
+	if cleanupOlderThanFlag != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--older-than cleans up every experiment unused for a while and can't be combined with a name")
 		}
-		var items []pickItem
-		var displayItems []string
+		if maxAge, err = parseAge(cleanupOlderThanFlag); err != nil {
+			return err
+		}
+	}
+
+	if cleanupRepoFlag != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--repo cleans up every experiment from a repo and can't be combined with a name")
+		}
+		repoPath, err := resolveCleanupRepo(cfg, cleanupRepoFlag)
+		if err != nil {
+			return err
+		}
+		return cleanupExperimentsBulk(cfg, state, repoPath, maxAge)
+	}
 
-		for _, exp := range state.Experiments {
-			items = append(items, pickItem{Name: exp.Name, Type: "experiment"})
-			displayItems = append(displayItems, fmt.Sprintf("%s %s", exp.Name, ui.Dim("[exp]")))
+	if (cleanupMergedFlag || maxAge > 0) && len(args) == 0 {
+		return cleanupExperimentsBulk(cfg, state, "", maxAge)
+	}
+
+	var item *resolvedItem
+	if len(args) > 0 {
+		item, err = resolveItem(state, args[0])
+		if err != nil {
+			return err
 		}
-		for _, proj := range state.Projects {
-			items = append(items, pickItem{Name: proj.Name, Type: "project"})
-			displayItems = append(displayItems, fmt.Sprintf("%s %s", proj.Name, ui.Dim("[project]")))
+		if item == nil {
+			return fmt.Errorf("'%s' not found as experiment, project, or scratch", args[0])
 		}
-		for _, scratch := range state.Scratches {
-			items = append(items, pickItem{Name: scratch.Name, Type: "scratch"})
-			displayItems = append(displayItems, fmt.Sprintf("%s %s", scratch.Name, ui.Dim("[scratch]")))
+	} else if cleanupDryRunFlag {
+		return fmt.Errorf("--dry-run needs a name, --merged, --older-than, or --repo")
+	} else {
+		// Interactive picker combining experiments, projects, and scratches
+		items := recentItems(state)
+		var displayItems []string
+		for _, item := range items {
+			displayItems = append(displayItems, fmt.Sprintf("%s %s", item.Name, ui.Dim(item.label())))
 		}
 
+		if err := ui.Interactive("pick what to clean up without a name"); err != nil {
+			return err
+		}
 		prompt := promptui.Select{
 			Label: "Select to clean up",
 			Items: displayItems,
@@ -84,29 +136,38 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		targetName = items[idx].Name
+		item = items[idx]
 	}
 
-	// Try to find as experiment first, then as project, then as scratch
-	for key, exp := range state.Experiments {
-		if exp.Name == targetName {
-			return cleanupExperiment(cfg, state, key, exp)
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		if cleanupMergedFlag {
+			if unmerged := unmergedBranches(cfg, []branchRef{{exp.Name, exp.Repo, exp.Branch}}); len(unmerged) > 0 {
+				return fmt.Errorf("branch %s is not merged into the default branch, leaving '%s' in place", exp.Branch, exp.Name)
+			}
 		}
-	}
-
-	for name, proj := range state.Projects {
-		if proj.Name == targetName {
-			return cleanupProject(cfg, state, name, proj)
+		return cleanupExperiment(cfg, state, item.Key, exp)
+
+	case item.Project != nil:
+		proj := item.Project
+		if cleanupMergedFlag {
+			var refs []branchRef
+			for _, repo := range proj.Repos {
+				refs = append(refs, branchRef{repo.Name, repo.Source, proj.RepoBranch(repo)})
+			}
+			if unmerged := unmergedBranches(cfg, refs); len(unmerged) > 0 {
+				return fmt.Errorf("branch %s is not merged in %s, leaving '%s' in place", projectBranchSummary(proj), strings.Join(unmerged, ", "), proj.Name)
+			}
 		}
-	}
+		return cleanupProject(cfg, state, item.Key, proj)
 
-	for name, scratch := range state.Scratches {
-		if scratch.Name == targetName {
-			return cleanupScratch(cfg, state, name, scratch)
+	default:
+		if cleanupMergedFlag {
+			return fmt.Errorf("--merged doesn't apply to scratch folders")
 		}
+		return cleanupScratch(cfg, state, item.Key, item.Scratch)
 	}
-
-	return fmt.Errorf("'%s' not found as experiment, project, or scratch", targetName)
 }
 
 func cleanupExperiment(cfg *config.Config, state *config.State, key string, exp *config.Experiment) error {
@@ -115,23 +176,27 @@ func cleanupExperiment(cfg *config.Config, state *config.State, key string, exp
 	ui.KeyValue("Branch", exp.Branch)
 	fmt.Println()
 
+	if cleanupDryRunFlag {
+		planCleanupWorktree("worktree", exp.Path)
+		planCleanupBranch(cfg, exp.Repo, exp.Branch, "")
+		return nil
+	}
+
 	// Check for uncommitted changes
 	if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
 		ui.Warn("Uncommitted changes detected")
 
 		if !cleanupForceFlag {
-			prompt := promptui.Prompt{
-				Label:     "Discard changes and continue",
-				IsConfirm: true,
-			}
-			_, err := prompt.Run()
-			if err != nil {
+			if !ui.Confirm("Discard changes and continue", false) {
 				ui.Info("Cleanup cancelled")
 				return nil
 			}
 		}
 	}
 
+	// Measure before removing so we can report the space reclaimed
+	size, sizeErr := files.DirSize(exp.Path)
+
 	// Remove worktree
 	ui.Info("Removing worktree...")
 	if err := git.RemoveWorktree(exp.Repo, exp.Path); err != nil {
@@ -141,21 +206,22 @@ func cleanupExperiment(cfg *config.Config, state *config.State, key string, exp
 		}
 	}
 	ui.Success("Worktree removed")
+	reportReclaimed(size, sizeErr)
 
-	// Ask about branch deletion
-	deleteBranch := cleanupForceFlag
-	if !cleanupForceFlag {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Delete branch %s", exp.Branch),
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		deleteBranch = err == nil
+	// Ask about branch deletion (merged branches are always deleted)
+	deleteBranch := cleanupForceFlag || cleanupMergedFlag || cleanupListConfirmed
+	if !deleteBranch {
+		deleteBranch = ui.Confirm(fmt.Sprintf("Delete branch %s", exp.Branch), false)
+	}
+
+	// --merged already verified the branch is merged
+	if deleteBranch && !cleanupMergedFlag {
+		deleteBranch = confirmUnmergedDelete(cfg, exp.Repo, exp.Branch, "")
 	}
 
 	if deleteBranch {
 		ui.Info("Deleting branch...")
-		if err := git.DeleteBranch(exp.Repo, exp.Branch); err != nil {
+		if err := deleteCleanupBranch(exp.Repo, exp.Branch); err != nil {
 			ui.Warn("Failed to delete branch: %v", err)
 		} else {
 			ui.Success("Branch deleted")
@@ -163,8 +229,11 @@ func cleanupExperiment(cfg *config.Config, state *config.State, key string, exp
 	}
 
 	// Update state
-	state.RemoveExperiment(key)
-	if err := state.Save(cfg); err != nil {
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		s.RemoveExperiment(key)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -175,7 +244,7 @@ func cleanupExperiment(cfg *config.Config, state *config.State, key string, exp
 func cleanupProject(cfg *config.Config, state *config.State, name string, proj *config.Project) error {
 	ui.Header("Project: %s", proj.Name)
 	ui.KeyValue("Path", proj.Path)
-	ui.KeyValue("Branch", proj.Branch)
+	ui.KeyValue("Branch", projectBranchSummary(proj))
 
 	var repoNames []string
 	for _, r := range proj.Repos {
@@ -184,6 +253,16 @@ func cleanupProject(cfg *config.Config, state *config.State, name string, proj *
 	ui.KeyValue("Repos", fmt.Sprintf("%v", repoNames))
 	fmt.Println()
 
+	if cleanupDryRunFlag {
+		for _, repo := range proj.Repos {
+			planCleanupWorktree(repo.Name, filepath.Join(proj.Path, repo.Name))
+		}
+		for _, repo := range proj.Repos {
+			planCleanupBranch(cfg, repo.Source, proj.RepoBranch(repo), repo.Name)
+		}
+		return nil
+	}
+
 	// Check for uncommitted changes in any repo
 	hasAnyChanges := false
 	for _, repo := range proj.Repos {
@@ -195,17 +274,15 @@ func cleanupProject(cfg *config.Config, state *config.State, name string, proj *
 	}
 
 	if hasAnyChanges && !cleanupForceFlag {
-		prompt := promptui.Prompt{
-			Label:     "Discard all changes and continue",
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		if err != nil {
+		if !ui.Confirm("Discard all changes and continue", false) {
 			ui.Info("Cleanup cancelled")
 			return nil
 		}
 	}
 
+	// The project dir holds every repo worktree, so one walk covers them all
+	size, sizeErr := files.DirSize(proj.Path)
+
 	// Remove each worktree
 	ui.Info("Removing worktrees...")
 	for _, repo := range proj.Repos {
@@ -219,22 +296,22 @@ func cleanupProject(cfg *config.Config, state *config.State, name string, proj *
 
 	// Remove project directory
 	os.RemoveAll(proj.Path)
+	reportReclaimed(size, sizeErr)
 
-	// Ask about branch deletion
-	deleteBranch := cleanupForceFlag
-	if !cleanupForceFlag {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Delete branch %s from all repos", proj.Branch),
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		deleteBranch = err == nil
+	// Ask about branch deletion (merged branches are always deleted)
+	deleteBranch := cleanupForceFlag || cleanupMergedFlag
+	if !deleteBranch {
+		deleteBranch = ui.Confirm(fmt.Sprintf("Delete branch %s from all repos", projectBranchSummary(proj)), false)
 	}
 
 	if deleteBranch {
 		ui.Info("Deleting branches...")
 		for _, repo := range proj.Repos {
-			if err := git.DeleteBranch(repo.Source, proj.Branch); err != nil {
+			branch := proj.RepoBranch(repo)
+			if !cleanupMergedFlag && !confirmUnmergedDelete(cfg, repo.Source, branch, repo.Name) {
+				continue
+			}
+			if err := deleteCleanupBranch(repo.Source, branch); err != nil {
 				ui.Warn("Failed to delete branch in %s: %v", repo.Name, err)
 			} else {
 				ui.Success("Deleted branch in %s", repo.Name)
@@ -243,8 +320,11 @@ func cleanupProject(cfg *config.Config, state *config.State, name string, proj *
 	}
 
 	// Update state
-	delete(state.Projects, name)
-	if err := state.Save(cfg); err != nil {
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		delete(s.Projects, name)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -257,6 +337,11 @@ func cleanupScratch(cfg *config.Config, state *config.State, name string, scratc
 	ui.KeyValue("Path", scratch.Path)
 	fmt.Println()
 
+	if cleanupDryRunFlag {
+		planCleanupWorktree("folder", scratch.Path)
+		return nil
+	}
+
 	// Check if directory has any files (warn user)
 	entries, err := os.ReadDir(scratch.Path)
 	if err == nil && len(entries) > 0 {
@@ -267,30 +352,31 @@ func cleanupScratch(cfg *config.Config, state *config.State, name string, scratc
 				fileCount++
 			}
 		}
-		if fileCount > 0 && !cleanupForceFlag {
+		if fileCount > 0 && !cleanupForceFlag && !cleanupListConfirmed {
 			ui.Warn("Scratch folder contains %d file(s)", fileCount)
-			prompt := promptui.Prompt{
-				Label:     "Delete all contents and continue",
-				IsConfirm: true,
-			}
-			_, err := prompt.Run()
-			if err != nil {
+			if !ui.Confirm("Delete all contents and continue", false) {
 				ui.Info("Cleanup cancelled")
 				return nil
 			}
 		}
 	}
 
+	size, sizeErr := files.DirSize(scratch.Path)
+
 	// Remove directory
 	ui.Info("Removing scratch folder...")
 	if err := os.RemoveAll(scratch.Path); err != nil {
 		return fmt.Errorf("failed to remove scratch folder: %w", err)
 	}
 	ui.Success("Folder removed")
+	reportReclaimed(size, sizeErr)
 
 	// Update state
-	state.RemoveScratch(name)
-	if err := state.Save(cfg); err != nil {
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.RemoveScratch(name)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -298,6 +384,297 @@ func cleanupScratch(cfg *config.Config, state *config.State, name string, scratc
 	return nil
 }
 
+// planCleanupWorktree describes what removing a directory would do
+func planCleanupWorktree(label, path string) {
+	if !pathExists(path) {
+		ui.Detail("%s %s: %s %s", ui.Dim("○"), label, path, ui.Dim("(already gone)"))
+		return
+	}
+
+	note := ""
+	if hasChanges, _ := git.HasUncommittedChanges(path); hasChanges {
+		note = " " + ui.Yellow("(uncommitted changes would be lost)")
+	}
+	if size, err := files.DirSize(path); err == nil {
+		note += " " + ui.Dim(formatSize(size))
+	}
+	ui.Detail("%s remove %s: %s%s", ui.Red("✗"), label, path, note)
+}
+
+// planCleanupBranch describes what would happen to a branch
+func planCleanupBranch(cfg *config.Config, repoPath, branch, label string) {
+	if label != "" {
+		label = " in " + label
+	}
+	if !git.RefExists(repoPath, "refs/heads/"+branch) {
+		ui.Detail("%s branch %s%s %s", ui.Dim("○"), branch, label, ui.Dim("(doesn't exist)"))
+		return
+	}
+
+	note := ""
+	if count, unmerged := branchHasUnmergedCommits(cfg, repoPath, branch); unmerged {
+		note = " " + ui.Yellow(fmt.Sprintf("(%d unmerged commit(s) would be lost)", count))
+	}
+
+	switch {
+	case cleanupMergedFlag:
+		ui.Detail("%s delete merged branch %s%s", ui.Red("✗"), branch, label)
+	case cleanupForceFlag:
+		ui.Detail("%s delete branch %s%s%s", ui.Red("✗"), branch, label, note)
+	case note != "":
+		ui.Detail("%s branch %s%s%s %s", ui.Yellow("?"), branch, label, note, ui.Dim("(would ask twice before deleting)"))
+	default:
+		ui.Detail("%s branch %s%s %s", ui.Yellow("?"), branch, label, ui.Dim("(would ask before deleting)"))
+	}
+}
+
+// reportReclaimed prints the disk space freed by a removal. It's skipped
+// when the size couldn't be measured.
+func reportReclaimed(size int64, err error) {
+	if err != nil {
+		return
+	}
+	ui.Success("Reclaimed %s", formatSize(size))
+}
+
+// branchRef identifies a branch in a source repo, labeled for messages
+type branchRef struct {
+	Label  string
+	Repo   string
+	Branch string
+}
+
+// unmergedBranches returns the labels of refs whose branch isn't merged into
+// the repo's default branch. A branch counts as merged if either the local
+// default branch or its remote-tracking branch contains it. Failures to check
+// count as unmerged.
+func unmergedBranches(cfg *config.Config, refs []branchRef) []string {
+	var unmerged []string
+	for _, ref := range refs {
+		if !branchMerged(cfg, ref.Repo, ref.Branch) {
+			unmerged = append(unmerged, ref.Label)
+		}
+	}
+	return unmerged
+}
+
+// branchMerged reports whether branch is merged into the default branch
+func branchMerged(cfg *config.Config, repoPath, branch string) bool {
+	count, err := unmergedCommitCount(cfg, repoPath, branch)
+	return err == nil && count == 0
+}
+
+// branchHasUnmergedCommits reports whether branch has commits that aren't in
+// the default branch, and how many. Returns false if it can't be determined.
+func branchHasUnmergedCommits(cfg *config.Config, repoPath, branch string) (int, bool) {
+	count, err := unmergedCommitCount(cfg, repoPath, branch)
+	if err != nil {
+		return 0, false
+	}
+	return count, count > 0
+}
+
+// unmergedCommitCount counts commits on branch missing from the default
+// branch. Both the local default branch and its remote-tracking branch are
+// checked, and the smaller count wins.
+func unmergedCommitCount(cfg *config.Config, repoPath, branch string) (int, error) {
+	defaultBranch := git.GetDefaultBranch(repoPath, cfg.Remote)
+	best := -1
+	for _, target := range []string{defaultBranch, cfg.Remote + "/" + defaultBranch} {
+		if !git.RefExists(repoPath, target) {
+			continue
+		}
+		count, err := git.UnmergedCommitCount(repoPath, branch, target)
+		if err != nil {
+			continue
+		}
+		if best < 0 || count < best {
+			best = count
+		}
+	}
+	if best < 0 {
+		return 0, fmt.Errorf("can't compare %s with %s", branch, defaultBranch)
+	}
+	return best, nil
+}
+
+// confirmUnmergedDelete warns when branch has unmerged commits and, unless
+// --force, asks for a second confirmation. Returns whether to delete it.
+func confirmUnmergedDelete(cfg *config.Config, repoPath, branch, label string) bool {
+	count, unmerged := branchHasUnmergedCommits(cfg, repoPath, branch)
+	if !unmerged {
+		return true
+	}
+
+	if label != "" {
+		label = " (" + label + ")"
+	}
+	fmt.Println()
+	ui.Warn("%s", ui.Bold(fmt.Sprintf("Branch %s%s has %d commit(s) not merged into %s", branch, label, count, git.GetDefaultBranch(repoPath, cfg.Remote))))
+	if cleanupForceFlag {
+		return true
+	}
+
+	if !ui.Confirm(fmt.Sprintf("Delete %s anyway (%d commit(s) will be lost)", branch, count), false) {
+		ui.Info("Keeping branch %s", branch)
+		return false
+	}
+	return true
+}
+
+// deleteCleanupBranch deletes a branch, using the safe "git branch -d" when
+// --merged was given
+func deleteCleanupBranch(repoPath, branch string) error {
+	if !cleanupMergedFlag {
+		return git.DeleteBranch(repoPath, branch)
+	}
+	if err := git.DeleteMergedBranch(repoPath, branch); err != nil {
+		// git only checks HEAD/upstream, so patch-equivalent merges can
+		// still be refused here
+		return fmt.Errorf("%w (if it was squash-merged, run: git branch -D %s)", err, branch)
+	}
+	return nil
+}
+
+// cleanupExperimentsBulk cleans up every experiment matching the filters
+// (--repo, --merged, and/or --older-than as maxAge) after a single
+// confirmation, and reports the ones that were skipped. Without --force,
+// experiments with uncommitted changes or unmerged commits are skipped.
+func cleanupExperimentsBulk(cfg *config.Config, state *config.State, repoPath string, maxAge time.Duration) error {
+	switch {
+	case repoPath != "" && cleanupMergedFlag:
+		ui.Header("Checking merged experiments from %s...", git.GetRepoName(repoPath))
+	case repoPath != "":
+		ui.Header("Experiments from %s:", git.GetRepoName(repoPath))
+	case cleanupMergedFlag:
+		ui.Header("Checking experiments for merged branches...")
+	default:
+		ui.Header("Experiments unused for %s or more:", cleanupOlderThanFlag)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	keys := make([]string, 0, len(state.Experiments))
+	for key, exp := range state.Experiments {
+		if repoPath != "" && cleanPath(exp.Repo) != cleanPath(repoPath) {
+			continue
+		}
+		if maxAge > 0 && !exp.LastUsed.Before(cutoff) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return state.Experiments[keys[i]].Name < state.Experiments[keys[j]].Name
+	})
+
+	// Fetch each source repo once so remote-tracking branches are current
+	fetched := make(map[string]bool)
+	var selected []string
+	var skipped []string
+	for _, key := range keys {
+		exp := state.Experiments[key]
+
+		if cleanupMergedFlag {
+			if !git.IsSourceRepo(exp.Repo) {
+				skipped = append(skipped, fmt.Sprintf("%s (source repo missing)", exp.Name))
+				continue
+			}
+			if !fetched[exp.Repo] {
+				if err := git.Fetch(exp.Repo, cfg.Remote); err != nil {
+					ui.Warn("%s: fetch %s", git.GetRepoName(exp.Repo), describeFetchError(err))
+				}
+				fetched[exp.Repo] = true
+			}
+			if !branchMerged(cfg, exp.Repo, exp.Branch) {
+				skipped = append(skipped, fmt.Sprintf("%s (unmerged commits on %s)", exp.Name, exp.Branch))
+				ui.Detail("%s %s %s", ui.Yellow("○"), exp.Name, ui.Dim("(unmerged)"))
+				continue
+			}
+		}
+
+		var risks []string
+		if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
+			risks = append(risks, "uncommitted changes")
+		}
+		if count, unmerged := branchHasUnmergedCommits(cfg, exp.Repo, exp.Branch); unmerged && !cleanupMergedFlag {
+			risks = append(risks, fmt.Sprintf("%d unmerged commit(s) on %s", count, exp.Branch))
+		}
+		note := ""
+		if len(risks) > 0 {
+			reason := strings.Join(risks, ", ")
+			if !cleanupForceFlag {
+				skipped = append(skipped, fmt.Sprintf("%s (%s)", exp.Name, reason))
+				ui.Detail("%s %s %s", ui.Yellow("○"), exp.Name, ui.Yellow("("+reason+", skipping)"))
+				continue
+			}
+			note = " " + ui.Yellow("("+reason+")")
+		}
+		selected = append(selected, key)
+		ui.Detail("%s %s %s%s", ui.Green("✓"), exp.Name, ui.Dim("("+exp.Branch+")"), note)
+	}
+
+	if len(selected) == 0 {
+		fmt.Println()
+		ui.Info("No matching experiments to clean up")
+		printCleanupSkipped(skipped)
+		return nil
+	}
+
+	if !cleanupForceFlag && !cleanupDryRunFlag {
+		fmt.Println()
+		if !ui.Confirm(fmt.Sprintf("Remove %d experiment(s) and their branches", len(selected)), false) {
+			ui.Info("Cleanup cancelled")
+			return nil
+		}
+	}
+	// The list was confirmed as a whole, so don't ask about each branch
+	cleanupListConfirmed = true
+
+	for _, key := range selected {
+		fmt.Println()
+		if err := cleanupExperiment(cfg, state, key, state.Experiments[key]); err != nil {
+			ui.Error("%s: %v", state.Experiments[key].Name, err)
+		}
+	}
+
+	fmt.Println()
+	if cleanupDryRunFlag {
+		ui.Info("Would clean up %d experiment(s) (dry run)", len(selected))
+	} else {
+		ui.Success("Cleaned up %d experiment(s)", len(selected))
+	}
+	printCleanupSkipped(skipped)
+	return nil
+}
+
+// printCleanupSkipped lists the experiments a bulk cleanup left alone
+func printCleanupSkipped(skipped []string) {
+	if len(skipped) == 0 {
+		return
+	}
+	ui.Info("Skipped %d:", len(skipped))
+	for _, line := range skipped {
+		ui.Detail("%s", line)
+	}
+	switch {
+	case cleanupForceFlag:
+	case cleanupMergedFlag:
+		ui.Detail("Pass --force to include experiments with uncommitted changes")
+	default:
+		ui.Detail("Pass --force to include experiments with uncommitted changes or unmerged commits")
+	}
+}
+
+// resolveCleanupRepo turns --repo into a path. Unlike resolveRepoPath it
+// accepts paths that no longer exist, so experiments from a deleted repo
+// can still be cleaned up.
+func resolveCleanupRepo(cfg *config.Config, input string) (string, error) {
+	if path, err := resolveRepoPath(cfg, input); err == nil {
+		return path, nil
+	}
+	return filepath.Abs(config.ExpandPath(input))
+}
+
 // completeCleanupNames provides shell completion for experiment/project/scratch names
 func completeCleanupNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) != 0 {
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestCleanupOlderThanDryRun(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &cleanupDryRunFlag, false)
+	setFlag(t, &cleanupOlderThanFlag, "")
+	setFlag(t, &cleanupForceFlag, false)
+	setFlag(t, &cleanupMergedFlag, false)
+	setFlag(t, &cleanupListConfirmed, false)
+	repo := newTestRepo(t, "api")
+	for _, name := range []string{"stale", "fresh"} {
+		if code := executeArgs(t, "exp", name, "-r", repo, "-b", "exp/"+name, "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+			t.Fatalf("exp %s exited %d", name, code)
+		}
+	}
+	staleKey := config.ExperimentKey(repo, "stale")
+	if err := config.UpdateState(cfg, func(s *config.State) error {
+		s.Experiments[staleKey].LastUsed = time.Now().Add(-30 * 24 * time.Hour)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// --force includes the worktrees' untracked .clade.json changes
+	out := captureStdout(t, func() {
+		if code := executeArgs(t, "--color", "never", "cleanup", "--older-than", "14d", "--dry-run", "--force"); code != 0 {
+			t.Errorf("cleanup exited %d", code)
+		}
+	})
+	if !strings.Contains(out, "stale") || strings.Contains(out, "fresh") {
+		t.Errorf("want only the stale experiment in the plan:\n%s", out)
+	}
+	if !strings.Contains(out, "Would clean up 1 experiment(s) (dry run)") {
+		t.Errorf("missing the dry-run summary:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ExperimentsDir(), staleKey)); err != nil {
+		t.Errorf("dry run removed the worktree: %v", err)
+	}
+
+	if code := executeArgs(t, "cleanup", "stale", "--older-than", "14d"); code == 0 {
+		t.Error("--older-than was accepted with a name")
+	}
+	if code := executeArgs(t, "cleanup", "--older-than", "soon", "--dry-run"); code == 0 {
+		t.Error("an invalid age was accepted")
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change clade configuration",
+	Long: `View and change clade configuration without editing config.json by hand.
+
+Examples:
+  clade config list
+  clade config get editor
+  clade config set editor cursor
+  clade config set auto_init false`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             "Print a config value",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigGet,
+	ValidArgsFunction: completeConfigKeys,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set <key> <value>",
+	Short:             "Set a config value",
+	Args:              cobra.ExactArgs(2),
+	RunE:              runConfigSet,
+	ValidArgsFunction: completeConfigKeys,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show all config values and the config file path",
+	RunE:  runConfigList,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	setting, ok := config.LookupSetting(args[0])
+	if !ok {
+		return unknownConfigKeyError(args[0])
+	}
+
+	// Print bare value so it can be used in scripts
+	fmt.Println(setting.Get(cfg))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	key, value := args[0], args[1]
+
+	setting, ok := config.LookupSetting(key)
+	if !ok {
+		return unknownConfigKeyError(key)
+	}
+
+	if err := setting.Set(cfg, value); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	ui.Success("Set %s = %s", key, setting.Get(cfg))
+	return nil
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	ui.Header("Configuration:")
+	ui.KeyValue("File", configPath)
+	fmt.Println()
+
+	for _, setting := range config.Settings {
+		value := setting.Get(cfg)
+		if value == "" {
+			value = ui.Dim("(not set)")
+		}
+		if setting.Key == "base_dir" && os.Getenv(config.BaseDirEnv) != "" {
+			value += ui.Dim(fmt.Sprintf(" (overridden by %s=%s)", config.BaseDirEnv, cfg.GetBaseDir()))
+		}
+		fmt.Printf("  %-22s %s\n", ui.Cyan(setting.Key), value)
+	}
+
+	fmt.Println()
+	ui.Detail("%d registered repos (see: clade repo list)", len(cfg.Repos))
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Println()
+		ui.Warn("Invalid config: %v", err)
+	}
+
+	return nil
+}
+
+func unknownConfigKeyError(key string) error {
+	return fmt.Errorf("unknown config key '%s' (valid keys: %s)", key, strings.Join(config.SettingKeys(), ", "))
+}
+
+// completeConfigKeys provides shell completion for config keys
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var keys []string
+	for _, setting := range config.Settings {
+		keys = append(keys, setting.Key+"\t"+setting.Description)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var diffBranchFlag bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Show a file-level summary of an item's changes",
+	Long: `Show which files changed in an experiment or project, without cd-ing in.
+
+By default this is the uncommitted work (staged and unstaged) in the
+worktree. With --branch it is everything the branch changes compared to the
+default branch, committed or not yet pushed. Untracked files aren't counted.
+
+Examples:
+  clade diff try-redis
+  clade diff try-redis --branch
+  clade diff my-project`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runDiff,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().BoolVarP(&diffBranchFlag, "branch", "b", false, "Compare the branch with the default branch instead of showing uncommitted changes")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		return printDiff(cfg, exp.Name, exp.Path, exp.Branch)
+
+	case item.Project != nil:
+		proj := item.Project
+		for _, repo := range proj.Repos {
+			if err := printDiff(cfg, repo.Name, filepath.Join(proj.Path, repo.Name), proj.RepoBranch(repo)); err != nil {
+				ui.Warn("%s: %v", repo.Name, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("'%s' is a scratch, which has no git history", name)
+}
+
+// printDiff prints the diffstat for one worktree
+func printDiff(cfg *config.Config, label, path, branch string) error {
+	if !pathExists(path) {
+		return fmt.Errorf("path no longer exists: %s", path)
+	}
+
+	var stats []git.DiffStat
+	var err error
+	if diffBranchFlag {
+		base := defaultBaseRef(cfg, path)
+		ui.Header("%s: %s %s", label, branch, ui.Dim("(vs "+base+")"))
+		stats, err = git.GetDiffStat(path, base+"..."+branch)
+	} else {
+		ui.Header("%s: %s", label, ui.Dim("uncommitted changes"))
+		stats, err = git.GetDiffStat(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to diff: %w", err)
+	}
+
+	printDiffStat(stats)
+	return nil
+}
+
+// printDiffStat prints a "git diff --stat" style summary
+func printDiffStat(stats []git.DiffStat) {
+	if len(stats) == 0 {
+		ui.Detail("No changes")
+		return
+	}
+
+	width := 0
+	for _, s := range stats {
+		if len(s.File) > width {
+			width = len(s.File)
+		}
+	}
+
+	added, removed := 0, 0
+	for _, s := range stats {
+		change := ui.Dim("binary")
+		if !s.Binary {
+			change = fmt.Sprintf("%s %s", ui.Green(fmt.Sprintf("+%d", s.Added)), ui.Red(fmt.Sprintf("-%d", s.Removed)))
+			added += s.Added
+			removed += s.Removed
+		}
+		fmt.Printf("  %-*s | %s\n", width, s.File, change)
+	}
+	ui.Detail("%d file(s) changed, %d insertion(s)(+), %d deletion(s)(-)", len(stats), added, removed)
+}
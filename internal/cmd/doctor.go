@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var doctorFixFlag bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Find state entries and worktrees that are out of sync",
+	Long: `Cross-check clade's state against the filesystem and git.
+
+Reports:
+  - Experiments, projects, and scratches whose paths no longer exist
+  - Worktrees under the clade base dir that clade isn't tracking
+  - Worktree registrations in git whose directories are gone
+  - Tracked experiments whose branch no longer exists
+
+With --fix, dead state entries are removed and "git worktree prune" is run
+in each source repo.
+
+Examples:
+  clade doctor         # Report problems
+  clade doctor --fix   # Report and repair`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFixFlag, "fix", false, "Prune dead state entries and stale worktree registrations")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	problems := 0
+
+	// 1. State entries whose paths are gone
+	var deadExps, deadProjects, deadScratches []string
+	for key, exp := range state.Experiments {
+		if !pathExists(exp.Path) {
+			deadExps = append(deadExps, key)
+		}
+	}
+	for key, proj := range state.Projects {
+		if !pathExists(proj.Path) {
+			deadProjects = append(deadProjects, key)
+		}
+	}
+	for key, scratch := range state.Scratches {
+		if !pathExists(scratch.Path) {
+			deadScratches = append(deadScratches, key)
+		}
+	}
+
+	if len(deadExps)+len(deadProjects)+len(deadScratches) > 0 {
+		ui.Header("Missing paths:")
+		for _, key := range sortedStrings(deadExps) {
+			ui.Warn("experiment %s: %s", state.Experiments[key].Name, state.Experiments[key].Path)
+		}
+		for _, key := range sortedStrings(deadProjects) {
+			ui.Warn("project %s: %s", state.Projects[key].Name, state.Projects[key].Path)
+		}
+		for _, key := range sortedStrings(deadScratches) {
+			ui.Warn("scratch %s: %s", state.Scratches[key].Name, state.Scratches[key].Path)
+		}
+		problems += len(deadExps) + len(deadProjects) + len(deadScratches)
+	}
+
+	// Worktree paths clade knows about, and the repos they came from
+	tracked := make(map[string]bool)
+	sourceRepos := make(map[string]bool)
+	for _, exp := range state.Experiments {
+		tracked[cleanPath(exp.Path)] = true
+		sourceRepos[exp.Repo] = true
+	}
+	var missingProjectRepos []string
+	for _, proj := range state.Projects {
+		for _, repo := range proj.Repos {
+			repoPath := filepath.Join(proj.Path, repo.Name)
+			tracked[cleanPath(repoPath)] = true
+			sourceRepos[repo.Source] = true
+			if pathExists(proj.Path) && !pathExists(repoPath) {
+				missingProjectRepos = append(missingProjectRepos, fmt.Sprintf("%s/%s: %s", proj.Name, repo.Name, repoPath))
+			}
+		}
+	}
+	for _, path := range cfg.Repos {
+		sourceRepos[config.ExpandPath(path)] = true
+	}
+
+	if len(missingProjectRepos) > 0 {
+		ui.Header("Missing project repos:")
+		for _, line := range sortedStrings(missingProjectRepos) {
+			ui.Warn("%s", line)
+		}
+		problems += len(missingProjectRepos)
+	}
+
+	// 2. Worktrees git knows about that clade doesn't (or that are gone)
+	baseDir := cleanPath(cfg.GetBaseDir())
+	var untracked, stale, missingRepos []string
+	var reposToPrune []string
+	for _, repoPath := range sortedKeys(sourceRepos) {
+		if !git.IsSourceRepo(repoPath) {
+			missingRepos = append(missingRepos, repoPath)
+			continue
+		}
+		reposToPrune = append(reposToPrune, repoPath)
+
+		worktrees, err := git.ListWorktrees(repoPath)
+		if err != nil {
+			continue
+		}
+		for _, wt := range worktrees {
+			wtPath := cleanPath(wt)
+			if !pathExists(wt) {
+				stale = append(stale, fmt.Sprintf("%s %s", wt, ui.Dim("("+filepath.Base(repoPath)+")")))
+				continue
+			}
+			if strings.HasPrefix(wtPath, baseDir+string(filepath.Separator)) && !tracked[wtPath] {
+				untracked = append(untracked, fmt.Sprintf("%s %s", wt, ui.Dim("("+filepath.Base(repoPath)+")")))
+			}
+		}
+	}
+
+	if len(missingRepos) > 0 {
+		ui.Header("Source repos not found:")
+		for _, repoPath := range missingRepos {
+			ui.Warn("%s", repoPath)
+		}
+		problems += len(missingRepos)
+	}
+
+	if len(untracked) > 0 {
+		ui.Header("Worktrees not tracked by clade:")
+		for _, line := range untracked {
+			ui.Warn("%s", line)
+		}
+		problems += len(untracked)
+	}
+
+	if len(stale) > 0 {
+		ui.Header("Stale worktree registrations:")
+		for _, line := range stale {
+			ui.Warn("%s", line)
+		}
+		problems += len(stale)
+	}
+
+	// 3. Tracked experiments whose branch is gone
+	var missingBranches []string
+	for _, exp := range state.Experiments {
+		if !git.IsSourceRepo(exp.Repo) {
+			continue
+		}
+		if git.CheckBranch(exp.Repo, cfg.Remote, exp.Branch).Status == git.BranchNotFound {
+			missingBranches = append(missingBranches, fmt.Sprintf("%s: %s", exp.Name, exp.Branch))
+		}
+	}
+	if len(missingBranches) > 0 {
+		ui.Header("Missing branches:")
+		for _, line := range sortedStrings(missingBranches) {
+			ui.Warn("%s", line)
+		}
+		problems += len(missingBranches)
+	}
+
+	fmt.Println()
+	if problems == 0 {
+		ui.Success("No problems found")
+		return nil
+	}
+
+	if !doctorFixFlag {
+		ui.Info("Found %d problem(s)", problems)
+		ui.Detail("Run 'clade doctor --fix' to prune dead entries and stale worktrees")
+		return nil
+	}
+
+	// Fix: drop dead state entries and prune git's worktree records
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		for _, key := range deadExps {
+			s.RemoveExperiment(key)
+		}
+		for _, key := range deadProjects {
+			delete(s.Projects, key)
+		}
+		for _, key := range deadScratches {
+			s.RemoveScratch(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	if removed := len(deadExps) + len(deadProjects) + len(deadScratches); removed > 0 {
+		ui.Success("Removed %d dead state entries", removed)
+	}
+
+	for _, repoPath := range reposToPrune {
+		if err := git.PruneWorktrees(repoPath); err != nil {
+			ui.Warn("%s: %v", filepath.Base(repoPath), err)
+		}
+	}
+	if len(stale) > 0 {
+		ui.Success("Pruned stale worktree registrations")
+	}
+
+	if len(untracked)+len(missingBranches)+len(missingRepos)+len(missingProjectRepos) > 0 {
+		ui.Info("Remaining problems need manual attention")
+	}
+
+	return nil
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// cleanPath normalizes a path for comparison, resolving symlinks when possible
+func cleanPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+func sortedStrings(values []string) []string {
+	sort.Strings(values)
+	return values
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dropForceFlag    bool
+	dropSnapshotFlag bool
+	dropAutoFlag     bool
+)
+
+var dropCmd = &cobra.Command{
+	Use:   "drop",
+	Short: "Write a DROPBAG.md template to fill in by hand",
+	Long: `Write the DROPBAG.md template used by the /drop command into the current
+worktree, without going through the agent.
+
+The file goes in the repo root (or the current directory outside git).
+An existing DROPBAG.md is kept unless --force is given, or archived first
+when dropbag_history is enabled. With --snapshot, the
+current branch and git status are appended.
+
+--auto is meant for a Stop hook (see "clade init --with-stop-hook"). It never
+touches what you or Claude wrote: it keeps an auto-generated snapshot of git
+status, recent commits, and TODOs at the end of DROPBAG.md and replaces it on
+each run, creating the file if needed.
+
+Examples:
+  clade drop
+  clade drop --snapshot
+  clade drop --force
+  clade drop --auto`,
+	Args: cobra.NoArgs,
+	RunE: runDrop,
+}
+
+func init() {
+	rootCmd.AddCommand(dropCmd)
+	dropCmd.Flags().BoolVarP(&dropForceFlag, "force", "f", false, "Overwrite an existing DROPBAG.md")
+	dropCmd.Flags().BoolVarP(&dropSnapshotFlag, "snapshot", "s", false, "Append the current branch and git status")
+	dropCmd.Flags().BoolVar(&dropAutoFlag, "auto", false, "Refresh an auto-generated snapshot at the end of DROPBAG.md (for hooks)")
+}
+
+func runDrop(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	dir := cwd
+	if git.IsGitRepo(cwd) {
+		if root, err := git.GetRepoRoot(cwd); err == nil {
+			dir = root
+		}
+	}
+
+	path := filepath.Join(dir, "DROPBAG.md")
+	if dropAutoFlag {
+		return writeAutoDropbag(dir, path)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		// With history on, the old DROPBAG.md is archived rather than lost
+		if cfg, err := config.Load(); err == nil && cfg.DropbagHistory {
+			archived, err := context.ArchiveDropbag(dir)
+			if err != nil {
+				return fmt.Errorf("failed to archive DROPBAG.md: %w", err)
+			}
+			if archived != "" {
+				ui.Detail("Archived previous DROPBAG.md to %s", archived)
+			}
+		} else if !dropForceFlag {
+			return fmt.Errorf("DROPBAG.md already exists in %s (use --force to overwrite)", dir)
+		}
+	}
+
+	content := "# DROPBAG\n\n" + context.DropbagTemplate
+	if dropSnapshotFlag {
+		if !git.IsGitRepo(dir) {
+			ui.Warn("Not a git repository, skipping snapshot")
+		} else if snapshot, err := context.GitSnapshot(dir); err != nil {
+			ui.Warn("Failed to capture git status: %v", err)
+		} else {
+			content += "\n" + snapshot
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write DROPBAG.md: %w", err)
+	}
+
+	ui.Success("Wrote %s", path)
+	ui.Detail("Fill in each section before you stop")
+	return nil
+}
+
+// writeAutoDropbag refreshes the auto snapshot section of DROPBAG.md
+func writeAutoDropbag(dir, path string) error {
+	snapshot, err := context.AutoSnapshot(dir)
+	if err != nil {
+		return fmt.Errorf("failed to gather context: %w", err)
+	}
+
+	var existing string
+	if data, err := os.ReadFile(path); err == nil {
+		existing = string(data)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read DROPBAG.md: %w", err)
+	}
+	if existing == "" {
+		existing = "# DROPBAG\n"
+	}
+
+	content := context.ReplaceAutoSnapshot(existing, snapshot)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write DROPBAG.md: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/files"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+)
+
+// createPlan describes what a create command would do (used by --dry-run)
+type createPlan struct {
+	Type         string        `json:"type"`
+	Name         string        `json:"name"`
+	Repo         string        `json:"repo,omitempty"`
+	Path         string        `json:"path"`
+	Branch       string        `json:"branch,omitempty"`
+	Base         string        `json:"base,omitempty"`
+	BranchStatus string        `json:"branch_status,omitempty"`
+	Ticket       string        `json:"ticket,omitempty"`
+	ClaudeConfig string        `json:"claude_config"` // "copy", "init", or "none"
+	CopyFiles    []string      `json:"copy_files,omitempty"`
+	CopyPrompt   bool          `json:"copy_prompt,omitempty"` // true if files would be prompted for
+	Repos        []plannedRepo `json:"repos,omitempty"`
+	Errors       []string      `json:"errors,omitempty"`
+}
+
+// plannedRepo describes a single repo within a planned project
+type plannedRepo struct {
+	Name         string   `json:"name"`
+	Source       string   `json:"source"`
+	Path         string   `json:"path"`
+	Branch       string   `json:"branch,omitempty"` // Only set when it differs from the project branch
+	BranchStatus string   `json:"branch_status"`
+	ClaudeConfig string   `json:"claude_config"`
+	CopyFiles    []string `json:"copy_files,omitempty"`
+	CopyPrompt   bool     `json:"copy_prompt,omitempty"`
+}
+
+// alreadyTracked is the plan error for an item that already exists. The
+// other checks (branch, path) only repeat it, so it replaces them.
+func alreadyTracked(itemType, name, path string) []string {
+	return []string{fmt.Sprintf("%s '%s' already exists at %s (resume it instead)", itemType, name, path)}
+}
+
+// planWorktree builds the plan for a single-repo experiment or feature
+func planWorktree(cfg *config.Config, itemType, name, repoPath, path, branch, base string) *createPlan {
+	plan := &createPlan{
+		Type:   itemType,
+		Name:   name,
+		Repo:   repoPath,
+		Path:   path,
+		Branch: branch,
+		Ticket: extractTicket(name),
+	}
+
+	info := git.CheckBranch(repoPath, cfg.Remote, branch)
+	plan.BranchStatus = branchStatusLabel(info)
+	if info.Status != git.BranchNotFound {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("branch '%s' already exists", branch))
+	}
+	if _, err := os.Stat(path); err == nil {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("path already exists: %s", path))
+	}
+	if base != "" {
+		git.Fetch(repoPath, cfg.Remote) // Ignore error - might be offline
+		resolved, err := git.ResolveBaseRef(repoPath, cfg.Remote, base)
+		if err != nil {
+			plan.Errors = append(plan.Errors, err.Error())
+		}
+		plan.Base = resolved
+	}
+
+	plan.ClaudeConfig = planClaudeConfig(cfg, repoPath, true)
+	plan.CopyFiles, plan.CopyPrompt = planCopyFiles(cfg, repoPath)
+
+	return plan
+}
+
+// planProject builds the plan for a multi-repo project
+func planProject(cfg *config.Config, name, path, branch string, repos []projectRepo, results map[string]git.BranchInfo) *createPlan {
+	plan := &createPlan{
+		Type:   "project",
+		Name:   name,
+		Path:   path,
+		Branch: branch,
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("path already exists: %s", path))
+	}
+
+	for _, repo := range repos {
+		copyFiles, prompt := planCopyFiles(cfg, repo.SourcePath)
+		plan.Repos = append(plan.Repos, plannedRepo{
+			Name:         repo.FolderName,
+			Source:       repo.SourcePath,
+			Path:         filepath.Join(path, repo.FolderName),
+			Branch:       repo.Branch,
+			BranchStatus: branchStatusLabel(results[repo.SourcePath]),
+			ClaudeConfig: planClaudeConfig(cfg, repo.SourcePath, false),
+			CopyFiles:    copyFiles,
+			CopyPrompt:   prompt,
+		})
+	}
+
+	return plan
+}
+
+// planClaudeConfig reports how .claude/ would be set up in a new worktree
+func planClaudeConfig(cfg *config.Config, repoPath string, copyFromSource bool) string {
+	if copyFromSource {
+		if _, err := os.Stat(filepath.Join(repoPath, ".claude")); err == nil {
+			return "copy"
+		}
+	}
+	if cfg.AutoInit {
+		return "init"
+	}
+	return "none"
+}
+
+// planCopyFiles reports which gitignored files would be copied and whether
+// the user would be prompted for them
+func planCopyFiles(cfg *config.Config, repoPath string) ([]string, bool) {
+	if saved := cfg.GetRepoCopyFiles(repoPath); saved != nil {
+		return saved, false
+	}
+	detected := files.FindGitignored(repoPath)
+	return detected, len(detected) > 0
+}
+
+// branchStatusLabel describes where a branch exists
+func branchStatusLabel(info git.BranchInfo) string {
+	switch info.Status {
+	case git.BranchLocalOnly:
+		return "exists locally"
+	case git.BranchRemoteOnly:
+		return "exists on remote"
+	case git.BranchBoth:
+		return "exists locally and on remote"
+	default:
+		return "new"
+	}
+}
+
+// printCreatePlan prints a dry-run plan as human-readable text or JSON
+func printCreatePlan(plan *createPlan, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+
+	ui.Header("Dry run: would create %s '%s'", plan.Type, plan.Name)
+	if plan.Repo != "" {
+		ui.KeyValue("Repo", plan.Repo)
+	}
+	ui.KeyValue("Path", plan.Path)
+	if plan.Branch != "" && plan.BranchStatus != "" {
+		ui.KeyValue("Branch", fmt.Sprintf("%s (%s)", plan.Branch, plan.BranchStatus))
+	} else if plan.Branch != "" {
+		ui.KeyValue("Branch", plan.Branch)
+	}
+	if plan.Base != "" {
+		ui.KeyValue("Base", plan.Base)
+	}
+	if plan.Ticket != "" {
+		ui.KeyValue("Ticket", plan.Ticket)
+	}
+	if plan.Type != "project" {
+		ui.KeyValue(".claude/", claudeConfigLabel(plan.ClaudeConfig))
+		if plan.Repo != "" {
+			printPlannedCopyFiles(plan.CopyFiles, plan.CopyPrompt)
+		}
+	}
+
+	for _, repo := range plan.Repos {
+		fmt.Println()
+		fmt.Printf("  %s %s\n", ui.Cyan(repo.Name), ui.Dim("("+repo.Source+")"))
+		ui.KeyValue("Path", repo.Path)
+		if repo.Branch != "" {
+			ui.KeyValue("Branch", fmt.Sprintf("%s (%s)", repo.Branch, repo.BranchStatus))
+		} else {
+			ui.KeyValue("Branch", repo.BranchStatus)
+		}
+		ui.KeyValue(".claude/", claudeConfigLabel(repo.ClaudeConfig))
+		printPlannedCopyFiles(repo.CopyFiles, repo.CopyPrompt)
+	}
+
+	if len(plan.Errors) > 0 {
+		fmt.Println()
+		for _, e := range plan.Errors {
+			ui.Error("%s", e)
+		}
+	}
+
+	fmt.Println()
+	ui.Info("Nothing was created (dry run)")
+	return nil
+}
+
+func printPlannedCopyFiles(copyFiles []string, prompt bool) {
+	if len(copyFiles) == 0 {
+		ui.KeyValue("Copy files", "none")
+		return
+	}
+	label := strings.Join(copyFiles, ", ")
+	if prompt {
+		label += ui.Dim(" (would prompt)")
+	}
+	ui.KeyValue("Copy files", label)
+}
+
+func claudeConfigLabel(mode string) string {
+	switch mode {
+	case "copy":
+		return "copy from source repo"
+	case "init":
+		return "initialize"
+	default:
+		return "skip"
+	}
+}
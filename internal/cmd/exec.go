@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var execRepoFlag string
+
+var execCmd = &cobra.Command{
+	Use:   "exec <name> -- <command> [args...]",
+	Short: "Run a command inside an experiment, project, or scratch",
+	Long: `Run a command with the worktree as the working directory.
+
+For projects the command runs in the first repo unless --repo picks another.
+clade exits with the command's exit code.
+
+Examples:
+  clade exec try-redis -- npm test
+  clade exec try-redis -- git log --oneline -5
+  clade exec my-project --repo frontend -- pnpm install`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              runExec,
+	ValidArgsFunction: completeResumableNames,
+	SilenceUsage:      true,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	execCmd.Flags().StringVar(&execRepoFlag, "repo", "", "Repo folder within a project (default: first repo)")
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash != 1 {
+		return fmt.Errorf("usage: clade exec <name> -- <command> [args...]")
+	}
+	name, command := args[0], args[1:]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	workdir, err := execWorkdir(item, execRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	err = runInDir(workdir, command)
+	if _, ok := err.(*exitCodeError); ok {
+		// The child already reported its own failure
+		cmd.SilenceErrors = true
+	}
+	return err
+}
+
+// execWorkdir picks the directory to run in, resolving --repo for projects
+func execWorkdir(item *resolvedItem, repoFolder string) (string, error) {
+	if item.Project == nil {
+		if repoFolder != "" {
+			return "", fmt.Errorf("--repo only applies to projects")
+		}
+		return item.Path, nil
+	}
+
+	proj := item.Project
+	if len(proj.Repos) == 0 {
+		return proj.Path, nil
+	}
+	if repoFolder == "" {
+		return filepath.Join(proj.Path, proj.Repos[0].Name), nil
+	}
+	for _, repo := range proj.Repos {
+		if repo.Name == repoFolder {
+			return filepath.Join(proj.Path, repo.Name), nil
+		}
+	}
+	return "", fmt.Errorf("repo '%s' not found in project '%s'", repoFolder, proj.Name)
+}
+
+// runInDir runs a command attached to the terminal, passing its exit code through
+func runInDir(workdir string, command []string) error {
+	if _, err := os.Stat(workdir); os.IsNotExist(err) {
+		return fmt.Errorf("path no longer exists: %s", workdir)
+	}
+
+	c := exec.Command(command[0], command[1:]...)
+	c.Dir = workdir
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &exitCodeError{code: exitErr.ExitCode()}
+	}
+	return err
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/daniil-lyalko/clade/internal/agent"
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
 	"github.com/daniil-lyalko/clade/internal/files"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
@@ -25,6 +28,13 @@ var (
 	expEditorFlag   string
 	expNoAgentFlag  bool
 	expNoEditorFlag bool
+	expDryRunFlag   bool
+	expOfflineFlag  bool
+	expFromFlag     string
+	expSymlinkFlag  bool
+	expNoSetupFlag  bool
+	expJSONFlag     bool
+	expForceFlag    bool
 )
 
 var expCmd = &cobra.Command{
@@ -40,10 +50,13 @@ Examples:
   clade exp foo -b custom/branch   # Custom branch name
   clade exp foo -o cursor          # Open Cursor IDE
   clade exp foo --no-agent         # Skip launching Claude
+  clade exp foo --dry-run          # Show what would be created
+  clade exp foo --offline          # Branch from local HEAD, skip fetch
+  clade exp foo --from v2.0        # Branch from another branch or ref
 
 The experiment creates:
-  - A new worktree at ~/clade/experiments/{repo}-{name}/
-  - A branch (default: exp/{name}, or custom with -b)
+  - A new worktree at ~/clade/experiments/{repo}-{hash}-{name}/
+  - A branch (default: exp/{name} or exp_branch_prefix, or custom with -b)
   - Copies .claude/ config from the source repo`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runExp,
@@ -58,6 +71,13 @@ func init() {
 	expCmd.Flags().StringVarP(&expEditorFlag, "editor", "e", "", "Alias for --open")
 	expCmd.Flags().BoolVar(&expNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
 	expCmd.Flags().BoolVar(&expNoEditorFlag, "no-editor", false, "Skip opening the editor")
+	expCmd.Flags().BoolVar(&expDryRunFlag, "dry-run", false, "Show what would be created without creating anything")
+	expCmd.Flags().BoolVar(&expJSONFlag, "json", false, "Print the dry-run plan as JSON")
+	expCmd.Flags().BoolVar(&expOfflineFlag, "offline", false, "Skip fetching and branch from local HEAD (no remote/divergence info)")
+	expCmd.Flags().StringVar(&expFromFlag, "from", "", "Branch, tag, or commit to start from (default: the remote's default branch)")
+	expCmd.Flags().BoolVar(&expNoSetupFlag, "no-setup", false, "Skip the repo's setup_command")
+	expCmd.Flags().BoolVar(&expForceFlag, "force", false, "Replace a leftover directory at the worktree path without asking")
+	expCmd.Flags().BoolVar(&expSymlinkFlag, "symlink", false, "Symlink gitignored files to the source repo instead of copying")
 }
 
 func runExp(cmd *cobra.Command, args []string) error {
@@ -66,15 +86,19 @@ func runExp(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if expOfflineFlag {
+		git.SetOffline(true)
+	}
+	if expSymlinkFlag {
+		files.SetSymlink(true)
+	}
+
 	// Get experiment name
 	var expName string
 	if len(args) > 0 {
 		expName = args[0]
 	} else {
-		prompt := promptui.Prompt{
-			Label: "Experiment name",
-		}
-		expName, err = prompt.Run()
+		expName, err = ui.Input("Experiment name", "")
 		if err != nil {
 			return err
 		}
@@ -92,9 +116,11 @@ func runExp(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update last used repo
-	cfg.LastRepo = repoPath
-	if err := cfg.Save(); err != nil {
-		ui.Warn("Failed to save config: %v", err)
+	if !expDryRunFlag {
+		cfg.LastRepo = repoPath
+		if err := cfg.Save(); err != nil {
+			ui.Warn("Failed to save config: %v", err)
+		}
 	}
 
 	repoName := git.GetRepoName(repoPath)
@@ -106,12 +132,8 @@ func runExp(cmd *cobra.Command, args []string) error {
 	if expBranchFlag != "" {
 		branch = expBranchFlag
 	} else {
-		defaultBranch := "exp/" + expName
-		prompt := promptui.Prompt{
-			Label:   "Branch name",
-			Default: defaultBranch,
-		}
-		branch, err = prompt.Run()
+		defaultBranch := cfg.ExpBranchPrefix + expName
+		branch, err = ui.Input("Branch name", defaultBranch)
 		if err != nil {
 			return err
 		}
@@ -127,21 +149,29 @@ func runExp(cmd *cobra.Command, args []string) error {
 	}
 
 	if existing := state.GetExperiment(expKey); existing != nil {
+		if expDryRunFlag {
+			plan := planWorktree(cfg, "experiment", expName, repoPath, existing.Path, existing.Branch, expFromFlag)
+			plan.Errors = alreadyTracked("experiment", expName, existing.Path)
+			return printCreatePlan(plan, expJSONFlag)
+		}
 		ui.Warn("Experiment '%s' already exists", expName)
 		ui.KeyValue("Path", existing.Path)
 
-		prompt := promptui.Prompt{
-			Label:     "Resume existing experiment",
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		if err == nil {
+		if ui.Confirm("Resume existing experiment", false) {
 			// User wants to resume
 			return launchSession(cfg, existing.Path, expEditorFlag, expNoAgentFlag, expNoEditorFlag)
 		}
 		return nil
 	}
 
+	if err := checkNameAvailable(state, expName, "experiment"); err != nil {
+		return err
+	}
+
+	if expDryRunFlag {
+		return printCreatePlan(planWorktree(cfg, "experiment", expName, repoPath, expPath, branch, expFromFlag), expJSONFlag)
+	}
+
 	// Create experiment directory
 	ui.Header("Creating experiment: %s", expName)
 	ui.KeyValue("Repo", repoName)
@@ -153,19 +183,51 @@ func runExp(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create experiments directory: %w", err)
 	}
 
+	// A crashed earlier run can leave the worktree behind without state
+	adopt, err := checkExistingWorktree(repoPath, expPath, branch, expForceFlag)
+	if err != nil {
+		return err
+	}
+	if adopt {
+		if _, err := adoptWorktree(cfg, "experiment", expName, repoPath, expPath, branch); err != nil {
+			return err
+		}
+		ui.Success("Experiment adopted!")
+		return launchSession(cfg, expPath, expEditorFlag, expNoAgentFlag, expNoEditorFlag)
+	}
+
 	// Check if branch already exists (local or remote)
 	ui.Info("Checking branch availability...")
-	branchInfo := git.CheckBranch(repoPath, branch)
+	branchInfo := git.CheckBranch(repoPath, cfg.Remote, branch)
 	if branchInfo.Status != git.BranchNotFound {
+		if path, ok := git.BranchCheckedOutAt(repoPath, branch); ok {
+			return branchCheckedOutError(repoPath, branch, path)
+		}
 		ui.Error("Branch '%s' already exists", branch)
 		ui.Detail("Use: clade resume %s", expName)
 		ui.Detail("Or pick a different name")
 		return fmt.Errorf("branch already exists")
 	}
 
-	// Create worktree with new branch from origin's default
+	// Make sure the base exists before creating anything
+	var base string
+	if expFromFlag != "" {
+		git.Fetch(repoPath, cfg.Remote) // Ignore error - might be offline
+		base, err = git.ResolveBaseRef(repoPath, cfg.Remote, expFromFlag)
+		if err != nil {
+			return err
+		}
+		ui.KeyValue("Base", base)
+	}
+
+	// Create worktree with new branch from the base or the remote's default
 	ui.Info("Creating worktree...")
-	if err := git.CreateWorktreeNew(repoPath, expPath, branch); err != nil {
+	if base != "" {
+		err = git.CreateWorktreeFromBase(repoPath, expPath, branch, base)
+	} else {
+		err = git.CreateWorktreeNew(repoPath, cfg.Remote, expPath, branch)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
@@ -173,7 +235,7 @@ func runExp(cmd *cobra.Command, args []string) error {
 	sourceClaudeDir := filepath.Join(repoPath, ".claude")
 	if _, err := os.Stat(sourceClaudeDir); err == nil {
 		ui.Info("Copying .claude/ configuration...")
-		if err := copyDir(sourceClaudeDir, filepath.Join(expPath, ".claude")); err != nil {
+		if err := files.CopyDir(sourceClaudeDir, filepath.Join(expPath, ".claude")); err != nil {
 			ui.Warn("Failed to copy .claude/ directory: %v", err)
 		}
 	} else if cfg.AutoInit {
@@ -189,6 +251,11 @@ func runExp(cmd *cobra.Command, args []string) error {
 		ui.Warn("Failed to copy some files: %v", err)
 	}
 
+	// Run the repo's setup command (pnpm install, direnv allow, ...)
+	if !expNoSetupFlag {
+		runSetupCommand(cfg, repoPath, expPath, "")
+	}
+
 	// Create .clade.json metadata
 	ticket := extractTicket(expName)
 	cladeMetadata := map[string]interface{}{
@@ -198,6 +265,9 @@ func runExp(cmd *cobra.Command, args []string) error {
 		"repo":    repoName,
 		"created": time.Now().Format(time.RFC3339),
 	}
+	if base != "" {
+		cladeMetadata["base"] = base
+	}
 	if err := writeJSON(filepath.Join(expPath, ".clade.json"), cladeMetadata); err != nil {
 		ui.Warn("Failed to write .clade.json: %v", err)
 	}
@@ -212,8 +282,11 @@ func runExp(cmd *cobra.Command, args []string) error {
 		Created:  time.Now(),
 		LastUsed: time.Now(),
 	}
-	state.AddExperiment(exp)
-	if err := state.Save(cfg); err != nil {
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.AddExperiment(exp)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -232,8 +305,8 @@ func resolveRepo(cfg *config.Config, repoFlag string) (string, error) {
 		}
 		// Assume it's a path
 		expanded := config.ExpandPath(repoFlag)
-		if git.IsGitRepo(expanded) {
-			return git.GetRepoRoot(expanded)
+		if git.IsSourceRepo(expanded) {
+			return git.GetRepoRootOrBare(expanded)
 		}
 		return "", fmt.Errorf("not a git repository: %s", repoFlag)
 	}
@@ -243,8 +316,8 @@ func resolveRepo(cfg *config.Config, repoFlag string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if git.IsGitRepo(cwd) {
-		return git.GetRepoRoot(cwd)
+	if git.IsSourceRepo(cwd) {
+		return git.GetRepoRootOrBare(cwd)
 	}
 
 	// 3. Check if there are registered repos
@@ -269,6 +342,9 @@ func resolveRepo(cfg *config.Config, repoFlag string) (string, error) {
 		}
 	}
 
+	if err := ui.Interactive("pick a repo without --repo"); err != nil {
+		return "", err
+	}
 	prompt := promptui.Select{
 		Label: "Select repo",
 		Items: repoNames,
@@ -299,8 +375,8 @@ func showRepoPicker(cfg *config.Config) (string, error) {
 	var currentRepoPath string
 
 	cwd, err := os.Getwd()
-	if err == nil && git.IsGitRepo(cwd) {
-		currentRepoPath, _ = git.GetRepoRoot(cwd)
+	if err == nil && git.IsSourceRepo(cwd) {
+		currentRepoPath, _ = git.GetRepoRootOrBare(cwd)
 		repoNames = append(repoNames, "(current directory)")
 	}
 
@@ -323,6 +399,9 @@ func showRepoPicker(cfg *config.Config) (string, error) {
 		return "", fmt.Errorf("no repos available. Register repos with: clade repo add <path>")
 	}
 
+	if err := ui.Interactive("pick a repo without --repo"); err != nil {
+		return "", err
+	}
 	prompt := promptui.Select{
 		Label: "Select repo",
 		Items: repoNames,
@@ -341,18 +420,101 @@ func showRepoPicker(cfg *config.Config) (string, error) {
 	return config.ExpandPath(cfg.Repos[selected]), nil
 }
 
-// launchSession opens editor and/or launches agent based on config and flags
-func launchSession(cfg *config.Config, workdir string, editorOverride string, noAgent bool, noEditor bool) error {
-	// Determine editor to use
+// agentExitError makes clade exit with the agent's own exit code when it
+// fails. The agent has already shown its error, so clade doesn't repeat it.
+func agentExitError(err error) error {
+	var exitErr *agent.ExitError
+	if errors.As(err, &exitErr) {
+		return silentExit(exitErr.Code)
+	}
+	return err
+}
+
+// resolveEditor picks the editor to open: the override, then the configured
+// editor, then $VISUAL/$EDITOR. "none" disables the editor.
+func resolveEditor(cfg *config.Config, editorOverride string) string {
 	editor := cfg.Editor
 	if editorOverride != "" {
 		editor = editorOverride
 	}
+	if editor == "" {
+		editor = agent.DetectEditor()
+	}
+	if editor == "none" {
+		return ""
+	}
+	return editor
+}
+
+// sessionEnv describes the item in workdir to the agent through CLADE_*
+// environment variables, taken from its .clade.json. Unknown values are set
+// empty so nothing leaks in from an enclosing clade session.
+func sessionEnv(workdir string) map[string]string {
+	env := map[string]string{
+		"CLADE_PATH":   workdir,
+		"CLADE_NAME":   "",
+		"CLADE_TYPE":   "",
+		"CLADE_TICKET": "",
+		"CLADE_REPO":   "",
+		"CLADE_BRANCH": "",
+	}
+	if metadata, err := context.ReadCladeMetadata(workdir); err == nil {
+		env["CLADE_NAME"] = metadata.Name
+		env["CLADE_TYPE"] = metadata.Type
+		env["CLADE_TICKET"] = metadata.Ticket
+		env["CLADE_REPO"] = metadata.Repo
+	}
+	if branch, err := git.GetCurrentBranch(workdir); err == nil {
+		env["CLADE_BRANCH"] = branch
+	}
+	return env
+}
+
+// sessionRepo returns the source repo of the item in workdir: the main repo
+// of a worktree, or the repo a scratch is linked to. Empty if there is none.
+func sessionRepo(workdir string) string {
+	if root, err := git.GetMainRepoRoot(workdir); err == nil {
+		return root
+	}
+	if metadata, err := context.ReadCladeMetadata(workdir); err == nil {
+		return metadata.LinkedRepo
+	}
+	return ""
+}
+
+// repoSessionConfig returns cfg with the agent and editor replaced by
+// repoPath's repo_settings, where set. cfg itself is left untouched.
+func repoSessionConfig(cfg *config.Config, repoPath string) *config.Config {
+	if repoPath == "" {
+		return cfg
+	}
+	agentCmd, editor := cfg.GetRepoAgent(repoPath), cfg.GetRepoEditor(repoPath)
+	if agentCmd == "" && editor == "" {
+		return cfg
+	}
+
+	session := *cfg
+	if agentCmd != "" {
+		session.Agent = agentCmd
+	}
+	if editor != "" {
+		session.Editor = editor
+	}
+	return &session
+}
+
+// launchSession opens editor and/or launches agent based on config and flags
+func launchSession(cfg *config.Config, workdir string, editorOverride string, noAgent bool, noEditor bool) error {
+	cfg = repoSessionConfig(cfg, sessionRepo(workdir))
+	editor := resolveEditor(cfg, editorOverride)
 
 	// Open editor first (if configured and not disabled)
 	if !noEditor && editor != "" {
 		opts := agent.EditorOptions{
 			TmuxSplitDirection: cfg.TmuxSplitDirection,
+			TmuxSplitPercent:   cfg.TmuxSplitPercent,
+			TmuxMode:           cfg.TmuxEditorMode,
+			WindowName:         filepath.Base(workdir),
 		}
 		if err := agent.OpenEditor(workdir, editor, opts); err != nil {
 			ui.Warn("Could not open editor: %s", err)
@@ -367,16 +529,96 @@ func launchSession(cfg *config.Config, workdir string, editorOverride string, no
 		ui.Info("Launching %s...", cfg.Agent)
 		fmt.Println()
 
-		ag := agent.NewAgent(cfg.Agent)
+		ag := agent.NewAgent(cfg)
 		opts := agent.LaunchOptions{
 			Flags: cfg.AgentFlags,
+			Env:   sessionEnv(workdir),
 		}
-		return ag.Launch(workdir, opts)
+		return agentExitError(ag.Launch(workdir, opts))
 	}
 
 	return nil
 }
 
+// checkNameAvailable rejects a name already used by an item of another type.
+// Items of the same type are told apart by their keys (experiments in
+// different repos may share a name), but resume, open, and cleanup look items
+// up by name and can't tell an experiment from a same-named project.
+func checkNameAvailable(state *config.State, name, itemType string) error {
+	if other, exists := state.NameUsedByOtherType(name, itemType); exists {
+		return fmt.Errorf("name '%s' is already taken by a tracked %s (a %s can't share its name with another type of item)", name, other, itemType)
+	}
+	return nil
+}
+
+// checkExistingWorktree handles a directory left at a new worktree's path by
+// an earlier run that never made it into state. It returns true if the user
+// chose to adopt it as-is; otherwise the path is clear to create on. Deleting
+// the directory needs a yes at the prompt or force.
+func checkExistingWorktree(repoPath, path, branch string, force bool) (bool, error) {
+	if !pathExists(path) {
+		return false, nil
+	}
+
+	if root, err := git.GetMainRepoRoot(path); err == nil && cleanPath(root) == cleanPath(repoPath) {
+		if current, err := git.GetCurrentBranch(path); err == nil && current == branch {
+			ui.Warn("An untracked worktree for %s already exists at %s", branch, path)
+			if ui.Confirm("Adopt it", false) {
+				return true, nil
+			}
+			return false, fmt.Errorf("path already exists: %s", path)
+		}
+	}
+
+	ui.Warn("%s already exists but isn't a worktree of %s on %s", path, git.GetRepoName(repoPath), branch)
+	if !force && !ui.Confirm("Delete it and create a fresh worktree", false) {
+		return false, fmt.Errorf("path already exists: %s (remove it or pass --force to replace it)", path)
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	// Forget the worktree if git still has it registered
+	git.PruneWorktrees(repoPath)
+	return false, nil
+}
+
+// adoptWorktree tracks an existing worktree as an experiment or feature,
+// writing .clade.json if it's missing
+func adoptWorktree(cfg *config.Config, itemType, name, repoPath, path, branch string) (*config.Experiment, error) {
+	ticket := extractTicket(name)
+	if _, err := context.ReadCladeMetadata(path); err != nil {
+		cladeMetadata := map[string]interface{}{
+			"type":    itemType,
+			"name":    name,
+			"ticket":  ticket,
+			"repo":    git.GetRepoName(repoPath),
+			"created": time.Now().Format(time.RFC3339),
+		}
+		if err := writeJSON(filepath.Join(path, ".clade.json"), cladeMetadata); err != nil {
+			ui.Warn("Failed to write .clade.json: %v", err)
+		}
+	}
+
+	exp := &config.Experiment{
+		Name:     name,
+		Repo:     repoPath,
+		Path:     path,
+		Branch:   branch,
+		Ticket:   ticket,
+		Created:  time.Now(),
+		LastUsed: time.Now(),
+	}
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		s.AddExperiment(exp)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save state: %w", err)
+	}
+	return exp, nil
+}
+
 func isValidExpName(name string) bool {
 	if name == "" {
 		return false
@@ -385,39 +627,64 @@ func isValidExpName(name string) bool {
 	return matched
 }
 
-// extractTicket extracts a JIRA-style ticket ID from the experiment name
-func extractTicket(name string) string {
-	// Match patterns like PROJ-1234, ABC-123, etc.
-	re := regexp.MustCompile(`^([A-Z]+-\d+)`)
-	matches := re.FindStringSubmatch(strings.ToUpper(name))
-	if len(matches) > 1 {
-		return matches[1]
+// unsafePathChars matches runs of characters that don't belong in a single
+// directory name
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// sanitizeForPath turns a name or branch (e.g. "feat/a/b") into a single
+// safe directory component ("feat-a-b"). Git branches keep their real names.
+func sanitizeForPath(name string) string {
+	safe := unsafePathChars.ReplaceAllString(name, "-")
+	safe = strings.Trim(safe, "-.")
+	if safe == "" {
+		return "unnamed"
 	}
-	return ""
+	return safe
 }
 
-func copyDir(src, dst string) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// branchCheckedOutError explains that a branch is already live in another
+// worktree, instead of surfacing git's raw "already checked out" failure
+func branchCheckedOutError(repoPath, branch, path string) error {
+	ui.Error("Branch '%s' is already checked out at %s", branch, path)
+	ui.Detail("Work there instead: cd %s", path)
+	ui.Detail("Or let clade track it: clade import -r %s", repoPath)
+	return fmt.Errorf("branch '%s' already checked out at %s", branch, path)
+}
 
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
-		}
-		dstPath := filepath.Join(dst, relPath)
+// ticketPattern finds ticket IDs in item names (see ticket_pattern)
+var ticketPattern = regexp.MustCompile(config.DefaultTicketPattern)
 
-		if info.IsDir() {
-			return os.MkdirAll(dstPath, info.Mode())
-		}
+// legacyTicketPattern keeps lowercase names like proj-1234-fix working as
+// they did before ticket_pattern: a ticket at the start, uppercased
+var legacyTicketPattern = regexp.MustCompile(`^([A-Z]+-\d+)`)
 
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return err
+// setTicketPattern switches to a configured pattern. Invalid patterns are
+// rejected by config validation, so they're ignored here.
+func setTicketPattern(pattern string) {
+	if pattern == "" {
+		pattern = config.DefaultTicketPattern
+	}
+	if re, err := regexp.Compile(pattern); err == nil {
+		ticketPattern = re
+	}
+}
+
+// extractTicket finds a ticket ID (e.g. PROJ-1234) anywhere in an item
+// name, keeping its case. The first capture group is the ticket if the
+// pattern has one, otherwise the whole match.
+func extractTicket(name string) string {
+	if matches := ticketPattern.FindStringSubmatch(name); matches != nil {
+		if len(matches) > 1 {
+			return matches[1]
 		}
-		return os.WriteFile(dstPath, data, info.Mode())
-	})
+		return matches[0]
+	}
+	if ticketPattern.String() == config.DefaultTicketPattern {
+		if matches := legacyTicketPattern.FindStringSubmatch(strings.ToUpper(name)); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
 }
 
 func writeJSON(path string, data interface{}) error {
@@ -458,10 +725,6 @@ func copyGitignoredFiles(cfg *config.Config, srcRepo, dstPath string) error {
 
 	fmt.Println()
 	ui.Info("Found gitignored files in source repo:")
-	for _, f := range detected {
-		ui.Detail("  %s", f)
-	}
-	fmt.Println()
 
 	// Interactive selection
 	selected, err := selectFilesToCopy(detected)
@@ -489,30 +752,84 @@ func copyGitignoredFiles(cfg *config.Config, srcRepo, dstPath string) error {
 	return nil
 }
 
-// selectFilesToCopy shows an interactive prompt to select files
+// runSetupCommand runs the repo's setup_command in a new worktree. Failures
+// are reported as warnings so they never abort creation.
+func runSetupCommand(cfg *config.Config, srcRepo, worktreePath, label string) {
+	command := cfg.GetRepoSetupCommand(srcRepo)
+	if command == "" {
+		return
+	}
+
+	if label != "" {
+		label = label + ": "
+	}
+	fmt.Println()
+	ui.Info("%sRunning setup: %s", label, command)
+
+	c := exec.Command("sh", "-c", command)
+	c.Dir = worktreePath
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		ui.Warn("%sSetup command failed: %v", label, err)
+		return
+	}
+	ui.Success("%sSetup complete", label)
+}
+
+// selectFilesToCopy shows a checklist of detected files. Every file starts
+// selected; picking a file toggles it and picking the first entry confirms.
 func selectFilesToCopy(detected []string) ([]string, error) {
-	// Simple approach: show list and ask which to copy
-	// For now, use a yes/no per file approach
+	if ui.AssumeYes() {
+		return detected, nil
+	}
 
-	var selected []string
+	checked := make([]bool, len(detected))
+	for i := range checked {
+		checked[i] = true
+	}
 
-	for _, file := range detected {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Copy %s", file),
-			IsConfirm: true,
-			Default:   "y",
+	ui.Detail("These preferences will be saved for future experiments from this repo.")
+
+	cursor := 0
+	for {
+		count := 0
+		items := make([]string, 0, len(detected)+1)
+		items = append(items, "") // confirm entry, filled in below
+		for i, file := range detected {
+			mark := "[ ]"
+			if checked[i] {
+				mark = "[x]"
+				count++
+			}
+			items = append(items, fmt.Sprintf("%s %s", mark, file))
 		}
-		_, err := prompt.Run()
-		if err == nil {
-			selected = append(selected, file)
+		items[0] = fmt.Sprintf("Done - copy %d file(s)", count)
+
+		prompt := promptui.Select{
+			Label:     "Toggle files to copy",
+			Items:     items,
+			Size:      len(items),
+			CursorPos: cursor,
 		}
-	}
 
-	// Ask if user wants to save this preference
-	if len(detected) > 0 {
-		fmt.Println()
-		ui.Detail("These preferences will be saved for future experiments from this repo.")
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		if idx == 0 {
+			break
+		}
+		checked[idx-1] = !checked[idx-1]
+		cursor = idx
 	}
 
+	var selected []string
+	for i, file := range detected {
+		if checked[i] {
+			selected = append(selected, file)
+		}
+	}
 	return selected, nil
 }
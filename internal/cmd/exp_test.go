@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestCheckNameAvailableOnlyRejectsOtherTypes(t *testing.T) {
+	state := &config.State{
+		Experiments: map[string]*config.Experiment{},
+		Projects:    map[string]*config.Project{},
+		Scratches:   map[string]*config.Scratch{},
+	}
+	state.AddExperiment(&config.Experiment{Name: "try-redis", Repo: "/src/api"})
+	state.Projects["platform"] = &config.Project{Name: "platform"}
+	state.AddScratch(&config.Scratch{Name: "notes"})
+
+	tests := []struct {
+		name, itemType string
+		wantTakenBy    string
+	}{
+		// Same-named experiment in another repo gets its own key
+		{"try-redis", "experiment", ""},
+		{"try-redis", "project", "experiment"},
+		{"try-redis", "scratch", "experiment"},
+		{"platform", "experiment", "project"},
+		{"platform", "project", ""},
+		{"notes", "experiment", "scratch"},
+		{"notes", "scratch", ""},
+		{"fresh", "project", ""},
+	}
+	for _, tt := range tests {
+		err := checkNameAvailable(state, tt.name, tt.itemType)
+		if tt.wantTakenBy == "" {
+			if err != nil {
+				t.Errorf("%s %q: unexpected error %v", tt.itemType, tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "tracked "+tt.wantTakenBy) {
+			t.Errorf("%s %q: got %v, want it rejected as taken by a %s", tt.itemType, tt.name, err, tt.wantTakenBy)
+		}
+	}
+}
+
+func TestResolveEditorFallsBackToEnvironment(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nvim")
+
+	tests := []struct {
+		configured, override string
+		want                 string
+	}{
+		{"", "", "nvim"},       // auto-detected from $EDITOR
+		{"none", "", ""},       // explicit sentinel disables it
+		{"code", "", "code"},   // config beats the environment
+		{"code", "zed", "zed"}, // -o beats config
+		{"none", "cursor", "cursor"},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Editor: tt.configured}
+		if got := resolveEditor(cfg, tt.override); got != tt.want {
+			t.Errorf("editor %q, override %q: got %q, want %q", tt.configured, tt.override, got, tt.want)
+		}
+	}
+}
+
+// resetExpFlags restores the exp flags cobra sets during a test run
+func resetExpFlags(t *testing.T) {
+	t.Helper()
+	setFlag(t, &expRepoFlag, "")
+	setFlag(t, &expBranchFlag, "")
+	setFlag(t, &expFromFlag, "")
+	setFlag(t, &expNoAgentFlag, false)
+	setFlag(t, &expNoEditorFlag, false)
+	setFlag(t, &expNoSetupFlag, false)
+	setFlag(t, &expDryRunFlag, false)
+	setFlag(t, &expJSONFlag, false)
+}
+
+func TestExpFromBranchesOffAnotherBase(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	repo := newTestRepo(t, "api")
+	runTestGit(t, repo, "checkout", "-q", "-b", "release")
+	writeTestFile(t, filepath.Join(repo, "release.txt"), "1.0\n")
+	runTestGit(t, repo, "add", "-A")
+	runTestGit(t, repo, "commit", "-q", "-m", "release work")
+	releaseHead := runTestGit(t, repo, "rev-parse", "HEAD")
+	runTestGit(t, repo, "checkout", "-q", "main")
+
+	args := []string{"exp", "spike", "-r", repo, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"}
+	if code := executeArgs(t, append(args, "--from", "nope")...); code == 0 {
+		t.Fatal("exp accepted a base that doesn't exist")
+	}
+	expPath := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(repo, "spike"))
+	if _, err := os.Stat(expPath); err == nil {
+		t.Fatal("a worktree was created for a missing base")
+	}
+
+	if code := executeArgs(t, append(args, "--from", "release")...); code != 0 {
+		t.Fatalf("exp --from release exited %d", code)
+	}
+	if got := runTestGit(t, expPath, "rev-parse", "HEAD"); got != releaseHead {
+		t.Errorf("worktree starts at %s, want release %s", got, releaseHead)
+	}
+	if got := runTestGit(t, expPath, "rev-parse", "--abbrev-ref", "HEAD"); got != "exp/spike" {
+		t.Errorf("worktree is on %s, want exp/spike", got)
+	}
+
+	var metadata map[string]interface{}
+	data, err := os.ReadFile(filepath.Join(expPath, ".clade.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata["base"] != "release" {
+		t.Errorf(".clade.json base = %v, want release", metadata["base"])
+	}
+}
+
+func TestExpInReposWithSameBasenameDoNotCollide(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	work := newTestRepo(t, "api")
+	personal := newTestRepo(t, "api")
+
+	for _, repo := range []string{work, personal} {
+		code := executeArgs(t, "exp", "spike", "-r", repo, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup")
+		if code != 0 {
+			t.Fatalf("exp in %s exited %d", repo, code)
+		}
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Experiments) != 2 {
+		t.Fatalf("got %d experiments, want 2: %v", len(state.Experiments), state.Experiments)
+	}
+	a := state.GetExperiment(config.ExperimentKey(work, "spike"))
+	b := state.GetExperiment(config.ExperimentKey(personal, "spike"))
+	if a == nil || b == nil {
+		t.Fatalf("experiments aren't stored under their repo's key: %v", state.Experiments)
+	}
+	if a.Path == b.Path {
+		t.Fatalf("both experiments live at %s", a.Path)
+	}
+	if got := runTestGit(t, a.Path, "rev-parse", "--git-common-dir"); !strings.HasPrefix(got, work) {
+		t.Errorf("%s belongs to %s, want %s", a.Path, got, work)
+	}
+	if got := runTestGit(t, b.Path, "rev-parse", "--git-common-dir"); !strings.HasPrefix(got, personal) {
+		t.Errorf("%s belongs to %s, want %s", b.Path, got, personal)
+	}
+}
+
+func TestExtractTicket(t *testing.T) {
+	t.Cleanup(func() { setTicketPattern(config.DefaultTicketPattern) })
+
+	tests := []struct {
+		pattern, name, want string
+	}{
+		{"", "PROJ-1234-fix-login", "PROJ-1234"},
+		{"", "fix-PROJ-1234", "PROJ-1234"}, // mid-string
+		{"", "ENG-12", "ENG-12"},
+		{"", "proj-99-cleanup", "PROJ-99"}, // lowercase prefix still matches
+		{"", "try-redis", ""},
+		{`gh-(\d+)`, "fix-gh-1234-crash", "1234"},
+		{`gh-(\d+)`, "PROJ-1234-fix", ""},         // the default no longer applies
+		{`[A-Za-z]+#\d+`, "fix-Api#42", "Api#42"}, // no group: whole match, case kept
+	}
+	for _, tt := range tests {
+		setTicketPattern(tt.pattern)
+		if got := extractTicket(tt.name); got != tt.want {
+			t.Errorf("pattern %q, name %q: got %q, want %q", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExpDryRunOnExistingExperimentOnlyReports(t *testing.T) {
+	setupTestEnv(t)
+	resetExpFlags(t)
+	repo := newTestRepo(t, "api")
+	args := []string{"exp", "spike", "-r", repo, "-b", "exp/spike", "--no-setup"}
+	if code := executeArgs(t, append(args, "--no-agent", "--no-editor")...); code != 0 {
+		t.Fatalf("exp exited %d", code)
+	}
+	// Keep a resume prompt from waiting on stdin; the old code asked one
+	// and printed no plan
+	t.Setenv("CLADE_YES", "1")
+
+	out := captureStdout(t, func() {
+		if code := executeArgs(t, append(args, "--dry-run", "--json")...); code != 0 {
+			t.Errorf("exp --dry-run exited %d", code)
+		}
+	})
+	var plan createPlan
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("output isn't a JSON plan: %v\n%s", err, out)
+	}
+	if len(plan.Errors) != 1 || !strings.Contains(plan.Errors[0], "experiment 'spike' already exists") {
+		t.Errorf("plan errors = %q, want the experiment reported as existing", plan.Errors)
+	}
+}
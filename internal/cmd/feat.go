@@ -10,7 +10,6 @@ import (
 	"github.com/daniil-lyalko/clade/internal/files"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +20,13 @@ var (
 	featEditorFlag   string
 	featNoAgentFlag  bool
 	featNoEditorFlag bool
+	featDryRunFlag   bool
+	featOfflineFlag  bool
+	featFromFlag     string
+	featSymlinkFlag  bool
+	featNoSetupFlag  bool
+	featJSONFlag     bool
+	featForceFlag    bool
 )
 
 var featCmd = &cobra.Command{
@@ -39,10 +45,13 @@ Examples:
   clade feat foo -b custom/branch  # Custom branch name
   clade feat foo -o cursor         # Open Cursor IDE
   clade feat foo --no-agent        # Skip launching Claude
+  clade feat foo --dry-run         # Show what would be created
+  clade feat foo --offline         # Branch from local HEAD, skip fetch
+  clade feat foo --from v2.0       # Branch from another branch or ref
 
 The feature creates:
-  - A new worktree at ~/clade/experiments/{repo}-{name}/
-  - A branch (default: feat/{name}, or custom with -b)
+  - A new worktree at ~/clade/experiments/{repo}-{hash}-{name}/
+  - A branch (default: feat/{name} or feat_branch_prefix, or custom with -b)
   - Copies .claude/ config from the source repo`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runFeat,
@@ -57,6 +66,13 @@ func init() {
 	featCmd.Flags().StringVarP(&featEditorFlag, "editor", "e", "", "Alias for --open")
 	featCmd.Flags().BoolVar(&featNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
 	featCmd.Flags().BoolVar(&featNoEditorFlag, "no-editor", false, "Skip opening the editor")
+	featCmd.Flags().BoolVar(&featDryRunFlag, "dry-run", false, "Show what would be created without creating anything")
+	featCmd.Flags().BoolVar(&featJSONFlag, "json", false, "Print the dry-run plan as JSON")
+	featCmd.Flags().BoolVar(&featOfflineFlag, "offline", false, "Skip fetching and branch from local HEAD (no remote/divergence info)")
+	featCmd.Flags().StringVar(&featFromFlag, "from", "", "Branch, tag, or commit to start from (default: the remote's default branch)")
+	featCmd.Flags().BoolVar(&featNoSetupFlag, "no-setup", false, "Skip the repo's setup_command")
+	featCmd.Flags().BoolVar(&featForceFlag, "force", false, "Replace a leftover directory at the worktree path without asking")
+	featCmd.Flags().BoolVar(&featSymlinkFlag, "symlink", false, "Symlink gitignored files to the source repo instead of copying")
 }
 
 func runFeat(cmd *cobra.Command, args []string) error {
@@ -65,15 +81,19 @@ func runFeat(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if featOfflineFlag {
+		git.SetOffline(true)
+	}
+	if featSymlinkFlag {
+		files.SetSymlink(true)
+	}
+
 	// Get feature name
 	var featName string
 	if len(args) > 0 {
 		featName = args[0]
 	} else {
-		prompt := promptui.Prompt{
-			Label: "Feature name",
-		}
-		featName, err = prompt.Run()
+		featName, err = ui.Input("Feature name", "")
 		if err != nil {
 			return err
 		}
@@ -91,9 +111,11 @@ func runFeat(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update last used repo
-	cfg.LastRepo = repoPath
-	if err := cfg.Save(); err != nil {
-		ui.Warn("Failed to save config: %v", err)
+	if !featDryRunFlag {
+		cfg.LastRepo = repoPath
+		if err := cfg.Save(); err != nil {
+			ui.Warn("Failed to save config: %v", err)
+		}
 	}
 
 	repoName := git.GetRepoName(repoPath)
@@ -106,12 +128,8 @@ func runFeat(cmd *cobra.Command, args []string) error {
 	if featBranchFlag != "" {
 		branch = featBranchFlag
 	} else {
-		defaultBranch := "feat/" + featName
-		prompt := promptui.Prompt{
-			Label:   "Branch name",
-			Default: defaultBranch,
-		}
-		branch, err = prompt.Run()
+		defaultBranch := cfg.FeatBranchPrefix + featName
+		branch, err = ui.Input("Branch name", defaultBranch)
 		if err != nil {
 			return err
 		}
@@ -127,21 +145,29 @@ func runFeat(cmd *cobra.Command, args []string) error {
 	}
 
 	if existing := state.GetExperiment(expKey); existing != nil {
+		if featDryRunFlag {
+			plan := planWorktree(cfg, "feature", featName, repoPath, existing.Path, existing.Branch, featFromFlag)
+			plan.Errors = alreadyTracked("feature", featName, existing.Path)
+			return printCreatePlan(plan, featJSONFlag)
+		}
 		ui.Warn("Feature '%s' already exists", featName)
 		ui.KeyValue("Path", existing.Path)
 
-		prompt := promptui.Prompt{
-			Label:     "Resume existing feature",
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		if err == nil {
+		if ui.Confirm("Resume existing feature", false) {
 			// User wants to resume
 			return launchSession(cfg, existing.Path, featEditorFlag, featNoAgentFlag, featNoEditorFlag)
 		}
 		return nil
 	}
 
+	if err := checkNameAvailable(state, featName, "experiment"); err != nil {
+		return err
+	}
+
+	if featDryRunFlag {
+		return printCreatePlan(planWorktree(cfg, "feature", featName, repoPath, featPath, branch, featFromFlag), featJSONFlag)
+	}
+
 	// Create feature directory
 	ui.Header("Creating feature: %s", featName)
 	ui.KeyValue("Repo", repoName)
@@ -153,19 +179,51 @@ func runFeat(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create experiments directory: %w", err)
 	}
 
+	// A crashed earlier run can leave the worktree behind without state
+	adopt, err := checkExistingWorktree(repoPath, featPath, branch, featForceFlag)
+	if err != nil {
+		return err
+	}
+	if adopt {
+		if _, err := adoptWorktree(cfg, "feature", featName, repoPath, featPath, branch); err != nil {
+			return err
+		}
+		ui.Success("Feature adopted!")
+		return launchSession(cfg, featPath, featEditorFlag, featNoAgentFlag, featNoEditorFlag)
+	}
+
 	// Check if branch already exists (local or remote)
 	ui.Info("Checking branch availability...")
-	branchInfo := git.CheckBranch(repoPath, branch)
+	branchInfo := git.CheckBranch(repoPath, cfg.Remote, branch)
 	if branchInfo.Status != git.BranchNotFound {
+		if path, ok := git.BranchCheckedOutAt(repoPath, branch); ok {
+			return branchCheckedOutError(repoPath, branch, path)
+		}
 		ui.Error("Branch '%s' already exists", branch)
 		ui.Detail("Use: clade resume %s", featName)
 		ui.Detail("Or pick a different name")
 		return fmt.Errorf("branch already exists")
 	}
 
-	// Create worktree with new branch from origin's default
+	// Make sure the base exists before creating anything
+	var base string
+	if featFromFlag != "" {
+		git.Fetch(repoPath, cfg.Remote) // Ignore error - might be offline
+		base, err = git.ResolveBaseRef(repoPath, cfg.Remote, featFromFlag)
+		if err != nil {
+			return err
+		}
+		ui.KeyValue("Base", base)
+	}
+
+	// Create worktree with new branch from the base or the remote's default
 	ui.Info("Creating worktree...")
-	if err := git.CreateWorktreeNew(repoPath, featPath, branch); err != nil {
+	if base != "" {
+		err = git.CreateWorktreeFromBase(repoPath, featPath, branch, base)
+	} else {
+		err = git.CreateWorktreeNew(repoPath, cfg.Remote, featPath, branch)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
@@ -173,7 +231,7 @@ func runFeat(cmd *cobra.Command, args []string) error {
 	sourceClaudeDir := filepath.Join(repoPath, ".claude")
 	if _, err := os.Stat(sourceClaudeDir); err == nil {
 		ui.Info("Copying .claude/ configuration...")
-		if err := copyDir(sourceClaudeDir, filepath.Join(featPath, ".claude")); err != nil {
+		if err := files.CopyDir(sourceClaudeDir, filepath.Join(featPath, ".claude")); err != nil {
 			ui.Warn("Failed to copy .claude/ directory: %v", err)
 		}
 	} else if cfg.AutoInit {
@@ -189,6 +247,11 @@ func runFeat(cmd *cobra.Command, args []string) error {
 		ui.Warn("Failed to copy some files: %v", err)
 	}
 
+	// Run the repo's setup command (pnpm install, direnv allow, ...)
+	if !featNoSetupFlag {
+		runSetupCommand(cfg, repoPath, featPath, "")
+	}
+
 	// Create .clade.json metadata
 	ticket := extractTicket(featName)
 	cladeMetadata := map[string]any{
@@ -198,6 +261,9 @@ func runFeat(cmd *cobra.Command, args []string) error {
 		"repo":    repoName,
 		"created": time.Now().Format(time.RFC3339),
 	}
+	if base != "" {
+		cladeMetadata["base"] = base
+	}
 	if err := writeJSON(filepath.Join(featPath, ".clade.json"), cladeMetadata); err != nil {
 		ui.Warn("Failed to write .clade.json: %v", err)
 	}
@@ -212,8 +278,11 @@ func runFeat(cmd *cobra.Command, args []string) error {
 		Created:  time.Now(),
 		LastUsed: time.Now(),
 	}
-	state.AddExperiment(exp)
-	if err := state.Save(cfg); err != nil {
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.AddExperiment(exp)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/files"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filesAllFlag          bool
+	filesRefreshPrefsFlag bool
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files <name>",
+	Short: "Copy gitignored files from the source repo into an existing worktree",
+	Long: `Re-copy gitignored files (.env, local configs, ...) from the source repo.
+
+Uses the saved copy list for the repo when there is one, otherwise prompts and
+saves your choice. Files already in the worktree are overwritten. For projects,
+each repo is handled separately.
+
+Examples:
+  clade files try-redis                  # Copy saved (or prompted) files
+  clade files try-redis --all            # Copy every detected file
+  clade files try-redis --refresh-prefs  # Re-prompt and update the saved list`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runFiles,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(filesCmd)
+	filesCmd.Flags().BoolVar(&filesAllFlag, "all", false, "Copy every detected file without prompting")
+	filesCmd.Flags().BoolVar(&filesRefreshPrefsFlag, "refresh-prefs", false, "Prompt again and overwrite the saved copy list")
+}
+
+func runFiles(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment or project", name)
+	}
+
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		if !pathExists(exp.Path) {
+			return fmt.Errorf("path no longer exists: %s", exp.Path)
+		}
+		ui.Header("Copying files into: %s", exp.Name)
+		return recopyGitignoredFiles(cfg, exp.Repo, exp.Path)
+
+	case item.Project != nil:
+		proj := item.Project
+		ui.Header("Copying files into project: %s", proj.Name)
+		for _, repo := range proj.Repos {
+			dst := filepath.Join(proj.Path, repo.Name)
+			if !pathExists(dst) {
+				ui.Warn("%s: path no longer exists, skipping", repo.Name)
+				continue
+			}
+			fmt.Println()
+			ui.Info("%s:", repo.Name)
+			if err := recopyGitignoredFiles(cfg, repo.Source, dst); err != nil {
+				return fmt.Errorf("failed to copy files for %s: %w", repo.Name, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("'%s' is a scratch, which has no source repo", name)
+}
+
+// recopyGitignoredFiles copies detected gitignored files from srcRepo into an
+// existing worktree, honoring --all and --refresh-prefs
+func recopyGitignoredFiles(cfg *config.Config, srcRepo, dstPath string) error {
+	detected := files.FindGitignored(srcRepo)
+	if len(detected) == 0 {
+		ui.Detail("No gitignored files found in %s", srcRepo)
+		return nil
+	}
+
+	var selected []string
+	saved := cfg.GetRepoCopyFiles(srcRepo)
+	switch {
+	case filesAllFlag:
+		selected = detected
+
+	case saved != nil && !filesRefreshPrefsFlag:
+		// Only copy saved entries that still match something in the source repo
+		for _, f := range saved {
+			if len(files.Expand(srcRepo, f)) > 0 {
+				selected = append(selected, f)
+			}
+		}
+		for _, f := range detected {
+			if !slices.Contains(saved, f) {
+				ui.Detail("Not in saved list: %s (use --refresh-prefs or --all)", f)
+			}
+		}
+
+	default:
+		var err error
+		selected, err = selectFilesToCopy(detected)
+		if err != nil {
+			return nil // User cancelled, not an error
+		}
+		cfg.SetRepoCopyFiles(srcRepo, selected)
+		if err := cfg.Save(); err != nil {
+			ui.Warn("Failed to save file preferences: %v", err)
+		}
+	}
+
+	if len(selected) == 0 {
+		ui.Detail("Nothing to copy")
+		return nil
+	}
+
+	if err := files.CopyFiles(srcRepo, dstPath, selected); err != nil {
+		return err
+	}
+	for _, f := range selected {
+		ui.Detail("  Copied %s", f)
+	}
+	return nil
+}
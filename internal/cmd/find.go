@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var findJSONFlag bool
+
+var findCmd = &cobra.Command{
+	Use:   "find <ticket-or-query>",
+	Short: "Find experiments, projects, and scratches by ticket or name",
+	Long: `Find tracked items by ticket or name.
+
+An item matches if its ticket equals the query (ignoring case) or its name
+contains the query. Handy when one ticket spawned a scratch for analysis and
+an experiment for the fix.
+
+Examples:
+  clade find PROJ-1234
+  clade find redis
+  clade find proj-1234 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFind,
+}
+
+func init() {
+	rootCmd.AddCommand(findCmd)
+	findCmd.Flags().BoolVar(&findJSONFlag, "json", false, "Output as JSON")
+}
+
+// findMatch is one item matching a find query
+type findMatch struct {
+	Type     string    `json:"type"`
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	Ticket   string    `json:"ticket,omitempty"`
+	Match    string    `json:"match"` // "ticket" or "name"
+	LastUsed time.Time `json:"last_used"`
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	query := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	matches := findItems(state, query)
+	if findJSONFlag {
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(matches) == 0 {
+		ui.Info("Nothing matches '%s'", query)
+		return nil
+	}
+
+	ui.Header("Matches for '%s':", query)
+	for _, m := range matches {
+		detail := m.Path
+		if m.Ticket != "" {
+			detail = m.Ticket + "  " + detail
+		}
+		fmt.Printf("  %s %s %s\n", ui.Cyan(m.Name), ui.Dim("["+m.Type+"]"), ui.Dim(detail))
+	}
+	return nil
+}
+
+// findItems returns the items whose ticket equals query (case-insensitive)
+// or whose name contains it, most recently used first
+func findItems(state *config.State, query string) []findMatch {
+	lowerQuery := strings.ToLower(query)
+	matches := []findMatch{}
+	for _, item := range recentItems(state) {
+		ticket := itemTicket(item)
+		var match string
+		switch {
+		case ticket != "" && strings.EqualFold(ticket, query):
+			match = "ticket"
+		case strings.Contains(strings.ToLower(item.Name), lowerQuery):
+			match = "name"
+		default:
+			continue
+		}
+		matches = append(matches, findMatch{
+			Type:     item.Type,
+			Name:     item.Name,
+			Path:     item.Path,
+			Ticket:   ticket,
+			Match:    match,
+			LastUsed: item.LastUsed,
+		})
+	}
+	return matches
+}
+
+// itemTicket returns an item's ticket. Projects don't store one, so it's
+// taken from the name like it is for experiments.
+func itemTicket(item *resolvedItem) string {
+	switch {
+	case item.Experiment != nil:
+		return item.Experiment.Ticket
+	case item.Scratch != nil:
+		return item.Scratch.Ticket
+	}
+	return extractTicket(item.Name)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestFindItemsByTicketAndName(t *testing.T) {
+	now := time.Now()
+	state := &config.State{
+		Experiments: map[string]*config.Experiment{
+			"k-fix":   {Name: "PROJ-1234-fix-login", Ticket: "PROJ-1234", Path: "/exp/fix", LastUsed: now},
+			"k-redis": {Name: "try-redis", Path: "/exp/redis", LastUsed: now.Add(-time.Hour)},
+		},
+		Projects: map[string]*config.Project{
+			"PROJ-12-platform": {Name: "PROJ-12-platform", Path: "/proj/platform", LastUsed: now.Add(-2 * time.Hour)},
+		},
+		Scratches: map[string]*config.Scratch{
+			"analysis": {Name: "analysis", Ticket: "PROJ-1234", Path: "/scratch/analysis", LastUsed: now.Add(-3 * time.Hour)},
+		},
+	}
+
+	names := func(matches []findMatch) [][2]string {
+		var got [][2]string
+		for _, m := range matches {
+			got = append(got, [2]string{m.Name, m.Match})
+		}
+		return got
+	}
+	tests := []struct {
+		query string
+		want  [][2]string
+	}{
+		// Ticket is exact and ignores case, so PROJ-12 doesn't pick up PROJ-1234
+		{"proj-1234", [][2]string{{"PROJ-1234-fix-login", "ticket"}, {"analysis", "ticket"}}},
+		{"PROJ-12", [][2]string{{"PROJ-1234-fix-login", "name"}, {"PROJ-12-platform", "ticket"}}},
+		{"Redis", [][2]string{{"try-redis", "name"}}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		if got := names(findItems(state, tt.query)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("find %q = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFindJSONOutput(t *testing.T) {
+	cfg := setupTestEnv(t)
+	setFlag(t, &findJSONFlag, false)
+	if err := config.UpdateState(cfg, func(state *config.State) error {
+		state.AddScratch(&config.Scratch{Name: "PROJ-7-notes", Ticket: "PROJ-7", Path: "/scratch/notes", LastUsed: time.Now()})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if code := executeArgs(t, "find", "proj-7", "--json"); code != 0 {
+			t.Errorf("find exited %d", code)
+		}
+	})
+	var matches []findMatch
+	if err := json.Unmarshal([]byte(out), &matches); err != nil {
+		t.Fatalf("output isn't JSON: %v\n%s", err, out)
+	}
+	if len(matches) != 1 || matches[0].Type != "scratch" || matches[0].Ticket != "PROJ-7" || matches[0].Match != "ticket" {
+		t.Errorf("got %+v, want the PROJ-7 scratch matched by ticket", matches)
+	}
+
+	out = captureStdout(t, func() { executeArgs(t, "find", "nothing", "--json") })
+	if err := json.Unmarshal([]byte(out), &matches); err != nil || len(matches) != 0 {
+		t.Errorf("no matches: got %q, want an empty JSON array", out)
+	}
+}
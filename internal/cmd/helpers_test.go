@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+// setupTestEnv points clade's config and base dir at temp dirs and gives git
+// an identity, so commands run against a throwaway setup. It returns the
+// loaded config.
+func setupTestEnv(t *testing.T) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, ".config"))
+	t.Setenv(config.ConfigEnv, filepath.Join(dir, "config.json"))
+	t.Setenv(config.BaseDirEnv, filepath.Join(dir, "clade"))
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(dir, ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.AutoInit = false
+	cfg.Agent = ""
+	cfg.Editor = "none"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("cfg.Save: %v", err)
+	}
+	return cfg
+}
+
+// newTestRepo creates a git repo on main with one commit and returns its path
+func newTestRepo(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, path, "init", "-q", "-b", "main")
+	writeTestFile(t, filepath.Join(path, "README.md"), "# "+name+"\n")
+	runTestGit(t, path, "add", "-A")
+	runTestGit(t, path, "commit", "-q", "-m", "initial")
+
+	// Match what git rev-parse reports (e.g. /private/var on macOS)
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resolved
+}
+
+// runTestGit runs git in dir and returns its trimmed output
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setFlag sets a package-level flag variable for the duration of a test
+func setFlag[T any](t *testing.T, flag *T, value T) {
+	t.Helper()
+	old := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = old })
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureStderr returns what fn prints to stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+// captureFile returns what fn writes to *file, which is swapped for a pipe
+func captureFile(t *testing.T, file **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+
+	old := *file
+	*file = w
+	defer func() { *file = old }()
+	fn()
+	w.Close()
+	return string(<-done)
+}
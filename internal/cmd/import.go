@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importRepoFlag   string
+	importDryRunFlag bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Adopt existing git worktrees as experiments",
+	Long: `Adopt worktrees created with plain "git worktree add" into clade.
+
+Every worktree of the repo that clade isn't already tracking is added as an
+experiment at its existing path. The name is derived from the branch
+(e.g. exp/try-redis -> try-redis). The main worktree is skipped.
+
+Examples:
+  clade import                 # Import worktrees of the current repo
+  clade import -r backend      # Import worktrees of a registered repo
+  clade import --dry-run       # Preview what would be imported`,
+	Args: cobra.NoArgs,
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVarP(&importRepoFlag, "repo", "r", "", "Repository path or registered name")
+	importCmd.Flags().BoolVar(&importDryRunFlag, "dry-run", false, "Show what would be imported without changing anything")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	repoPath, err := resolveRepo(cfg, importRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := git.ListWorktrees(repoPath)
+	if err != nil {
+		return err
+	}
+
+	tracked := make(map[string]bool)
+	for _, exp := range state.Experiments {
+		tracked[cleanPath(exp.Path)] = true
+	}
+	for _, proj := range state.Projects {
+		for _, repo := range proj.Repos {
+			tracked[cleanPath(filepath.Join(proj.Path, repo.Name))] = true
+		}
+	}
+
+	ui.Header("Importing worktrees from %s", git.GetRepoName(repoPath))
+
+	var imported []*config.Experiment
+	for i, wt := range worktrees {
+		// git lists the main worktree first
+		if i == 0 || cleanPath(wt) == cleanPath(repoPath) {
+			continue
+		}
+		if tracked[cleanPath(wt)] {
+			continue
+		}
+		if !pathExists(wt) {
+			ui.Warn("Skipping %s: directory missing (run: clade doctor --fix)", wt)
+			continue
+		}
+
+		branch, err := git.GetCurrentBranch(wt)
+		if err != nil || branch == "HEAD" {
+			ui.Warn("Skipping %s: no branch checked out", wt)
+			continue
+		}
+
+		name := importName(cfg, branch)
+		if name == "" {
+			ui.Warn("Skipping %s: can't derive a name from branch '%s'", wt, branch)
+			continue
+		}
+		if itemType, exists := state.NameExists(name); exists {
+			ui.Warn("Skipping %s: '%s' already exists as a %s", wt, name, itemType)
+			continue
+		}
+
+		exp := &config.Experiment{
+			Name:     name,
+			Repo:     repoPath,
+			Path:     wt,
+			Branch:   branch,
+			Ticket:   extractTicket(name),
+			Created:  time.Now(),
+			LastUsed: time.Now(),
+		}
+		imported = append(imported, exp)
+		// Reserve the name so two worktrees can't import under the same one
+		state.AddExperiment(exp)
+
+		fmt.Printf("  %s %s\n", ui.Cyan(name), ui.Dim("("+branch+")"))
+		ui.KeyValue("Path", wt)
+	}
+
+	if len(imported) == 0 {
+		ui.Info("No untracked worktrees to import")
+		return nil
+	}
+
+	fmt.Println()
+	if importDryRunFlag {
+		ui.Info("Would import %d worktree(s) (dry run)", len(imported))
+		return nil
+	}
+
+	for _, exp := range imported {
+		metaPath := filepath.Join(exp.Path, ".clade.json")
+		if _, err := os.Stat(metaPath); os.IsNotExist(err) {
+			cladeMetadata := map[string]interface{}{
+				"type":    "experiment",
+				"name":    exp.Name,
+				"ticket":  exp.Ticket,
+				"repo":    git.GetRepoName(repoPath),
+				"created": exp.Created.Format(time.RFC3339),
+			}
+			if err := writeJSON(metaPath, cladeMetadata); err != nil {
+				ui.Warn("Failed to write .clade.json for %s: %v", exp.Name, err)
+			}
+		}
+	}
+
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		for _, exp := range imported {
+			s.AddExperiment(exp)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("Imported %d worktree(s)", len(imported))
+	return nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// importName derives an experiment name from a branch, dropping the
+// configured exp/feat prefix (or any other prefix up to the last "/")
+func importName(cfg *config.Config, branch string) string {
+	name := branch
+	switch {
+	case cfg.ExpBranchPrefix != "" && strings.HasPrefix(branch, cfg.ExpBranchPrefix):
+		name = strings.TrimPrefix(branch, cfg.ExpBranchPrefix)
+	case cfg.FeatBranchPrefix != "" && strings.HasPrefix(branch, cfg.FeatBranchPrefix):
+		name = strings.TrimPrefix(branch, cfg.FeatBranchPrefix)
+	default:
+		name = filepath.Base(branch)
+	}
+
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-_")
+	if !isValidExpName(name) {
+		return ""
+	}
+	return name
+}
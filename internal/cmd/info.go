@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/files"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// infoMaxTodos caps the TODO scan; counts at the cap are shown as "N+"
+const infoMaxTodos = 100
+
+var infoJSONFlag bool
+
+var infoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show details for an experiment, project, or scratch",
+	Long: `Show everything clade knows about one item, from any directory.
+
+Includes metadata, git status, ahead/behind vs the remote, recent commits,
+DROPBAG.md age, open TODOs, and size on disk. For projects, every repo's
+branch status is shown.
+
+Examples:
+  clade info try-redis
+  clade info my-project --json`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runInfo,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().BoolVar(&infoJSONFlag, "json", false, "Output as JSON")
+}
+
+// infoOutput is the detailed view of a single item
+type infoOutput struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	Path       string                 `json:"path"`
+	Exists     bool                   `json:"exists"`
+	Repo       string                 `json:"repo,omitempty"`
+	Branch     string                 `json:"branch,omitempty"`
+	Ticket     string                 `json:"ticket,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Created    time.Time              `json:"created"`
+	LastUsed   time.Time              `json:"last_used"`
+	Metadata   *context.CladeMetadata `json:"metadata,omitempty"`
+	SizeBytes  int64                  `json:"size_bytes"`
+	Dropbag    *time.Time             `json:"dropbag_modified,omitempty"`
+	TodoCount  int                    `json:"todo_count"`
+	TodoCapped bool                   `json:"todo_count_capped,omitempty"`
+	Git        *infoWorktree          `json:"git,omitempty"`
+	Repos      []infoWorktree         `json:"repos,omitempty"`
+}
+
+// infoWorktree is the git view of one worktree
+type infoWorktree struct {
+	Name     string         `json:"name"`
+	Path     string         `json:"path"`
+	Exists   bool           `json:"exists"`
+	Branch   string         `json:"branch,omitempty"`
+	Remote   string         `json:"remote"` // none, local-only, remote-only, or both
+	Ahead    int            `json:"ahead"`
+	Behind   int            `json:"behind"`
+	Diverged bool           `json:"diverged,omitempty"`
+	Status   *git.Status    `json:"status,omitempty"`
+	Changes  []git.DiffStat `json:"changes,omitempty"`
+	Commits  []string       `json:"recent_commits,omitempty"`
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	info := gatherInfo(cfg, item)
+	if infoJSONFlag {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printInfo(info)
+	return nil
+}
+
+// gatherInfo collects the detailed view of a resolved item
+func gatherInfo(cfg *config.Config, item *resolvedItem) *infoOutput {
+	info := &infoOutput{
+		Type:   item.Type,
+		Name:   item.Name,
+		Path:   item.Path,
+		Exists: pathExists(item.Path),
+		Tags:   item.Tags,
+	}
+
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		info.Repo = exp.Repo
+		info.Branch = exp.Branch
+		info.Ticket = exp.Ticket
+		info.Created = exp.Created
+		info.LastUsed = exp.LastUsed
+		wt := gatherWorktreeInfo(cfg, exp.Name, exp.Repo, exp.Path, exp.Branch)
+		info.Git = &wt
+	case item.Project != nil:
+		proj := item.Project
+		info.Branch = proj.Branch
+		info.Created = proj.Created
+		info.LastUsed = proj.LastUsed
+		for _, repo := range proj.Repos {
+			info.Repos = append(info.Repos, gatherWorktreeInfo(cfg, repo.Name, repo.Source, filepath.Join(proj.Path, repo.Name), proj.RepoBranch(repo)))
+		}
+	case item.Scratch != nil:
+		info.Repo = item.Scratch.LinkedRepo
+		info.Ticket = item.Scratch.Ticket
+		info.Created = item.Scratch.Created
+		info.LastUsed = item.Scratch.LastUsed
+	}
+
+	if !info.Exists {
+		return info
+	}
+
+	info.Metadata, _ = context.ReadCladeMetadata(item.Path)
+	info.SizeBytes, _ = files.DirSize(item.Path)
+	if dropbag, err := context.ReadDropbag(item.Path); err == nil && dropbag.Exists {
+		info.Dropbag = &dropbag.ModTime
+	}
+	if todos, err := context.FindTodos(item.Path, infoMaxTodos); err == nil {
+		info.TodoCount = len(todos)
+		info.TodoCapped = len(todos) >= infoMaxTodos
+	}
+
+	return info
+}
+
+// gatherWorktreeInfo collects git status and branch tracking for a worktree
+func gatherWorktreeInfo(cfg *config.Config, name, repoPath, path, branch string) infoWorktree {
+	wt := infoWorktree{
+		Name:   name,
+		Path:   path,
+		Exists: pathExists(path),
+		Branch: branch,
+		Remote: "none",
+	}
+	if !wt.Exists || !git.IsGitRepo(path) {
+		return wt
+	}
+
+	if current, err := git.GetCurrentBranch(path); err == nil {
+		wt.Branch = current
+	}
+	wt.Status, _ = git.GetStatus(path)
+	wt.Changes, _ = git.GetDiffStat(path)
+	wt.Commits, _ = git.GetRecentCommits(path, 5)
+
+	branchInfo := git.CheckBranch(repoPath, cfg.Remote, wt.Branch)
+	switch branchInfo.Status {
+	case git.BranchLocalOnly:
+		wt.Remote = "local-only"
+	case git.BranchRemoteOnly:
+		wt.Remote = "remote-only"
+	case git.BranchBoth:
+		wt.Remote = "both"
+		wt.Ahead = branchInfo.LocalAhead
+		wt.Behind = branchInfo.RemoteBehind
+		wt.Diverged = branchInfo.Diverged
+	}
+
+	return wt
+}
+
+func printInfo(info *infoOutput) {
+	ui.Header("%s: %s", capitalize(info.Type), info.Name)
+	ui.KeyValue("Path", info.Path)
+	if info.Repo != "" {
+		ui.KeyValue("Repo", info.Repo)
+	}
+	if info.Branch != "" {
+		ui.KeyValue("Branch", info.Branch)
+	}
+	if info.Ticket != "" {
+		ui.KeyValue("Ticket", info.Ticket)
+	}
+	printTags(info.Tags)
+	ui.KeyValue("Created", formatAge(info.Created))
+	ui.KeyValue("Last used", formatAge(info.LastUsed))
+
+	if !info.Exists {
+		fmt.Println()
+		ui.Warn("Path no longer exists (run: clade doctor)")
+		return
+	}
+
+	ui.KeyValue("Size", formatSize(info.SizeBytes))
+	if info.Dropbag != nil {
+		ui.KeyValue("DROPBAG", "updated "+formatAge(*info.Dropbag))
+	} else {
+		ui.KeyValue("DROPBAG", "none")
+	}
+	todos := fmt.Sprintf("%d", info.TodoCount)
+	if info.TodoCapped {
+		todos += "+"
+	}
+	ui.KeyValue("TODOs", todos)
+
+	if info.Git != nil {
+		printWorktreeInfo(info.Git, false)
+	}
+	for i := range info.Repos {
+		printWorktreeInfo(&info.Repos[i], true)
+	}
+}
+
+// printWorktreeInfo prints the git section for one worktree. Project repos
+// get their own header.
+func printWorktreeInfo(wt *infoWorktree, showName bool) {
+	fmt.Println()
+	if showName {
+		ui.Header("%s:", wt.Name)
+	} else {
+		ui.Header("Git:")
+	}
+	if !wt.Exists {
+		ui.Warn("Worktree missing: %s", wt.Path)
+		return
+	}
+
+	if showName {
+		ui.KeyValue("Branch", wt.Branch)
+	}
+	ui.KeyValue("Remote", describeRemote(wt))
+	printGitStatus(wt.Path)
+
+	if len(wt.Commits) > 0 {
+		fmt.Println()
+		ui.Header("Recent Commits:")
+		for _, commit := range wt.Commits {
+			fmt.Printf("  %s\n", commit)
+		}
+	}
+}
+
+// describeRemote summarizes how a worktree's branch compares to the remote
+func describeRemote(wt *infoWorktree) string {
+	switch wt.Remote {
+	case "local-only":
+		return "not pushed"
+	case "remote-only":
+		return "remote only"
+	case "both":
+		switch {
+		case wt.Diverged:
+			return fmt.Sprintf("diverged (%d ahead, %d behind)", wt.Ahead, wt.Behind)
+		case wt.Ahead > 0:
+			return fmt.Sprintf("%d ahead", wt.Ahead)
+		case wt.Behind > 0:
+			return fmt.Sprintf("%d behind", wt.Behind)
+		}
+		return "in sync"
+	}
+	return "branch not found"
+}
+
+// formatSize formats a byte count for display
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
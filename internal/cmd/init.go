@@ -1,18 +1,24 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var initForceFlag bool
+var (
+	initForceFlag        bool
+	initGlobalFlag       bool
+	initWithStopHookFlag bool
+)
 
 var initCmd = &cobra.Command{
 	Use:   "init",
@@ -22,18 +28,46 @@ var initCmd = &cobra.Command{
 This creates:
   - .claude/settings.json with SessionStart hook
   - .claude/commands/drop.md for the /drop command
-  - Appends DROPBAG.md and .clade.json to .gitignore
+  - Appends DROPBAG.md, .clade.json, and .clade/dropbags/ to .gitignore
+
+An existing settings.json is kept: the SessionStart hook is merged into it
+alongside any other hooks, and only added once. Use --force to replace it.
+
+With --with-stop-hook, a Stop hook running "clade drop --auto" is added too.
+It fires every time Claude finishes responding, including at the end of every
+session, and refreshes an auto-generated snapshot (git status, recent commits,
+TODOs) at the bottom of DROPBAG.md without touching notes above it.
 
-Run this in any git repository to enable context injection.`,
+Run this in any git repository to enable context injection, or use --global
+to set up ~/.claude/settings.json and ~/.claude/commands once for all repos
+(no .gitignore changes are made in global mode).`,
 	RunE: runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
-	initCmd.Flags().BoolVarP(&initForceFlag, "force", "f", false, "Overwrite existing configuration")
+	initCmd.Flags().BoolVarP(&initForceFlag, "force", "f", false, "Replace .claude/settings.json instead of merging into it")
+	initCmd.Flags().BoolVarP(&initGlobalFlag, "global", "g", false, "Configure ~/.claude for every repo instead of the current one")
+	initCmd.Flags().BoolVar(&initWithStopHookFlag, "with-stop-hook", false, "Also add a Stop hook that snapshots state into DROPBAG.md (clade drop --auto)")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initGlobalFlag {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find home directory: %w", err)
+		}
+		ui.Header("Initializing clade globally")
+		if err := setupClaudeDir(filepath.Join(homeDir, ".claude"), "~/.claude"); err != nil {
+			return err
+		}
+
+		ui.Success("Clade initialized for all repos!")
+		ui.Detail("SessionStart hook will call: clade inject-context")
+		ui.Detail("Use /drop to save session context before stopping")
+		return nil
+	}
+
 	// Get current directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -50,93 +84,180 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	claudeDir := filepath.Join(repoRoot, ".claude")
-	commandsDir := filepath.Join(claudeDir, "commands")
+	ui.Header("Initializing clade in %s", git.GetRepoName(repoRoot))
+	if err := setupClaudeDir(filepath.Join(repoRoot, ".claude"), ".claude"); err != nil {
+		return err
+	}
 
-	// Check if already initialized
-	settingsPath := filepath.Join(claudeDir, "settings.json")
-	if _, err := os.Stat(settingsPath); err == nil && !initForceFlag {
-		ui.Warn(".claude/settings.json already exists")
-		ui.Detail("Use --force to overwrite")
-		return nil
+	// Update .gitignore
+	gitignorePath := filepath.Join(repoRoot, ".gitignore")
+	ui.Info("Updating .gitignore...")
+	if err := updateGitignore(gitignorePath); err != nil {
+		ui.Warn("Failed to update .gitignore: %v", err)
 	}
 
-	ui.Header("Initializing clade in %s", git.GetRepoName(repoRoot))
+	ui.Success("Clade initialized!")
+	ui.Detail("SessionStart hook will call: clade inject-context")
+	ui.Detail("Use /drop to save session context before stopping")
+
+	return nil
+}
+
+// setupClaudeDir adds the SessionStart hook and /drop command to a .claude
+// directory, either a repo's or the user's. label is how the directory is
+// shown in messages.
+func setupClaudeDir(claudeDir, label string) error {
+	commandsDir := filepath.Join(claudeDir, "commands")
 
 	// Create directories
 	if err := os.MkdirAll(commandsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .claude/commands: %w", err)
+		return fmt.Errorf("failed to create %s/commands: %w", label, err)
 	}
 
-	// Write settings.json
-	ui.Info("Creating .claude/settings.json...")
-	if err := writeSettingsJSON(settingsPath); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
+	// Add the SessionStart hook, keeping whatever else settings.json has
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	if initForceFlag {
+		if err := os.Remove(settingsPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove settings.json: %w", err)
+		}
+	}
+	_, statErr := os.Stat(settingsPath)
+	added, err := addHook(settingsPath, "SessionStart", "*", injectContextCommand)
+	if err != nil {
+		return fmt.Errorf("failed to update settings.json: %w", err)
+	}
+	switch {
+	case !added:
+		ui.Info("%s/settings.json already has the SessionStart hook", label)
+	case statErr == nil:
+		ui.Info("Added SessionStart hook to existing %s/settings.json", label)
+	default:
+		ui.Info("Created %s/settings.json", label)
+	}
+
+	if initWithStopHookFlag {
+		added, err := addHook(settingsPath, "Stop", "", autoDropCommand)
+		if err != nil {
+			return fmt.Errorf("failed to update settings.json: %w", err)
+		}
+		if added {
+			ui.Info("Added Stop hook: %s (runs whenever Claude stops)", autoDropCommand)
+		} else {
+			ui.Info("%s/settings.json already has the Stop hook", label)
+		}
 	}
 
 	// Write drop.md command
 	dropPath := filepath.Join(commandsDir, "drop.md")
-	ui.Info("Creating .claude/commands/drop.md...")
+	ui.Info("Creating %s/commands/drop.md...", label)
 	if err := writeDropCommand(dropPath); err != nil {
 		return fmt.Errorf("failed to write drop.md: %w", err)
 	}
+	return nil
+}
 
-	// Update .gitignore
-	gitignorePath := filepath.Join(repoRoot, ".gitignore")
-	ui.Info("Updating .gitignore...")
-	if err := updateGitignore(gitignorePath); err != nil {
-		ui.Warn("Failed to update .gitignore: %v", err)
-	}
+// Hook commands clade adds to settings.json
+const (
+	injectContextCommand = "clade inject-context"
+	autoDropCommand      = "clade drop --auto"
+)
 
-	ui.Success("Clade initialized!")
-	ui.Detail("SessionStart hook will call: clade inject-context")
-	ui.Detail("Use /drop to save session context before stopping")
+// claudeHookEntry is one matcher in a settings.json hook list
+type claudeHookEntry struct {
+	Matcher string              `json:"matcher,omitempty"`
+	Hooks   []claudeHookCommand `json:"hooks"`
+}
 
-	return nil
+// claudeHookCommand is a single command hook
+type claudeHookCommand struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
 }
 
 func writeSettingsJSON(path string) error {
-	content := `{
-  "hooks": {
-    "SessionStart": [
-      {
-        "matcher": "*",
-        "hooks": [
-          {
-            "type": "command",
-            "command": "clade inject-context"
-          }
-        ]
-      }
-    ]
-  }
-}
-`
-	return os.WriteFile(path, []byte(content), 0644)
+	_, err := addHook(path, "SessionStart", "*", injectContextCommand)
+	return err
 }
 
-func writeDropCommand(path string) error {
-	content := `Write a DROPBAG.md file in the repo root with the following sections:
+// addHook adds a command hook for event to the settings.json at path,
+// creating it if needed. Other settings, hooks, and matchers are kept. An
+// empty matcher is left out, as for events like Stop that don't use one.
+// Returns false if the command was already hooked for event.
+func addHook(path, event, matcher, command string) (bool, error) {
+	settings := map[string]interface{}{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
 
-## Summary
-What we accomplished this session. Be specific about changes made.
+	hooks, ok := settings["hooks"].(map[string]interface{})
+	if !ok {
+		if settings["hooks"] != nil {
+			return false, fmt.Errorf("unexpected \"hooks\" value in %s", path)
+		}
+		hooks = map[string]interface{}{}
+	}
 
-## Current State
-What's working, what's broken, what's partially implemented.
+	entries, ok := hooks[event].([]interface{})
+	if !ok && hooks[event] != nil {
+		return false, fmt.Errorf("unexpected \"hooks.%s\" value in %s", event, path)
+	}
+	if hasHookCommand(entries, command) {
+		return false, nil
+	}
 
-## Next Steps
-Exact actions to continue (be specific - file names, function names, etc.).
+	hooks[event] = append(entries, claudeHookEntry{
+		Matcher: matcher,
+		Hooks:   []claudeHookCommand{{Type: "command", Command: command}},
+	})
+	settings["hooks"] = hooks
 
-## Key Files
-Files to look at first when resuming. Include line numbers if relevant.
+	file, err := os.Create(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
 
-## Open Questions
-Anything unresolved or decisions that need to be made.
+	// Leave other tools' commands readable (no \u0026 for &)
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return true, encoder.Encode(settings)
+}
 
----
+// hasHookCommand reports whether any matcher in a hook list runs command
+// (possibly with extra flags)
+func hasHookCommand(entries []interface{}, command string) bool {
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		hookList, _ := entryMap["hooks"].([]interface{})
+		for _, hook := range hookList {
+			hookMap, ok := hook.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cmd, _ := hookMap["command"].(string)
+			if cmd == command || strings.HasPrefix(cmd, command+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-Save the file to DROPBAG.md in the repository root, then confirm it's written.
-`
+func writeDropCommand(path string) error {
+	content := "Write a DROPBAG.md file in the repo root with the following sections:\n\n" +
+		context.DropbagTemplate +
+		"\n---\n\nSave the file to DROPBAG.md in the repository root, then confirm it's written.\n"
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
@@ -144,6 +265,7 @@ func updateGitignore(path string) error {
 	linesToAdd := []string{
 		"DROPBAG.md",
 		".clade.json",
+		context.DropbagArchiveDir + "/",
 	}
 
 	// Read existing content
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readHooks decodes the hooks object of a settings.json
+func readHooks(t *testing.T, path string) (map[string]interface{}, map[string][]claudeHookEntry) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("invalid settings.json: %v\n%s", err, data)
+	}
+	var parsed struct {
+		Hooks map[string][]claudeHookEntry `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	return settings, parsed.Hooks
+}
+
+func TestWriteSettingsJSONMergesIntoExistingHooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		// commands expected per event after the merge, in order
+		want map[string][]string
+	}{
+		{
+			name:     "new file",
+			existing: "",
+			want:     map[string][]string{"SessionStart": {injectContextCommand}},
+		},
+		{
+			name: "existing PreToolUse hook",
+			existing: `{"model": "opus", "hooks": {"PreToolUse": [
+				{"matcher": "Bash", "hooks": [{"type": "command", "command": "guard.sh && echo ok"}]}]}}`,
+			want: map[string][]string{
+				"PreToolUse":   {"guard.sh && echo ok"},
+				"SessionStart": {injectContextCommand},
+			},
+		},
+		{
+			name: "existing SessionStart hook",
+			existing: `{"hooks": {"SessionStart": [
+				{"matcher": "startup", "hooks": [{"type": "command", "command": "other-tool prime"}]}]}}`,
+			want: map[string][]string{"SessionStart": {"other-tool prime", injectContextCommand}},
+		},
+		{
+			name: "already hooked with extra flags",
+			existing: `{"hooks": {"SessionStart": [
+				{"matcher": "*", "hooks": [{"type": "command", "command": "clade inject-context --format json"}]}]}}`,
+			want: map[string][]string{"SessionStart": {"clade inject-context --format json"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.json")
+			if tt.existing != "" {
+				writeTestFile(t, path, tt.existing)
+			}
+
+			// Running twice must not add the hook twice
+			for i := 0; i < 2; i++ {
+				if err := writeSettingsJSON(path); err != nil {
+					t.Fatalf("writeSettingsJSON: %v", err)
+				}
+			}
+
+			settings, hooks := readHooks(t, path)
+			if len(hooks) != len(tt.want) {
+				t.Errorf("events = %v, want %v", hooks, tt.want)
+			}
+			for event, want := range tt.want {
+				var got []string
+				for _, entry := range hooks[event] {
+					for _, hook := range entry.Hooks {
+						got = append(got, hook.Command)
+					}
+				}
+				if strings.Join(got, "\n") != strings.Join(want, "\n") {
+					t.Errorf("%s commands = %q, want %q", event, got, want)
+				}
+			}
+			if strings.Contains(tt.existing, `"model"`) && settings["model"] != "opus" {
+				t.Errorf("other settings were dropped: %v", settings)
+			}
+		})
+	}
+}
+
+func TestWriteSettingsJSONRejectsUnparsableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	writeTestFile(t, path, `{"hooks": [`)
+	if err := writeSettingsJSON(path); err == nil {
+		t.Fatal("writeSettingsJSON overwrote an unparsable settings.json")
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"hooks": [` {
+		t.Errorf("file was changed to %s", data)
+	}
+}
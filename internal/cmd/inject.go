@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/daniil-lyalko/clade/internal/config"
 	"github.com/daniil-lyalko/clade/internal/context"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/spf13/cobra"
@@ -22,38 +24,121 @@ by 'clade init'. It gathers:
   - Open TODOs in the codebase
   - Ticket information from .clade.json
 
-The output is formatted as markdown for Claude to read.`,
+Inside a project (a repo whose parent has .clade-project.json, or the project
+folder itself), git status, commits, and TODOs are shown for every repo.
+
+With dropbag_history enabled, DROPBAG.md is archived to .clade/dropbags and
+the titles of earlier sessions are listed.
+
+The output is formatted as markdown for Claude to read. With --format json
+(or inject_format = json) the markdown is wrapped in the SessionStart hook
+JSON envelope as hookSpecificOutput.additionalContext.
+
+Context is gathered from the root of the current git repo, or the current
+directory outside git. --dir gathers it from the given directory instead,
+used as-is.`,
 	RunE: runInjectContext,
 }
 
+var (
+	injectFormatFlag string
+	injectDirFlag    string
+)
+
 func init() {
 	rootCmd.AddCommand(injectCmd)
+	injectCmd.Flags().StringVar(&injectFormatFlag, "format", "", "Output format: markdown or json (default: inject_format)")
+	injectCmd.Flags().StringVar(&injectDirFlag, "dir", "", "Gather context from this directory instead of the current repo")
 }
 
 func runInjectContext(cmd *cobra.Command, args []string) error {
-	// Get current directory
-	cwd, err := os.Getwd()
+	// A broken config shouldn't stop the hook - fall back to the defaults
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	format := injectFormatFlag
+	if format == "" {
+		format = cfg.InjectFormat
+	}
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid format '%s' (use markdown or json)", format)
+	}
+
+	dir, err := injectDir()
 	if err != nil {
 		return err
 	}
 
-	// Try to get repo root, fall back to cwd
-	dir := cwd
-	if git.IsGitRepo(cwd) {
-		if root, err := git.GetRepoRoot(cwd); err == nil {
-			dir = root
+	// Gather and format context, covering every repo when inside a project
+	var output string
+	projectDir, inProject := context.FindProjectRoot(dir)
+	if cfg.DropbagHistory {
+		archiveDropbag(dir)
+		if inProject && projectDir != dir {
+			archiveDropbag(projectDir)
 		}
 	}
 
-	// Gather context
-	ctx, err := context.GatherContext(dir)
-	if err != nil {
-		return fmt.Errorf("failed to gather context: %w", err)
+	if inProject {
+		ctx, err := context.GatherProjectContext(projectDir, dir)
+		if err != nil {
+			return fmt.Errorf("failed to gather context: %w", err)
+		}
+		output = context.FormatProjectContext(ctx)
+	} else {
+		ctx, err := context.GatherContext(dir)
+		if err != nil {
+			return fmt.Errorf("failed to gather context: %w", err)
+		}
+		output = context.FormatContext(ctx)
 	}
 
-	// Format and output
-	output := context.FormatContext(ctx)
+	if format == "json" {
+		output, err = context.FormatHookJSON(output)
+		if err != nil {
+			return fmt.Errorf("failed to encode context: %w", err)
+		}
+	}
 	fmt.Print(output)
 
 	return nil
 }
+
+// injectDir returns the directory to gather context from: --dir if given,
+// otherwise the current repo root, falling back to cwd
+func injectDir() (string, error) {
+	if injectDirFlag != "" {
+		dir, err := filepath.Abs(injectDirFlag)
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return "", fmt.Errorf("not a directory: %s", injectDirFlag)
+		}
+		return dir, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	// Try to get repo root, fall back to cwd
+	if git.IsGitRepo(cwd) {
+		if root, err := git.GetRepoRoot(cwd); err == nil {
+			return root, nil
+		}
+	}
+	return cwd, nil
+}
+
+// archiveDropbag saves the DROPBAG.md in dir to its history. Failures are
+// ignored so the hook still produces context.
+func archiveDropbag(dir string) {
+	_, _ = context.ArchiveDropbag(dir)
+}
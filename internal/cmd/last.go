@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var lastCmd = &cobra.Command{
+	Use:   "last",
+	Short: "Resume the most recently used experiment, project, or scratch",
+	Long: `Resume whatever you worked on last, without the picker.
+
+Same as "clade resume -".
+
+Examples:
+  clade last
+  clade last -o cursor     # Also open Cursor IDE
+  clade last --no-agent    # Just open the editor`,
+	Args: cobra.NoArgs,
+	RunE: runLast,
+}
+
+func init() {
+	rootCmd.AddCommand(lastCmd)
+	lastCmd.Flags().StringVarP(&resumeEditorFlag, "open", "o", "", "Open editor/IDE (cursor, code, nvim)")
+	lastCmd.Flags().StringVarP(&resumeEditorFlag, "editor", "e", "", "Alias for --open")
+	lastCmd.Flags().BoolVar(&resumeNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
+	lastCmd.Flags().BoolVar(&resumeNoEditorFlag, "no-editor", false, "Skip opening the editor")
+}
+
+func runLast(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	return resumeMostRecent(cfg, state)
+}
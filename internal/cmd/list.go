@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/files"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
 	"github.com/spf13/cobra"
@@ -17,8 +23,21 @@ var listCmd = &cobra.Command{
 	RunE:  runList,
 }
 
+var (
+	listJSONFlag bool
+	listSizeFlag bool
+	listTagFlag  []string
+)
+
+// listSizeWorkers bounds how many directories are measured at once
+const listSizeWorkers = 4
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().BoolVar(&listJSONFlag, "json", false, "Output as JSON")
+	listCmd.Flags().BoolVar(&listSizeFlag, "size", false, "Show disk usage of each item (slower)")
+	listCmd.Flags().StringSliceVarP(&listTagFlag, "tag", "t", nil, "Only show items with this tag (repeatable; items must have all)")
+	listCmd.RegisterFlagCompletionFunc("tag", completeTags)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -31,6 +50,19 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load state: %w", err)
 	}
+	if len(listTagFlag) > 0 {
+		state = filterStateByTags(state, listTagFlag)
+	}
+
+	// Sizes stay nil unless requested, which hides them in the output
+	var sizes map[string]int64
+	if listSizeFlag {
+		sizes = dirSizes(statePaths(state))
+	}
+
+	if listJSONFlag {
+		return printListJSON(state, sizes)
+	}
 
 	hasContent := false
 
@@ -39,7 +71,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		hasContent = true
 		ui.Header("Experiments:")
 		for _, exp := range state.Experiments {
-			printExperiment(exp)
+			printExperiment(cfg, exp, sizes)
 		}
 	}
 
@@ -48,7 +80,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		hasContent = true
 		ui.Header("Projects:")
 		for _, proj := range state.Projects {
-			printProject(proj)
+			printProject(cfg, proj, sizes)
 		}
 	}
 
@@ -57,10 +89,14 @@ func runList(cmd *cobra.Command, args []string) error {
 		hasContent = true
 		ui.Header("Scratch:")
 		for _, scratch := range state.Scratches {
-			printScratch(scratch)
+			printScratch(scratch, cfg.StaleAfter(), sizes)
 		}
 	}
 
+	if !hasContent && len(listTagFlag) > 0 {
+		ui.Info("Nothing tagged %s", strings.Join(listTagFlag, " and "))
+		return nil
+	}
 	if !hasContent {
 		ui.Info("No active experiments, projects, or scratch folders")
 		ui.Detail("Create one with: clade exp <name>")
@@ -70,7 +106,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printExperiment(exp *config.Experiment) {
+func printExperiment(cfg *config.Config, exp *config.Experiment, sizes map[string]int64) {
 	repoName := filepath.Base(exp.Repo)
 	age := formatAge(exp.LastUsed)
 
@@ -82,9 +118,9 @@ func printExperiment(exp *config.Experiment) {
 		status = ui.Green("clean")
 	}
 
-	// Check if stale (older than 7 days)
+	// Check if stale
 	staleMarker := ""
-	if time.Since(exp.LastUsed) > 7*24*time.Hour {
+	if time.Since(exp.LastUsed) > cfg.StaleAfter() {
 		staleMarker = " " + ui.Yellow("⚠")
 	}
 
@@ -93,34 +129,97 @@ func printExperiment(exp *config.Experiment) {
 	ui.KeyValue("Path", exp.Path)
 	ui.KeyValue("Age", age)
 	ui.KeyValue("Status", status)
+	ui.KeyValue("Remote", describeBranchInfo(git.CheckBranchLocal(exp.Repo, cfg.Remote, exp.Branch)))
 	if exp.Ticket != "" {
 		ui.KeyValue("Ticket", exp.Ticket)
 	}
+	printTags(exp.Tags)
+	printSize(sizes, exp.Path)
 	fmt.Println()
 }
 
-func printProject(proj *config.Project) {
+func printProject(cfg *config.Config, proj *config.Project, sizes map[string]int64) {
 	age := formatAge(proj.LastUsed)
 
-	var repoNames []string
-	for _, r := range proj.Repos {
-		repoNames = append(repoNames, r.Name)
-	}
-
 	fmt.Printf("  %s\n", ui.Cyan(proj.Name))
-	ui.KeyValue("Branch", proj.Branch)
+	ui.KeyValue("Branch", projectBranchSummary(proj))
 	ui.KeyValue("Path", proj.Path)
-	ui.KeyValue("Repos", fmt.Sprintf("%v", repoNames))
 	ui.KeyValue("Age", age)
+	printTags(proj.Tags)
+	printSize(sizes, proj.Path)
+	fmt.Printf("  %s:\n", ui.Dim("Repos"))
+
+	width := 0
+	for _, repo := range proj.Repos {
+		if len(repo.Name) > width {
+			width = len(repo.Name)
+		}
+	}
+	for _, repo := range proj.Repos {
+		fmt.Printf("    %-*s  %s\n", width, repo.Name, describeProjectRepo(cfg, proj, repo))
+	}
 	fmt.Println()
 }
 
-func printScratch(scratch *config.Scratch) {
+// describeProjectRepo summarizes one project repo's worktree: its branch,
+// uncommitted changes, and where it stands against the remote. Only local
+// refs are used, so it's as fresh as the last fetch.
+func describeProjectRepo(cfg *config.Config, proj *config.Project, repo config.ProjectRepo) string {
+	path := filepath.Join(proj.Path, repo.Name)
+	if !git.IsGitRepo(path) {
+		return ui.Red("worktree missing")
+	}
+
+	branch := proj.RepoBranch(repo)
+	parts := []string{ui.Dim(branch)}
+	if current, err := git.GetCurrentBranch(path); err == nil && current != branch {
+		parts[0] = ui.Yellow("on " + current)
+		branch = current
+	}
+
+	if hasChanges, _ := git.HasUncommittedChanges(path); hasChanges {
+		parts = append(parts, ui.Yellow("uncommitted changes"))
+	} else {
+		parts = append(parts, ui.Green("clean"))
+	}
+
+	info := git.CheckBranchLocal(repo.Source, cfg.Remote, branch)
+	remote := describeBranchInfo(info)
+	if info.Diverged {
+		remote = ui.Yellow(remote)
+	}
+	parts = append(parts, remote)
+
+	return strings.Join(parts, ", ")
+}
+
+// describeBranchInfo summarizes a branch against its remote counterpart
+func describeBranchInfo(info git.BranchInfo) string {
+	switch info.Status {
+	case git.BranchLocalOnly:
+		return "not pushed"
+	case git.BranchRemoteOnly:
+		return "remote only"
+	case git.BranchBoth:
+		switch {
+		case info.Diverged:
+			return fmt.Sprintf("diverged (%d ahead, %d behind)", info.LocalAhead, info.RemoteBehind)
+		case info.LocalAhead > 0:
+			return fmt.Sprintf("%d ahead", info.LocalAhead)
+		case info.RemoteBehind > 0:
+			return fmt.Sprintf("%d behind", info.RemoteBehind)
+		}
+		return "in sync"
+	}
+	return "branch not found"
+}
+
+func printScratch(scratch *config.Scratch, staleAfter time.Duration, sizes map[string]int64) {
 	age := formatAge(scratch.LastUsed)
 
-	// Check if stale (older than 7 days)
+	// Check if stale
 	staleMarker := ""
-	if time.Since(scratch.LastUsed) > 7*24*time.Hour {
+	if time.Since(scratch.LastUsed) > staleAfter {
 		staleMarker = " " + ui.Yellow("⚠")
 	}
 
@@ -130,9 +229,265 @@ func printScratch(scratch *config.Scratch) {
 	if scratch.Ticket != "" {
 		ui.KeyValue("Ticket", scratch.Ticket)
 	}
+	if scratch.LinkedRepo != "" {
+		ui.KeyValue("Linked repo", scratch.LinkedRepo)
+	}
+	printTags(scratch.Tags)
+	printSize(sizes, scratch.Path)
 	fmt.Println()
 }
 
+// printTags prints an item's tags, if it has any
+func printTags(tags []string) {
+	if len(tags) > 0 {
+		ui.KeyValue("Tags", formatTags(tags))
+	}
+}
+
+// filterStateByTags returns a copy of state holding only items that have
+// every one of tags
+func filterStateByTags(state *config.State, tags []string) *config.State {
+	filtered := *state
+	filtered.Experiments = make(map[string]*config.Experiment)
+	filtered.Projects = make(map[string]*config.Project)
+	filtered.Scratches = make(map[string]*config.Scratch)
+	for key, exp := range state.Experiments {
+		if hasAllTags(exp.Tags, tags) {
+			filtered.Experiments[key] = exp
+		}
+	}
+	for key, proj := range state.Projects {
+		if hasAllTags(proj.Tags, tags) {
+			filtered.Projects[key] = proj
+		}
+	}
+	for key, scratch := range state.Scratches {
+		if hasAllTags(scratch.Tags, tags) {
+			filtered.Scratches[key] = scratch
+		}
+	}
+	return &filtered
+}
+
+// printSize prints the measured size of path, if sizes were requested
+func printSize(sizes map[string]int64, path string) {
+	if sizes == nil {
+		return
+	}
+	if size, ok := sizes[path]; ok {
+		ui.KeyValue("Size", formatSize(size))
+	}
+}
+
+// statePaths returns the directory of every tracked item
+func statePaths(state *config.State) []string {
+	var paths []string
+	for _, exp := range state.Experiments {
+		paths = append(paths, exp.Path)
+	}
+	for _, proj := range state.Projects {
+		paths = append(paths, proj.Path)
+	}
+	for _, scratch := range state.Scratches {
+		paths = append(paths, scratch.Path)
+	}
+	return paths
+}
+
+// dirSizes measures directories concurrently. Paths that can't be measured
+// (e.g. missing) are left out of the result.
+func dirSizes(paths []string) map[string]int64 {
+	sizes := make(map[string]int64)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan string)
+	for i := 0; i < listSizeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				size, err := files.DirSize(path)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				sizes[path] = size
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	return sizes
+}
+
+// Item statuses reported by `clade list --json`
+const (
+	listStatusClean   = "clean"
+	listStatusDirty   = "dirty"
+	listStatusMissing = "missing"
+	listStatusUnknown = "unknown"
+	listStatusNoGit   = "no-git"
+)
+
+// listOutput is the JSON document written by `clade list --json`
+type listOutput struct {
+	Experiments []listItem `json:"experiments"`
+	Projects    []listItem `json:"projects"`
+	Scratches   []listItem `json:"scratches"`
+}
+
+// listItem is a single experiment, project, or scratch in JSON output
+type listItem struct {
+	Name     string         `json:"name"`
+	Repo     string         `json:"repo,omitempty"`
+	Path     string         `json:"path"`
+	Branch   string         `json:"branch,omitempty"`
+	Ticket   string         `json:"ticket,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+	Created  time.Time      `json:"created"`
+	LastUsed time.Time      `json:"last_used"`
+	Status   string         `json:"status"`
+	Repos    []listRepoItem `json:"repos,omitempty"`
+	Size     *int64         `json:"size_bytes,omitempty"`
+}
+
+// listRepoItem is a single repo within a project in JSON output
+type listRepoItem struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+func printListJSON(state *config.State, sizes map[string]int64) error {
+	output := listOutput{
+		Experiments: []listItem{},
+		Projects:    []listItem{},
+		Scratches:   []listItem{},
+	}
+
+	for _, exp := range state.Experiments {
+		output.Experiments = append(output.Experiments, listItem{
+			Name:     exp.Name,
+			Repo:     exp.Repo,
+			Path:     exp.Path,
+			Branch:   exp.Branch,
+			Ticket:   exp.Ticket,
+			Tags:     exp.Tags,
+			Created:  exp.Created,
+			LastUsed: exp.LastUsed,
+			Status:   worktreeStatus(exp.Path),
+			Size:     lookupSize(sizes, exp.Path),
+		})
+	}
+
+	for _, proj := range state.Projects {
+		item := listItem{
+			Name:     proj.Name,
+			Path:     proj.Path,
+			Branch:   proj.Branch,
+			Tags:     proj.Tags,
+			Created:  proj.Created,
+			LastUsed: proj.LastUsed,
+			Repos:    []listRepoItem{},
+			Size:     lookupSize(sizes, proj.Path),
+		}
+		for _, r := range proj.Repos {
+			repoPath := filepath.Join(proj.Path, r.Name)
+			item.Repos = append(item.Repos, listRepoItem{
+				Name:   r.Name,
+				Source: r.Source,
+				Path:   repoPath,
+				Status: worktreeStatus(repoPath),
+			})
+		}
+		item.Status = projectStatus(proj.Path, item.Repos)
+		output.Projects = append(output.Projects, item)
+	}
+
+	for _, scratch := range state.Scratches {
+		status := listStatusNoGit
+		if _, err := os.Stat(scratch.Path); os.IsNotExist(err) {
+			status = listStatusMissing
+		}
+		output.Scratches = append(output.Scratches, listItem{
+			Name:     scratch.Name,
+			Path:     scratch.Path,
+			Ticket:   scratch.Ticket,
+			Tags:     scratch.Tags,
+			Created:  scratch.Created,
+			LastUsed: scratch.LastUsed,
+			Status:   status,
+			Size:     lookupSize(sizes, scratch.Path),
+		})
+	}
+
+	// Map iteration order is random - sort so output is stable
+	for _, items := range [][]listItem{output.Experiments, output.Projects, output.Scratches} {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Name != items[j].Name {
+				return items[i].Name < items[j].Name
+			}
+			return items[i].Repo < items[j].Repo
+		})
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// lookupSize returns the measured size of path for JSON output, or nil
+func lookupSize(sizes map[string]int64, path string) *int64 {
+	size, ok := sizes[path]
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// worktreeStatus reports whether a worktree is clean, dirty, or missing
+func worktreeStatus(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return listStatusMissing
+	}
+	hasChanges, err := git.HasUncommittedChanges(path)
+	if err != nil {
+		return listStatusUnknown
+	}
+	if hasChanges {
+		return listStatusDirty
+	}
+	return listStatusClean
+}
+
+// projectStatus summarizes a project: missing if the project folder is gone,
+// otherwise the "worst" status of its repos
+func projectStatus(path string, repos []listRepoItem) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return listStatusMissing
+	}
+	status := listStatusClean
+	for _, r := range repos {
+		switch r.Status {
+		case listStatusDirty:
+			status = listStatusDirty
+		case listStatusMissing, listStatusUnknown:
+			if status == listStatusClean {
+				status = r.Status
+			}
+		}
+	}
+	return status
+}
+
 func formatAge(t time.Time) string {
 	d := time.Since(t)
 
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logCountFlag int
+	logAllFlag   bool
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <name>",
+	Short: "Show the commits on an experiment's or project's branch",
+	Long: `Show the commits an item's branch adds on top of the default branch.
+
+Runs in the source repo, so it works even if the worktree is gone. For
+projects every repo is shown. Use --all for the branch's full history.
+
+Examples:
+  clade log try-redis
+  clade log try-redis -n 5
+  clade log my-project --all`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runLog,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	logCmd.Flags().IntVarP(&logCountFlag, "count", "n", 20, "Maximum number of commits to show (0 for no limit)")
+	logCmd.Flags().BoolVar(&logAllFlag, "all", false, "Show the full history, not just commits ahead of the default branch")
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		return printBranchLog(cfg, exp.Name, exp.Repo, exp.Branch)
+
+	case item.Project != nil:
+		proj := item.Project
+		for _, repo := range proj.Repos {
+			if err := printBranchLog(cfg, repo.Name, repo.Source, proj.RepoBranch(repo)); err != nil {
+				ui.Warn("%s: %v", repo.Name, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("'%s' is a scratch, which has no git history", name)
+}
+
+// printBranchLog prints the commits on branch, by default only those not yet
+// on the default branch
+func printBranchLog(cfg *config.Config, label, repoPath, branch string) error {
+	if !git.RefExists(repoPath, branch) {
+		return fmt.Errorf("branch %s not found in %s", branch, repoPath)
+	}
+
+	if logAllFlag {
+		ui.Header("%s: %s", label, branch)
+		commits, err := git.GetBranchCommits(repoPath, branch, logCountFlag)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+		printCommits(commits, "No commits")
+		return nil
+	}
+
+	base := defaultBaseRef(cfg, repoPath)
+	ui.Header("%s: %s %s", label, branch, ui.Dim("(ahead of "+base+")"))
+	commits, err := git.GetCommitsAhead(repoPath, base, branch, logCountFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	printCommits(commits, "No commits ahead of "+base)
+	return nil
+}
+
+// defaultBaseRef returns the ref new branches are compared against: the
+// remote's default branch when fetched, otherwise the local default branch
+func defaultBaseRef(cfg *config.Config, repoPath string) string {
+	defaultBranch := git.GetDefaultBranch(repoPath, cfg.Remote)
+	if base := cfg.Remote + "/" + defaultBranch; git.RefExists(repoPath, base) {
+		return base
+	}
+	return defaultBranch
+}
+
+// printCommits prints one commit per line, or empty when there are none
+func printCommits(commits []string, empty string) {
+	if len(commits) == 0 {
+		ui.Detail("%s", empty)
+		return
+	}
+	for _, commit := range commits {
+		fmt.Printf("  %s\n", commit)
+	}
+}
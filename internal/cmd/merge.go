@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeNoFFFlag         bool
+	mergeDeleteBranchFlag bool
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <name>",
+	Short: "Merge an experiment or feature into the default branch",
+	Long: `Merge an experiment or feature branch back into the repo's default branch.
+
+The merge happens in the source repo (not the worktree): clade fetches,
+checks out the default branch, fast-forwards it to the remote, merges the
+experiment branch, and switches the source repo back to the branch it was
+on. The worktree must have no uncommitted changes, and the source repo
+can't be bare.
+
+Examples:
+  clade merge try-redis                   # Merge, then offer cleanup
+  clade merge try-redis --no-ff           # Always create a merge commit
+  clade merge try-redis --delete-branch   # Merge, remove worktree and branch`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMerge,
+	ValidArgsFunction: completeExperimentNames,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.Flags().BoolVar(&mergeNoFFFlag, "no-ff", false, "Create a merge commit even if fast-forward is possible")
+	mergeCmd.Flags().BoolVar(&mergeDeleteBranchFlag, "delete-branch", false, "Remove the worktree and delete the branch after merging")
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case item == nil:
+		return fmt.Errorf("experiment '%s' not found", name)
+	case item.Project != nil:
+		return fmt.Errorf("'%s' is a project - merge each repo's branch from its source repo", name)
+	case item.Experiment == nil:
+		return fmt.Errorf("'%s' is a %s - only experiments and features can be merged", name, item.Type)
+	}
+	key, exp := item.Key, item.Experiment
+
+	ui.Header("Merging: %s", exp.Name)
+	ui.KeyValue("Repo", exp.Repo)
+	ui.KeyValue("Branch", exp.Branch)
+
+	// The merge happens in the source repo's checkout, which a bare repo doesn't have
+	if git.IsBareRepo(exp.Repo) {
+		ui.Error("Source repo is bare, so there's no checkout to merge in")
+		ui.Detail("Push the branch and open a PR instead: clade pr %s", exp.Name)
+		return fmt.Errorf("%s is a bare repository", exp.Repo)
+	}
+
+	if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
+		ui.Error("Worktree has uncommitted changes")
+		ui.Detail("Commit or stash them first: cd %s", exp.Path)
+		return fmt.Errorf("uncommitted changes in %s", exp.Path)
+	}
+
+	// The default branch gets checked out in the source repo, so it must be clean too
+	if hasChanges, _ := git.HasUncommittedChanges(exp.Repo); hasChanges {
+		ui.Error("Source repo has uncommitted changes")
+		ui.Detail("Commit or stash them first: cd %s", exp.Repo)
+		return fmt.Errorf("uncommitted changes in %s", exp.Repo)
+	}
+
+	ui.Info("Fetching from %s...", cfg.Remote)
+	if err := git.Fetch(exp.Repo, cfg.Remote); err != nil {
+		ui.Warn("Fetch %s, merging into local state", describeFetchError(err))
+	}
+
+	// The source repo goes back to this branch once the merge is done
+	originalBranch, err := git.GetCurrentBranch(exp.Repo)
+	if err != nil {
+		return err
+	}
+	if originalBranch == "HEAD" {
+		ui.Detail("Check out a branch first: cd %s", exp.Repo)
+		return fmt.Errorf("source repo %s is on a detached HEAD", exp.Repo)
+	}
+
+	defaultBranch := git.GetDefaultBranch(exp.Repo, cfg.Remote)
+	ui.KeyValue("Into", defaultBranch)
+	fmt.Println()
+
+	if originalBranch != defaultBranch {
+		ui.Info("Checking out %s in source repo...", defaultBranch)
+		if err := git.Checkout(exp.Repo, defaultBranch); err != nil {
+			return err
+		}
+	}
+
+	// Bring the local default branch up to date before merging
+	remoteRef := cfg.Remote + "/" + defaultBranch
+	if info := git.CheckBranch(exp.Repo, cfg.Remote, defaultBranch); info.Status == git.BranchBoth {
+		if err := git.FastForward(exp.Repo, remoteRef); err != nil {
+			ui.Warn("Could not fast-forward %s to %s", defaultBranch, remoteRef)
+		}
+	}
+
+	ui.Info("Merging %s into %s...", exp.Branch, defaultBranch)
+	if err := git.MergeBranch(exp.Repo, exp.Branch, mergeNoFFFlag); err != nil {
+		var conflict *git.ConflictError
+		if errors.As(err, &conflict) {
+			return handleMergeConflict(exp, defaultBranch, originalBranch, conflict)
+		}
+		restoreBranch(exp.Repo, originalBranch, defaultBranch)
+		return err
+	}
+	ui.Success("Merged %s into %s", exp.Branch, defaultBranch)
+	restoreBranch(exp.Repo, originalBranch, defaultBranch)
+
+	if mergeDeleteBranchFlag {
+		// The branch was just merged, so only uncommitted changes can be lost
+		cleanupListConfirmed = true
+		return cleanupExperiment(cfg, state, key, exp)
+	}
+
+	fmt.Println()
+	if !ui.Confirm(fmt.Sprintf("Clean up experiment '%s'", exp.Name), false) {
+		ui.Detail("Clean up later with: clade cleanup %s", exp.Name)
+		return nil
+	}
+
+	return cleanupExperiment(cfg, state, key, exp)
+}
+
+// restoreBranch switches repoPath back to original after merging into current
+func restoreBranch(repoPath, original, current string) {
+	if original == current {
+		return
+	}
+	if err := git.Checkout(repoPath, original); err != nil {
+		ui.Warn("Could not switch source repo back to %s: %v", original, err)
+		return
+	}
+	ui.Info("Switched source repo back to %s", original)
+}
+
+// handleMergeConflict lists conflicted files and offers to abort the merge.
+// An aborted merge puts the source repo back on originalBranch.
+func handleMergeConflict(exp *config.Experiment, defaultBranch, originalBranch string, conflict *git.ConflictError) error {
+	ui.Error("Merge conflicts in %s:", exp.Repo)
+	for _, file := range conflict.Files {
+		ui.Detail("%s", file)
+	}
+	fmt.Println()
+
+	if ui.Confirm(fmt.Sprintf("Abort the merge and restore %s", defaultBranch), false) {
+		if err := git.AbortMerge(exp.Repo); err != nil {
+			return err
+		}
+		ui.Info("Merge aborted")
+		restoreBranch(exp.Repo, originalBranch, defaultBranch)
+		return conflict
+	}
+
+	ui.Info("Merge left in progress")
+	ui.Detail("Resolve conflicts in: %s", exp.Repo)
+	ui.Detail("Then run: git commit")
+	ui.Detail("Or abort with: git merge --abort")
+	if originalBranch != defaultBranch {
+		ui.Detail("Afterwards, switch back with: git checkout %s", originalBranch)
+	}
+	return conflict
+}
+
+// completeExperimentNames provides shell completion for experiment names
+func completeExperimentNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, exp := range state.Experiments {
+		names = append(names, exp.Name+"\t"+exp.Branch)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
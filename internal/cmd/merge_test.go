@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestMergeRestoresSourceBranch(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &mergeNoFFFlag, false)
+	setFlag(t, &mergeDeleteBranchFlag, false)
+	t.Setenv("CLADE_YES", "1") // prompts take their defaults (no cleanup)
+	origin := newTestRepo(t, "origin")
+	repo := filepath.Join(filepath.Dir(origin), "api")
+	runTestGit(t, origin, "clone", "-q", origin, repo)
+	if code := executeArgs(t, "exp", "spike", "-r", repo, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+		t.Fatalf("exp exited %d", code)
+	}
+	expPath := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(repo, "spike"))
+	writeTestFile(t, filepath.Join(expPath, "spike.txt"), "spike\n")
+	runTestGit(t, expPath, "add", "-A")
+	runTestGit(t, expPath, "commit", "-q", "-m", "spike work")
+	spikeHead := runTestGit(t, expPath, "rev-parse", "HEAD")
+
+	// The user is working on another branch in the main checkout
+	runTestGit(t, repo, "checkout", "-q", "-b", "wip")
+
+	if code := executeArgs(t, "merge", "spike"); code != 0 {
+		t.Fatalf("merge exited %d", code)
+	}
+	if got := runTestGit(t, repo, "rev-parse", "main"); got != spikeHead {
+		t.Errorf("main is at %s, want the spike commit %s", got, spikeHead)
+	}
+	if got := runTestGit(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); got != "wip" {
+		t.Errorf("source repo left on %s, want it back on wip", got)
+	}
+}
+
+func TestMergeRefusesAmbiguousNamesAndBareRepos(t *testing.T) {
+	setupTestEnv(t)
+	resetExpFlags(t)
+	t.Setenv("CLADE_YES", "1")
+	work := newTestRepo(t, "api")
+	personal := newTestRepo(t, "api")
+	for _, repo := range []string{work, personal} {
+		if code := executeArgs(t, "exp", "spike", "-r", repo, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+			t.Fatalf("exp exited %d", code)
+		}
+	}
+
+	// Which 'spike' is meant needs a prompt, which --yes can't answer
+	if code := executeArgs(t, "merge", "spike"); code == 0 {
+		t.Error("merge picked one of two experiments named spike")
+	}
+	for _, repo := range []string{work, personal} {
+		if got := runTestGit(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); got != "main" {
+			t.Errorf("%s was switched to %s", repo, got)
+		}
+	}
+
+	bare := filepath.Join(t.TempDir(), "lib.git")
+	runTestGit(t, work, "clone", "-q", "--bare", work, bare)
+	if code := executeArgs(t, "exp", "bare-spike", "-r", bare, "-b", "exp/bare-spike", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+		t.Fatalf("exp from a bare repo exited %d", code)
+	}
+	var code int
+	out := captureStdout(t, func() { code = executeArgs(t, "--color", "never", "merge", "bare-spike") })
+	if code == 0 || !strings.Contains(out, "Source repo is bare") {
+		t.Errorf("merge from a bare repo: exit %d, output:\n%s", code, out)
+	}
+}
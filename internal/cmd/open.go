@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"time"
+	"sort"
+	"strings"
 
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
@@ -18,16 +20,33 @@ var openCmd = &cobra.Command{
 Examples:
   cd $(clade open try-redis)
   cd $(clade open)              # Interactive picker
+  cd $(clade open -)            # Most recently used
 
-Tip: Add a shell alias for convenience:
-  alias cdo='cd $(clade open)'`,
+Use --shell to print a ready-to-eval cd command for shells where $(...)
+doesn't apply, and --print-exports to print CLADE_* variable assignments.
+
+Examples for other shells:
+  clade open try-redis --shell fish | source
+  clade open try-redis --shell powershell | Invoke-Expression
+  eval "$(clade open try-redis --print-exports)"
+
+Tip: For a cdo function that only changes directory on success, add
+  eval "$(clade shell-init bash)"
+to your shell rc file (see "clade shell-init --help").`,
 	Args:              cobra.MaximumNArgs(1),
 	RunE:              runOpen,
 	ValidArgsFunction: completeResumableNames,
 }
 
+var (
+	openShellFlag        string
+	openPrintExportsFlag bool
+)
+
 func init() {
 	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().StringVar(&openShellFlag, "shell", "", "Print a cd command for this shell (bash, zsh, fish, powershell)")
+	openCmd.Flags().BoolVar(&openPrintExportsFlag, "print-exports", false, "Print CLADE_* variable assignments")
 }
 
 func runOpen(cmd *cobra.Command, args []string) error {
@@ -41,99 +60,50 @@ func runOpen(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load state: %w", err)
 	}
 
+	switch openShellFlag {
+	case "", "bash", "zsh", "sh", "fish", "powershell", "pwsh":
+	default:
+		return fmt.Errorf("unsupported shell '%s' (use bash, zsh, fish, or powershell)", openShellFlag)
+	}
+
 	// If no args, show picker
 	if len(args) == 0 {
 		return openInteractive(cfg, state)
 	}
 
 	name := args[0]
-
-	// Check experiments
-	for _, exp := range state.Experiments {
-		if exp.Name == name {
-			return openPath(cfg, state, exp.Path, "experiment", name)
+	if name == "-" {
+		item, ok := mostRecentItem(state)
+		if !ok {
+			return fmt.Errorf("no experiments, projects, or scratch folders")
 		}
+		return openPath(cfg, item)
 	}
 
-	// Check projects
-	for _, proj := range state.Projects {
-		if proj.Name == name {
-			return openPath(cfg, state, proj.Path, "project", name)
-		}
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
 	}
-
-	// Check scratches
-	for _, scratch := range state.Scratches {
-		if scratch.Name == name {
-			return openPath(cfg, state, scratch.Path, "scratch", name)
-		}
+	if item == nil {
+		return fmt.Errorf("not found: %s", name)
 	}
-
-	return fmt.Errorf("not found: %s", name)
+	return openPath(cfg, item)
 }
 
 func openInteractive(cfg *config.Config, state *config.State) error {
-	if len(state.Experiments) == 0 && len(state.Projects) == 0 && len(state.Scratches) == 0 {
+	items := recentItems(state)
+	if len(items) == 0 {
 		return fmt.Errorf("no experiments, projects, or scratch folders")
 	}
 
-	type pickItem struct {
-		Name     string
-		Path     string
-		Type     string
-		LastUsed time.Time
-		Display  string
-	}
-
-	var items []pickItem
-
-	for _, exp := range state.Experiments {
-		age := formatAge(exp.LastUsed)
-		items = append(items, pickItem{
-			Name:     exp.Name,
-			Path:     exp.Path,
-			Type:     "experiment",
-			LastUsed: exp.LastUsed,
-			Display:  fmt.Sprintf("%s [exp] (%s)", exp.Name, age),
-		})
-	}
-
-	for _, proj := range state.Projects {
-		age := formatAge(proj.LastUsed)
-		items = append(items, pickItem{
-			Name:     proj.Name,
-			Path:     proj.Path,
-			Type:     "project",
-			LastUsed: proj.LastUsed,
-			Display:  fmt.Sprintf("%s [project] (%s)", proj.Name, age),
-		})
-	}
-
-	for _, scratch := range state.Scratches {
-		age := formatAge(scratch.LastUsed)
-		items = append(items, pickItem{
-			Name:     scratch.Name,
-			Path:     scratch.Path,
-			Type:     "scratch",
-			LastUsed: scratch.LastUsed,
-			Display:  fmt.Sprintf("%s [scratch] (%s)", scratch.Name, age),
-		})
-	}
-
-	// Sort by last used
-	for i := 0; i < len(items)-1; i++ {
-		for j := i + 1; j < len(items); j++ {
-			if items[j].LastUsed.After(items[i].LastUsed) {
-				items[i], items[j] = items[j], items[i]
-			}
-		}
-	}
-
 	var displayItems []string
 	for _, item := range items {
-		displayItems = append(displayItems, item.Display)
+		displayItems = append(displayItems, fmt.Sprintf("%s %s (%s)", item.Name, item.label(), formatAge(item.LastUsed)))
 	}
 
+	if err := ui.Interactive("pick what to open without a name"); err != nil {
+		return err
+	}
 	prompt := promptui.Select{
 		Label:  "Select to open",
 		Items:  displayItems,
@@ -147,37 +117,80 @@ func openInteractive(cfg *config.Config, state *config.State) error {
 	}
 
 	selected := items[idx]
-	return openPath(cfg, state, selected.Path, selected.Type, selected.Name)
+	return openPath(cfg, selected)
 }
 
-func openPath(cfg *config.Config, state *config.State, path, itemType, name string) error {
+func openPath(cfg *config.Config, item *resolvedItem) error {
+	path, itemType, name := item.Path, item.Type, item.Name
+
 	// Verify path exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return fmt.Errorf("path no longer exists: %s", path)
 	}
 
-	// Update last used timestamp
-	switch itemType {
-	case "experiment":
-		for _, exp := range state.Experiments {
-			if exp.Name == name {
-				exp.LastUsed = time.Now()
-				state.Experiments[config.ExperimentKey(exp.Repo, exp.Name)] = exp
-				break
-			}
+	touchItem(cfg, itemType, item.Key)
+
+	if openShellFlag == "" && !openPrintExportsFlag {
+		// Print path to stdout (clean, no decoration)
+		fmt.Println(path)
+		return nil
+	}
+
+	if openPrintExportsFlag {
+		env := sessionEnv(path)
+		if env["CLADE_NAME"] == "" {
+			env["CLADE_NAME"] = name
 		}
-	case "project":
-		if proj := state.Projects[name]; proj != nil {
-			proj.LastUsed = time.Now()
+		if env["CLADE_TYPE"] == "" {
+			env["CLADE_TYPE"] = itemType
 		}
-	case "scratch":
-		if scratch := state.Scratches[name]; scratch != nil {
-			scratch.LastUsed = time.Now()
+
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Println(shellExport(openShellFlag, key, env[key]))
 		}
 	}
-	state.Save(cfg)
-
-	// Print path to stdout (clean, no decoration)
-	fmt.Println(path)
+	if openShellFlag != "" {
+		fmt.Println(shellCd(openShellFlag, path))
+	}
 	return nil
 }
+
+// shellQuote quotes a value as a single-quoted literal for the given shell
+func shellQuote(shell, value string) string {
+	switch shell {
+	case "fish":
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+	case "powershell", "pwsh":
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	default:
+		return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+	}
+}
+
+// shellExport returns a statement that sets an environment variable
+func shellExport(shell, key, value string) string {
+	switch shell {
+	case "fish":
+		return fmt.Sprintf("set -gx %s %s", key, shellQuote(shell, value))
+	case "powershell", "pwsh":
+		return fmt.Sprintf("$env:%s = %s", key, shellQuote(shell, value))
+	default:
+		return fmt.Sprintf("export %s=%s", key, shellQuote(shell, value))
+	}
+}
+
+// shellCd returns a statement that changes to path
+func shellCd(shell, path string) string {
+	switch shell {
+	case "powershell", "pwsh":
+		return "Set-Location -LiteralPath " + shellQuote(shell, path)
+	default:
+		return "cd " + shellQuote(shell, path)
+	}
+}
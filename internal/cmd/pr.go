@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prTitleFlag string
+	prBodyFlag  string
+	prBaseFlag  string
+	prDraftFlag bool
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr <name>",
+	Short: "Open a pull request for a feature or experiment",
+	Long: `Push a feature/experiment branch and open a pull request with the gh CLI.
+
+The title defaults to "[TICKET] name" when a ticket was detected in the
+name, otherwise just the name. Requires gh (https://cli.github.com).
+
+Examples:
+  clade pr PROJ-1234-auth                    # Title: [PROJ-1234] PROJ-1234-auth
+  clade pr auth-fix --title "Fix auth"       # Custom title
+  clade pr auth-fix --body "Closes #12" --draft`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runPR,
+	ValidArgsFunction: completeExperimentNames,
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+	prCmd.Flags().StringVarP(&prTitleFlag, "title", "t", "", "Pull request title")
+	prCmd.Flags().StringVar(&prBodyFlag, "body", "", "Pull request body")
+	prCmd.Flags().StringVar(&prBaseFlag, "base", "", "Base branch (default: repo's default branch)")
+	prCmd.Flags().BoolVar(&prDraftFlag, "draft", false, "Open as a draft pull request")
+}
+
+func runPR(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if !git.GHInstalled() {
+		ui.Error("gh CLI not found")
+		ui.Detail("Install it from https://cli.github.com and run: gh auth login")
+		return fmt.Errorf("gh CLI not installed")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case item == nil:
+		return fmt.Errorf("experiment '%s' not found", name)
+	case item.Experiment == nil:
+		return fmt.Errorf("'%s' is a %s - pull requests are opened for experiments and features", name, item.Type)
+	}
+	exp := item.Experiment
+
+	base := prBaseFlag
+	if base == "" {
+		base = git.GetDefaultBranch(exp.Repo, cfg.Remote)
+	}
+
+	title := prTitleFlag
+	if title == "" {
+		title = defaultPRTitle(exp)
+	}
+
+	ui.Header("Pull request: %s", exp.Name)
+	ui.KeyValue("Repo", exp.Repo)
+	ui.KeyValue("Head", exp.Branch)
+	ui.KeyValue("Base", base)
+	ui.KeyValue("Title", title)
+	fmt.Println()
+
+	if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
+		ui.Warn("Worktree has uncommitted changes (they won't be in the PR)")
+	}
+
+	// Push if the branch isn't on the remote yet or has unpushed commits
+	git.Fetch(exp.Repo, cfg.Remote) // Ignore error - push will surface problems
+	info := git.CheckBranch(exp.Repo, cfg.Remote, exp.Branch)
+	if info.Status == git.BranchLocalOnly || (info.Status == git.BranchBoth && info.LocalAhead > 0) {
+		ui.Info("Pushing %s to %s...", exp.Branch, cfg.Remote)
+		if err := git.Push(exp.Repo, cfg.Remote, exp.Branch); err != nil {
+			return err
+		}
+	}
+
+	ui.Info("Creating pull request...")
+	url, err := git.CreatePullRequest(exp.Repo, git.PullRequest{
+		Base:  base,
+		Head:  exp.Branch,
+		Title: title,
+		Body:  prBodyFlag,
+		Draft: prDraftFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	ui.Success("Pull request created")
+	ui.KeyValue("URL", url)
+	return nil
+}
+
+// defaultPRTitle builds "[TICKET] name" from .clade.json, falling back to state
+func defaultPRTitle(exp *config.Experiment) string {
+	ticket := exp.Ticket
+	if metadata, err := context.ReadCladeMetadata(exp.Path); err == nil && metadata.Ticket != "" {
+		ticket = metadata.Ticket
+	}
+
+	if ticket == "" {
+		return exp.Name
+	}
+	return fmt.Sprintf("[%s] %s", ticket, exp.Name)
+}
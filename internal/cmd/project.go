@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/daniil-lyalko/clade/internal/agent"
@@ -18,12 +19,18 @@ import (
 )
 
 var (
-	projectEditorFlag    string
-	projectNoAgentFlag   bool
-	projectNoEditorFlag  bool
-	projectAddEditorFlag string
+	projectEditorFlag      string
+	projectNoAgentFlag     bool
+	projectNoEditorFlag    bool
+	projectDryRunFlag      bool
+	projectOfflineFlag     bool
+	projectJSONFlag        bool
+	projectAddEditorFlag   string
 	projectAddNoAgentFlag  bool
 	projectAddNoEditorFlag bool
+	projectNoSetupFlag     bool
+	projectAddNoSetupFlag  bool
+	projectAddBranchFlag   string
 )
 
 var projectCmd = &cobra.Command{
@@ -31,14 +38,18 @@ var projectCmd = &cobra.Command{
 	Short: "Create multi-repo workspace with unified branch",
 	Long: `Create a project workspace containing worktrees from multiple repositories.
 
-All repos in the project share the same branch name, making it easy to
-coordinate changes across repositories for a single feature.
+All repos in the project share the same branch name by default, making it
+easy to coordinate changes across repositories for a single feature. Each repo
+can override the branch when prompted (e.g. when backend and frontend use
+different naming).
 
 Examples:
   clade project                     # Interactive setup
   clade project api-integration     # Named project with interactive repo selection
   clade project foo -o cursor       # Open Cursor IDE
   clade project foo --no-agent      # Skip launching Claude
+  clade project foo --dry-run       # Show what would be created
+  clade project foo --offline       # Branch from local HEAD, skip fetch
 
 Creates:
   ~/clade/projects/{name}/
@@ -54,12 +65,15 @@ var projectAddCmd = &cobra.Command{
 	Short: "Add a repository to an existing project",
 	Long: `Add a new repository to an existing project.
 
-The new repo will use the same branch name as the existing project.
+The new repo uses the project's branch unless you pick another one at the
+prompt or with --branch (useful when the project branch is already taken in
+that repo).
 
 Examples:
   clade project add                           # Interactive: pick project and repo
   clade project add api-integration           # Pick repo from registered repos
-  clade project add api-integration backend   # Fully specified`,
+  clade project add api-integration backend   # Fully specified
+  clade project add api-integration backend -b feat/api-v2`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runProjectAdd,
 }
@@ -71,15 +85,30 @@ func init() {
 	projectCmd.Flags().StringVarP(&projectEditorFlag, "editor", "e", "", "Alias for --open")
 	projectCmd.Flags().BoolVar(&projectNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
 	projectCmd.Flags().BoolVar(&projectNoEditorFlag, "no-editor", false, "Skip opening the editor")
+	projectCmd.Flags().BoolVar(&projectDryRunFlag, "dry-run", false, "Show what would be created without creating anything")
+	projectCmd.Flags().BoolVar(&projectJSONFlag, "json", false, "Print the dry-run plan as JSON")
+	projectCmd.Flags().BoolVar(&projectNoSetupFlag, "no-setup", false, "Skip each repo's setup_command")
+	projectCmd.Flags().BoolVar(&projectOfflineFlag, "offline", false, "Skip fetching and branch from local HEAD (no remote/divergence info)")
 	projectAddCmd.Flags().StringVarP(&projectAddEditorFlag, "open", "o", "", "Open editor/IDE (cursor, code, nvim)")
 	projectAddCmd.Flags().StringVarP(&projectAddEditorFlag, "editor", "e", "", "Alias for --open")
 	projectAddCmd.Flags().BoolVar(&projectAddNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
 	projectAddCmd.Flags().BoolVar(&projectAddNoEditorFlag, "no-editor", false, "Skip opening the editor")
+	projectAddCmd.Flags().BoolVar(&projectAddNoSetupFlag, "no-setup", false, "Skip the repo's setup_command")
+	projectAddCmd.Flags().StringVarP(&projectAddBranchFlag, "branch", "b", "", "Branch for the new repo (default: the project branch)")
 }
 
 type projectRepo struct {
 	SourcePath string // Original repo path
 	FolderName string // Name in project directory
+	Branch     string // Branch override, empty for the project branch
+}
+
+// branchOr returns the repo's branch override, or the project branch
+func (r projectRepo) branchOr(projectBranch string) string {
+	if r.Branch != "" {
+		return r.Branch
+	}
+	return projectBranch
 }
 
 func runProject(cmd *cobra.Command, args []string) error {
@@ -88,15 +117,16 @@ func runProject(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if projectOfflineFlag {
+		git.SetOffline(true)
+	}
+
 	// Get project name
 	var projectName string
 	if len(args) > 0 {
 		projectName = args[0]
 	} else {
-		prompt := promptui.Prompt{
-			Label: "Project name",
-		}
-		projectName, err = prompt.Run()
+		projectName, err = ui.Input("Project name", "")
 		if err != nil {
 			return err
 		}
@@ -113,26 +143,30 @@ func runProject(cmd *cobra.Command, args []string) error {
 	}
 
 	if existing, ok := state.Projects[projectName]; ok {
+		if projectDryRunFlag {
+			return printCreatePlan(&createPlan{
+				Type:   "project",
+				Name:   projectName,
+				Path:   existing.Path,
+				Branch: existing.Branch,
+				Errors: alreadyTracked("project", projectName, existing.Path),
+			}, projectJSONFlag)
+		}
 		ui.Warn("Project '%s' already exists", projectName)
 		ui.KeyValue("Path", existing.Path)
 
-		prompt := promptui.Prompt{
-			Label:     "Resume existing project",
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		if err == nil {
+		if ui.Confirm("Resume existing project", false) {
 			return launchProjectSession(cfg, existing, projectEditorFlag, projectNoAgentFlag, projectNoEditorFlag)
 		}
 		return nil
 	}
 
-	// Get branch name
-	prompt := promptui.Prompt{
-		Label:   "Branch name",
-		Default: "feat/" + projectName,
+	if err := checkNameAvailable(state, projectName, "project"); err != nil {
+		return err
 	}
-	branchName, err := prompt.Run()
+
+	// Get branch name
+	branchName, err := ui.Input("Branch name", cfg.FeatBranchPrefix+projectName)
 	if err != nil {
 		return err
 	}
@@ -150,10 +184,7 @@ func runProject(cmd *cobra.Command, args []string) error {
 			ui.Detail("Registered repos: %s", strings.Join(getRepoNames(cfg), ", "))
 		}
 
-		prompt := promptui.Prompt{
-			Label: "Repo",
-		}
-		repoInput, err := prompt.Run()
+		repoInput, err := ui.Input("Repo", "")
 		if err != nil || repoInput == "" {
 			break
 		}
@@ -175,18 +206,24 @@ func runProject(cmd *cobra.Command, args []string) error {
 
 		// Get folder name
 		defaultName := filepath.Base(repoPath)
-		folderPrompt := promptui.Prompt{
-			Label:   "  Folder name",
-			Default: defaultName,
+		folderName, err := ui.Input("  Folder name", defaultName)
+		if err != nil {
+			continue
 		}
-		folderName, err := folderPrompt.Run()
+		folderName = sanitizeForPath(folderName)
+
+		repoBranch, err := ui.Input("  Branch", branchName)
 		if err != nil {
 			continue
 		}
+		if repoBranch == branchName {
+			repoBranch = ""
+		}
 
 		repos = append(repos, projectRepo{
 			SourcePath: repoPath,
 			FolderName: folderName,
+			Branch:     repoBranch,
 		})
 
 		ui.Success("Added %s -> %s", filepath.Base(repoPath), folderName)
@@ -198,11 +235,7 @@ func runProject(cmd *cobra.Command, args []string) error {
 
 	if len(repos) < 2 {
 		ui.Warn("Only one repo added. Consider using 'clade exp' for single-repo work.")
-		prompt := promptui.Prompt{
-			Label:     "Continue anyway",
-			IsConfirm: true,
-		}
-		if _, err := prompt.Run(); err != nil {
+		if !ui.Confirm("Continue anyway", false) {
 			return nil
 		}
 	}
@@ -211,17 +244,20 @@ func runProject(cmd *cobra.Command, args []string) error {
 	ui.Info("Checking branches...")
 	fmt.Println()
 
-	var repoPaths []string
+	branches := make(map[string]string)
 	for _, r := range repos {
-		repoPaths = append(repoPaths, r.SourcePath)
+		branches[r.SourcePath] = r.branchOr(branchName)
 	}
 
-	branchResults := git.PreflightCheck(repoPaths, branchName)
+	branchResults := git.PreflightCheck(branches, cfg.Remote)
 	hasWarnings := false
 
 	for _, repo := range repos {
 		info := branchResults[repo.SourcePath]
 		repoName := repo.FolderName
+		if repo.Branch != "" {
+			repoName += " (" + repo.Branch + ")"
+		}
 
 		switch info.Status {
 		case git.BranchNotFound:
@@ -248,20 +284,20 @@ func runProject(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 
+	// Create project
+	projectPath := filepath.Join(cfg.ProjectsDir(), projectName)
+
+	if projectDryRunFlag {
+		return printCreatePlan(planProject(cfg, projectName, projectPath, branchName, repos, branchResults), projectJSONFlag)
+	}
+
 	if hasWarnings {
-		prompt := promptui.Prompt{
-			Label:     "Warnings detected. Proceed anyway",
-			IsConfirm: true,
-		}
-		if _, err := prompt.Run(); err != nil {
+		if !ui.Confirm("Warnings detected. Proceed anyway", false) {
 			ui.Info("Aborted.")
 			return nil
 		}
 	}
 
-	// Create project
-	projectPath := filepath.Join(cfg.ProjectsDir(), projectName)
-
 	ui.Header("Creating project: %s", projectName)
 	ui.KeyValue("Path", projectPath)
 	ui.KeyValue("Branch", branchName)
@@ -273,48 +309,27 @@ func runProject(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create worktrees for each repo
-	var createdRepos []config.ProjectRepo
-	for _, repo := range repos {
-		worktreePath := filepath.Join(projectPath, repo.FolderName)
-		info := branchResults[repo.SourcePath]
-
-		ui.Info("Creating %s...", repo.FolderName)
-
-		var wtErr error
-		switch info.Status {
-		case git.BranchNotFound:
-			wtErr = git.CreateWorktreeNew(repo.SourcePath, worktreePath, branchName)
-		case git.BranchLocalOnly, git.BranchBoth:
-			wtErr = git.CreateWorktreeFromBranch(repo.SourcePath, worktreePath, branchName)
-		case git.BranchRemoteOnly:
-			wtErr = git.CreateWorktreeTrackRemote(repo.SourcePath, worktreePath, branchName)
-		}
-
-		if wtErr != nil {
-			ui.Error("Failed to create worktree for %s: %v", repo.FolderName, wtErr)
-			// Clean up on failure
-			cleanupPartialProject(projectPath, createdRepos)
-			return wtErr
-		}
-
-		// Auto-init .claude/ if configured
-		if cfg.AutoInit {
-			if err := InitRepo(worktreePath); err != nil {
-				ui.Warn("Failed to init %s: %v", repo.FolderName, err)
-			}
+	ui.Info("Creating %d worktree(s)...", len(repos))
+	createdRepos, failures := createProjectWorktrees(cfg, projectPath, branchName, repos, branchResults)
+	if len(failures) > 0 {
+		for _, f := range failures {
+			ui.Error("Failed to create worktree for %s: %v", f.Repo, f.Err)
 		}
+		// Clean up on failure
+		cleanupPartialProject(projectPath, createdRepos)
+		return fmt.Errorf("failed to create %d of %d worktrees", len(failures), len(repos))
+	}
 
-		// Copy gitignored files (.env, .npmrc, etc.)
+	// Copy gitignored files (.env, .npmrc, etc.). This may prompt, so it
+	// runs one repo at a time after the worktrees exist.
+	for _, repo := range repos {
+		worktreePath := filepath.Join(projectPath, repo.FolderName)
 		if err := copyGitignoredFilesForProject(cfg, repo.SourcePath, worktreePath); err != nil {
 			ui.Warn("Failed to copy some files for %s: %v", repo.FolderName, err)
 		}
-
-		createdRepos = append(createdRepos, config.ProjectRepo{
-			Name:   repo.FolderName,
-			Source: repo.SourcePath,
-		})
-
-		ui.Success("Created %s", repo.FolderName)
+		if !projectNoSetupFlag {
+			runSetupCommand(cfg, repo.SourcePath, worktreePath, repo.FolderName)
+		}
 	}
 
 	// Create .clade-project.json
@@ -340,8 +355,11 @@ func runProject(cmd *cobra.Command, args []string) error {
 		Created:  time.Now(),
 		LastUsed: time.Now(),
 	}
-	state.Projects[projectName] = project
-	if err := state.Save(cfg); err != nil {
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.Projects[projectName] = project
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -373,11 +391,11 @@ func resolveRepoPath(cfg *config.Config, input string) (string, error) {
 		return "", fmt.Errorf("invalid path: %s", input)
 	}
 
-	if !git.IsGitRepo(absPath) {
+	if !git.IsSourceRepo(absPath) {
 		return "", fmt.Errorf("not a git repository: %s", input)
 	}
 
-	return git.GetRepoRoot(absPath)
+	return git.GetRepoRootOrBare(absPath)
 }
 
 // launchProjectSession opens editor and/or launches agent for a project
@@ -388,16 +406,17 @@ func launchProjectSession(cfg *config.Config, project *config.Project, editorOve
 
 	primaryDir := filepath.Join(project.Path, project.Repos[0].Name)
 
-	// Determine editor to use
-	editor := cfg.Editor
-	if editorOverride != "" {
-		editor = editorOverride
-	}
+	// The primary repo's settings pick the agent and editor for the project
+	cfg = repoSessionConfig(cfg, project.Repos[0].Source)
+	editor := resolveEditor(cfg, editorOverride)
 
 	// Open editor first (if configured and not disabled)
 	if !noEditor && editor != "" {
 		opts := agent.EditorOptions{
 			TmuxSplitDirection: cfg.TmuxSplitDirection,
+			TmuxSplitPercent:   cfg.TmuxSplitPercent,
+			TmuxMode:           cfg.TmuxEditorMode,
+			WindowName:         project.Name,
 		}
 		// Open editor at project root to see all repos
 		if err := agent.OpenEditor(project.Path, editor, opts); err != nil {
@@ -418,23 +437,125 @@ func launchProjectSession(cfg *config.Config, project *config.Project, editorOve
 			addDirs = append(addDirs, filepath.Join(project.Path, project.Repos[i].Name))
 		}
 
-		ag := agent.NewAgent(cfg.Agent)
+		ag := agent.NewAgent(cfg)
+		if len(addDirs) > 0 && !ag.SupportsAddDirs() {
+			ui.Warn("%s can't be given extra directories; only %s is in scope", ag.Name(), project.Repos[0].Name)
+			ui.Detail("Set agent_add_dir_flag (or add_dir_flag under \"agents\") to include every repo")
+		}
 		opts := agent.LaunchOptions{
 			AddDirs: addDirs,
 			Flags:   cfg.AgentFlags,
+			Env: map[string]string{
+				"CLADE_NAME":   project.Name,
+				"CLADE_TYPE":   "project",
+				"CLADE_BRANCH": project.RepoBranch(project.Repos[0]),
+				"CLADE_REPO":   project.Repos[0].Source,
+				"CLADE_PATH":   project.Path,
+				"CLADE_TICKET": "",
+			},
 		}
 
-		return ag.Launch(primaryDir, opts)
+		return agentExitError(ag.Launch(primaryDir, opts))
 	}
 
 	return nil
 }
 
+// projectBranchSummary describes a project's branch, noting repos that
+// override it
+func projectBranchSummary(proj *config.Project) string {
+	var overrides []string
+	for _, repo := range proj.Repos {
+		if repo.Branch != "" && repo.Branch != proj.Branch {
+			overrides = append(overrides, repo.Name+": "+repo.Branch)
+		}
+	}
+	if len(overrides) == 0 {
+		return proj.Branch
+	}
+	return fmt.Sprintf("%s (%s)", proj.Branch, strings.Join(overrides, ", "))
+}
+
+// projectCreateWorkers bounds how many worktrees are created at once
+const projectCreateWorkers = 4
+
+// createProjectWorktrees creates (and auto-inits) a worktree per repo using a
+// bounded worker pool. Returns the repos that were created, in input order,
+// and a result for every repo that failed.
+func createProjectWorktrees(cfg *config.Config, projectPath, branchName string, repos []projectRepo, branchResults map[string]git.BranchInfo) ([]config.ProjectRepo, []repoRunResult) {
+	created := make([]bool, len(repos))
+	var failures []repoRunResult
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan int)
+	for i := 0; i < projectCreateWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				repo := repos[idx]
+				worktreePath := filepath.Join(projectPath, repo.FolderName)
+				branch := repo.branchOr(branchName)
+
+				var wtErr error
+				switch branchResults[repo.SourcePath].Status {
+				case git.BranchNotFound:
+					wtErr = git.CreateWorktreeNew(repo.SourcePath, cfg.Remote, worktreePath, branch)
+				case git.BranchLocalOnly, git.BranchBoth:
+					wtErr = git.CreateWorktreeFromBranch(repo.SourcePath, worktreePath, branch)
+				case git.BranchRemoteOnly:
+					wtErr = git.CreateWorktreeTrackRemote(repo.SourcePath, cfg.Remote, worktreePath, branch)
+				}
+
+				if wtErr != nil {
+					mu.Lock()
+					failures = append(failures, repoRunResult{Repo: repo.FolderName, Err: wtErr})
+					mu.Unlock()
+					continue
+				}
+
+				// Auto-init .claude/ if configured
+				if cfg.AutoInit {
+					if err := InitRepo(worktreePath); err != nil {
+						ui.Warn("Failed to init %s: %v", repo.FolderName, err)
+					}
+				}
+
+				mu.Lock()
+				created[idx] = true
+				mu.Unlock()
+				ui.Success("Created %s", repo.FolderName)
+			}
+		}()
+	}
+
+	for i := range repos {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var createdRepos []config.ProjectRepo
+	for i, repo := range repos {
+		if created[i] {
+			createdRepos = append(createdRepos, config.ProjectRepo{
+				Name:   repo.FolderName,
+				Source: repo.SourcePath,
+				Branch: repo.Branch,
+			})
+		}
+	}
+	return createdRepos, failures
+}
+
 func cleanupPartialProject(projectPath string, created []config.ProjectRepo) {
 	ui.Warn("Cleaning up partial project...")
 	for _, repo := range created {
 		worktreePath := filepath.Join(projectPath, repo.Name)
 		os.RemoveAll(worktreePath)
+		// Drop git's record of the removed worktree
+		git.PruneWorktrees(repo.Source)
 	}
 	os.Remove(projectPath)
 }
@@ -479,22 +600,11 @@ func copyGitignoredFilesForProject(cfg *config.Config, srcRepo, dstPath string)
 	repoName := filepath.Base(srcRepo)
 	fmt.Println()
 	ui.Info("Found gitignored files in %s:", repoName)
-	for _, f := range detected {
-		ui.Detail("  %s", f)
-	}
 
 	// Interactive selection
-	var selected []string
-	for _, file := range detected {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Copy %s", file),
-			IsConfirm: true,
-			Default:   "y",
-		}
-		_, err := prompt.Run()
-		if err == nil {
-			selected = append(selected, file)
-		}
+	selected, err := selectFilesToCopy(detected)
+	if err != nil {
+		return nil // User cancelled, not an error
 	}
 
 	// Save preference for future
@@ -579,14 +689,11 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 
 	// Get folder name for the new repo
 	defaultName := filepath.Base(repoPath)
-	folderPrompt := promptui.Prompt{
-		Label:   "Folder name",
-		Default: defaultName,
-	}
-	folderName, err := folderPrompt.Run()
+	folderName, err := ui.Input("Folder name", defaultName)
 	if err != nil {
 		return err
 	}
+	folderName = sanitizeForPath(folderName)
 
 	// Check folder name doesn't conflict
 	for _, r := range project.Repos {
@@ -595,9 +702,24 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Get branch for the new repo
+	branch := projectAddBranchFlag
+	if branch == "" {
+		branch, err = ui.Input("Branch", project.Branch)
+		if err != nil {
+			return err
+		}
+		if branch == "" {
+			branch = project.Branch
+		}
+	}
+	if branch != project.Branch {
+		ui.Warn("%s will use branch '%s' instead of the project branch '%s'", folderName, branch, project.Branch)
+	}
+
 	// Preflight check for branch
-	ui.Info("Checking branch '%s'...", project.Branch)
-	branchResults := git.PreflightCheck([]string{repoPath}, project.Branch)
+	ui.Info("Checking branch '%s'...", branch)
+	branchResults := git.PreflightCheck(map[string]string{repoPath: branch}, cfg.Remote)
 	info := branchResults[repoPath]
 
 	switch info.Status {
@@ -622,7 +744,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	ui.Header("Adding to project: %s", projectName)
 	ui.KeyValue("Repo", filepath.Base(repoPath))
 	ui.KeyValue("Folder", folderName)
-	ui.KeyValue("Branch", project.Branch)
+	ui.KeyValue("Branch", branch)
 	fmt.Println()
 
 	ui.Info("Creating worktree...")
@@ -630,11 +752,11 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	var wtErr error
 	switch info.Status {
 	case git.BranchNotFound:
-		wtErr = git.CreateWorktreeNew(repoPath, worktreePath, project.Branch)
+		wtErr = git.CreateWorktreeNew(repoPath, cfg.Remote, worktreePath, branch)
 	case git.BranchLocalOnly, git.BranchBoth:
-		wtErr = git.CreateWorktreeFromBranch(repoPath, worktreePath, project.Branch)
+		wtErr = git.CreateWorktreeFromBranch(repoPath, worktreePath, branch)
 	case git.BranchRemoteOnly:
-		wtErr = git.CreateWorktreeTrackRemote(repoPath, worktreePath, project.Branch)
+		wtErr = git.CreateWorktreeTrackRemote(repoPath, cfg.Remote, worktreePath, branch)
 	}
 
 	if wtErr != nil {
@@ -652,16 +774,26 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	if err := copyGitignoredFilesForProject(cfg, repoPath, worktreePath); err != nil {
 		ui.Warn("Failed to copy some files: %v", err)
 	}
+	if !projectAddNoSetupFlag {
+		runSetupCommand(cfg, repoPath, worktreePath, "")
+	}
 
 	// Update project in state
 	newRepo := config.ProjectRepo{
 		Name:   folderName,
 		Source: repoPath,
 	}
+	if branch != project.Branch {
+		newRepo.Branch = branch
+	}
 	project.Repos = append(project.Repos, newRepo)
 	project.LastUsed = time.Now()
 
-	if err := state.Save(cfg); err != nil {
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.Projects[projectName] = project
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -683,12 +815,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Ask if user wants to launch agent
-	prompt := promptui.Prompt{
-		Label:     "Launch agent",
-		IsConfirm: true,
-		Default:   "y",
-	}
-	if _, err := prompt.Run(); err == nil {
+	if ui.Confirm("Launch agent", true) {
 		return launchProjectSession(cfg, project, projectAddEditorFlag, projectAddNoAgentFlag, projectAddNoEditorFlag)
 	}
 
@@ -705,6 +832,9 @@ func pickProject(state *config.State) (string, error) {
 		return projectNames[0], nil
 	}
 
+	if err := ui.Interactive("pick a project without a name"); err != nil {
+		return "", err
+	}
 	prompt := promptui.Select{
 		Label: "Select project",
 		Items: projectNames,
@@ -744,6 +874,9 @@ func pickRepoToAdd(cfg *config.Config, project *config.Project) (string, string,
 		items = append(items, fmt.Sprintf("%s (%s)", r.Name, r.Path))
 	}
 
+	if err := ui.Interactive("pick a repo to add without one given"); err != nil {
+		return "", "", err
+	}
 	prompt := promptui.Select{
 		Label: "Select repo to add",
 		Items: items,
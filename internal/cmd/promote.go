@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/files"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	promoteToFlag         string
+	promoteRepoFlag       string
+	promoteBranchFlag     string
+	promoteKeepBranchFlag bool
+	promoteNoSetupFlag    bool
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote <name>",
+	Short: "Turn a scratch or experiment into a feature",
+	Long: `Promote a scratch or experiment to a feature once it turns into real work.
+
+For an experiment, .clade.json is marked as a feature and the branch is
+renamed from the exp prefix to the feat prefix (e.g. exp/foo -> feat/foo),
+unless --keep-branch is given. The worktree stays where it is.
+
+For a scratch, a new feature worktree is created in the chosen repo and the
+scratch's files (DROPBAG.md, notes, ...) are moved into it. Files that would
+overwrite something in the repo are left behind in the scratch folder.
+
+Either way the target branch must not exist yet.
+
+Examples:
+  clade promote try-redis
+  clade promote try-redis --keep-branch
+  clade promote auth-notes -r api
+  clade promote auth-notes -r api -b feat/auth`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runPromote,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+	promoteCmd.Flags().StringVar(&promoteToFlag, "to", "feat", "What to promote to (only feat is supported)")
+	promoteCmd.Flags().StringVarP(&promoteRepoFlag, "repo", "r", "", "Repository for a promoted scratch (path or registered name)")
+	promoteCmd.Flags().StringVarP(&promoteBranchFlag, "branch", "b", "", "Branch name for the feature (skips prompt)")
+	promoteCmd.Flags().BoolVar(&promoteKeepBranchFlag, "keep-branch", false, "Don't rename an experiment's branch")
+	promoteCmd.Flags().BoolVar(&promoteNoSetupFlag, "no-setup", false, "Skip the repo's setup_command for a promoted scratch")
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if promoteToFlag != "feat" {
+		return fmt.Errorf("can't promote to '%s' (only feat is supported)", promoteToFlag)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	switch {
+	case item.Experiment != nil:
+		return promoteExperiment(cfg, item.Experiment)
+	case item.Scratch != nil:
+		return promoteScratch(cfg, state, item.Scratch)
+	}
+	return fmt.Errorf("'%s' is a project, which can't be promoted", name)
+}
+
+// promoteExperiment marks an experiment as a feature and renames its branch
+func promoteExperiment(cfg *config.Config, exp *config.Experiment) error {
+	metaPath := filepath.Join(exp.Path, ".clade.json")
+	if metadata, err := context.ReadCladeMetadata(exp.Path); err == nil && metadata.Type == "feature" {
+		return fmt.Errorf("'%s' is already a feature", exp.Name)
+	}
+
+	newBranch := exp.Branch
+	switch {
+	case promoteBranchFlag != "":
+		newBranch = promoteBranchFlag
+	case !promoteKeepBranchFlag && strings.HasPrefix(exp.Branch, cfg.ExpBranchPrefix):
+		newBranch = cfg.FeatBranchPrefix + strings.TrimPrefix(exp.Branch, cfg.ExpBranchPrefix)
+	}
+	if newBranch != exp.Branch {
+		if err := checkBranchFree(cfg, exp.Repo, newBranch); err != nil {
+			return err
+		}
+	}
+
+	ui.Header("Promoting experiment to feature: %s", exp.Name)
+
+	if newBranch != exp.Branch {
+		if err := git.RenameBranch(exp.Repo, exp.Branch, newBranch); err != nil {
+			return err
+		}
+		ui.Success("Renamed branch %s -> %s", exp.Branch, newBranch)
+		if info := git.CheckBranchLocal(exp.Repo, cfg.Remote, exp.Branch); info.Status == git.BranchRemoteOnly {
+			ui.Detail("%s/%s still exists on the remote; push %s and delete it when ready", cfg.Remote, exp.Branch, newBranch)
+		}
+	}
+
+	if err := updateMetadataType(metaPath, "feature"); err != nil {
+		ui.Warn("Failed to update .clade.json: %v", err)
+	}
+
+	key := config.ExperimentKey(exp.Repo, exp.Name)
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		if e := s.GetExperiment(key); e != nil {
+			e.Branch = newBranch
+			e.LastUsed = time.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("'%s' is now a feature", exp.Name)
+	return nil
+}
+
+// promoteScratch creates a feature worktree and moves the scratch's files in
+func promoteScratch(cfg *config.Config, state *config.State, scratch *config.Scratch) error {
+	// Default to the repo the scratch is linked to, if any
+	repoFlag := promoteRepoFlag
+	if repoFlag == "" {
+		repoFlag = scratch.LinkedRepo
+	}
+	repoPath, err := resolveRepo(cfg, repoFlag)
+	if err != nil {
+		return err
+	}
+
+	expKey := config.ExperimentKey(repoPath, scratch.Name)
+	if state.GetExperiment(expKey) != nil {
+		return fmt.Errorf("an experiment named '%s' already exists in %s", scratch.Name, git.GetRepoName(repoPath))
+	}
+	featPath := filepath.Join(cfg.ExperimentsDir(), expKey)
+	if _, err := os.Stat(featPath); err == nil {
+		return fmt.Errorf("path already exists: %s", featPath)
+	}
+
+	branch := promoteBranchFlag
+	if branch == "" {
+		defaultBranch := cfg.FeatBranchPrefix + scratch.Name
+		branch, err = ui.Input("Branch name", defaultBranch)
+		if err != nil {
+			return err
+		}
+		if branch == "" {
+			branch = defaultBranch
+		}
+	}
+	if err := checkBranchFree(cfg, repoPath, branch); err != nil {
+		return err
+	}
+
+	ui.Header("Promoting scratch to feature: %s", scratch.Name)
+	ui.KeyValue("Repo", git.GetRepoName(repoPath))
+	ui.KeyValue("Path", featPath)
+	ui.KeyValue("Branch", branch)
+
+	if err := os.MkdirAll(cfg.ExperimentsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create experiments directory: %w", err)
+	}
+
+	ui.Info("Creating worktree...")
+	if err := git.CreateWorktreeNew(repoPath, cfg.Remote, featPath, branch); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	ui.Info("Moving scratch files...")
+	left, err := moveScratchFiles(scratch.Path, featPath)
+	if err != nil {
+		return fmt.Errorf("failed to move scratch files: %w", err)
+	}
+	for _, name := range left {
+		ui.Warn("Left %s in the scratch folder (already exists in the repo)", name)
+	}
+
+	if err := copyGitignoredFiles(cfg, repoPath, featPath); err != nil {
+		ui.Warn("Failed to copy some files: %v", err)
+	}
+	if !promoteNoSetupFlag {
+		runSetupCommand(cfg, repoPath, featPath, "")
+	}
+
+	ticket := extractTicket(scratch.Name)
+	if ticket == "" {
+		ticket = scratch.Ticket
+	}
+	cladeMetadata := map[string]any{
+		"type":    "feature",
+		"name":    scratch.Name,
+		"ticket":  ticket,
+		"repo":    git.GetRepoName(repoPath),
+		"created": time.Now().Format(time.RFC3339),
+	}
+	if err := writeJSON(filepath.Join(featPath, ".clade.json"), cladeMetadata); err != nil {
+		ui.Warn("Failed to write .clade.json: %v", err)
+	}
+
+	exp := &config.Experiment{
+		Name:     scratch.Name,
+		Repo:     repoPath,
+		Path:     featPath,
+		Branch:   branch,
+		Ticket:   ticket,
+		Created:  scratch.Created,
+		LastUsed: time.Now(),
+	}
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.RemoveScratch(scratch.Name)
+		s.AddExperiment(exp)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	if len(left) == 0 {
+		if err := os.RemoveAll(scratch.Path); err != nil {
+			ui.Warn("Failed to remove scratch folder: %v", err)
+		}
+	} else {
+		ui.Detail("Review and delete the scratch folder when done: %s", scratch.Path)
+	}
+
+	ui.Success("'%s' is now a feature", scratch.Name)
+	ui.Detail("Resume with: clade resume %s", scratch.Name)
+	return nil
+}
+
+// checkBranchFree errors if branch already exists locally or on the remote
+func checkBranchFree(cfg *config.Config, repoPath, branch string) error {
+	if git.CheckBranch(repoPath, cfg.Remote, branch).Status != git.BranchNotFound {
+		return fmt.Errorf("branch '%s' already exists (pick another with --branch)", branch)
+	}
+	return nil
+}
+
+// moveScratchFiles moves a scratch folder's files into dst. Entries that
+// already exist in dst are left in place and returned. .clade.json is
+// rewritten by the caller, and the scratch's .gitignore only hid clade's own
+// files from a repo that doesn't exist, so neither is moved.
+func moveScratchFiles(src, dst string) ([]string, error) {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var left []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == ".clade.json" || name == ".gitignore" {
+			continue
+		}
+		srcPath := filepath.Join(src, name)
+		dstPath := filepath.Join(dst, name)
+		if _, err := os.Lstat(dstPath); err == nil {
+			left = append(left, name)
+			continue
+		}
+		if err := os.Rename(srcPath, dstPath); err != nil {
+			// Different filesystems: copy, then remove the original
+			if err := files.CopyDir(srcPath, dstPath); err != nil {
+				return left, err
+			}
+			if err := os.RemoveAll(srcPath); err != nil {
+				return left, err
+			}
+		}
+	}
+	return left, nil
+}
+
+// updateMetadataType rewrites the type in a .clade.json file
+func updateMetadataType(path, itemType string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return err
+	}
+
+	metadata["type"] = itemType
+	return writeJSON(path, metadata)
+}
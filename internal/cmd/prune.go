@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneOlderThanFlag string
+	pruneForceFlag     bool
+	pruneDryRunFlag    bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Clean up experiments and scratches that haven't been used in a while",
+	Long: `Remove experiments and scratch folders not used within a time window.
+
+Experiments lose their worktree and branch, exactly like "clade cleanup".
+Experiments with uncommitted changes or unmerged commits are skipped unless
+--force is given.
+
+Examples:
+  clade prune                    # Unused for stale_after_days (default 7)
+  clade prune --older-than 14d   # Unused for 14+ days
+  clade prune --dry-run          # Preview only`,
+	Args: cobra.NoArgs,
+	RunE: runPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+	pruneCmd.Flags().StringVar(&pruneOlderThanFlag, "older-than", "", "Age threshold, e.g. 14d, 2w, or 72h (default: stale_after_days)")
+	pruneCmd.Flags().BoolVarP(&pruneForceFlag, "force", "f", false, "Skip confirmation and include experiments with uncommitted changes or unmerged commits")
+	pruneCmd.Flags().BoolVar(&pruneDryRunFlag, "dry-run", false, "Show what would be pruned without removing anything")
+}
+
+// pruneCandidate is a stale experiment or scratch
+type pruneCandidate struct {
+	Key        string
+	Experiment *config.Experiment
+	Scratch    *config.Scratch
+	LastUsed   time.Time
+}
+
+func (c pruneCandidate) name() string {
+	if c.Experiment != nil {
+		return c.Experiment.Name
+	}
+	return c.Scratch.Name
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	threshold := pruneOlderThanFlag
+	if threshold == "" {
+		threshold = fmt.Sprintf("%dd", int(cfg.StaleAfter().Hours()/24))
+	}
+	maxAge, err := parseAge(threshold)
+	if err != nil {
+		return err
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	var candidates []pruneCandidate
+	for key, exp := range state.Experiments {
+		if exp.LastUsed.Before(cutoff) {
+			candidates = append(candidates, pruneCandidate{Key: key, Experiment: exp, LastUsed: exp.LastUsed})
+		}
+	}
+	for key, scratch := range state.Scratches {
+		if scratch.LastUsed.Before(cutoff) {
+			candidates = append(candidates, pruneCandidate{Key: key, Scratch: scratch, LastUsed: scratch.LastUsed})
+		}
+	}
+
+	if len(candidates) == 0 {
+		ui.Info("Nothing unused for more than %s", threshold)
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+
+	ui.Header("Unused for more than %s:", threshold)
+	var toPrune []pruneCandidate
+	var skipped []string
+	for _, c := range candidates {
+		kind := "[scratch]"
+		if c.Experiment != nil {
+			kind = "[exp]"
+		}
+		label := fmt.Sprintf("%s %s %s", c.name(), ui.Dim(kind), ui.Dim("last used "+formatAge(c.LastUsed)))
+
+		if c.Experiment != nil && !pruneForceFlag {
+			if reason := pruneRisk(cfg, c.Experiment); reason != "" {
+				fmt.Printf("  %s %s %s\n", ui.Yellow("○"), label, ui.Yellow("("+reason+", skipping)"))
+				skipped = append(skipped, fmt.Sprintf("%s (%s)", c.name(), reason))
+				continue
+			}
+		}
+		fmt.Printf("  %s %s\n", ui.Red("✗"), label)
+		toPrune = append(toPrune, c)
+	}
+
+	fmt.Println()
+	if len(toPrune) == 0 {
+		ui.Info("Nothing to prune (use --force to include experiments with uncommitted changes or unmerged commits)")
+		return nil
+	}
+
+	if pruneDryRunFlag {
+		ui.Info("Would prune %d item(s) (dry run)", len(toPrune))
+		return nil
+	}
+
+	if !pruneForceFlag {
+		if !ui.Confirm(fmt.Sprintf("Remove %d item(s) and their branches", len(toPrune)), false) {
+			ui.Info("Prune cancelled")
+			return nil
+		}
+	}
+
+	// The list was confirmed as a whole, so don't ask about each branch.
+	// Only an explicit --force lets dirty or unmerged work go.
+	cleanupListConfirmed = true
+	cleanupForceFlag = pruneForceFlag
+
+	pruned := 0
+	for _, c := range toPrune {
+		var err error
+		if c.Experiment != nil {
+			err = cleanupExperiment(cfg, state, c.Key, c.Experiment)
+		} else {
+			err = cleanupScratch(cfg, state, c.Key, c.Scratch)
+		}
+		if err != nil {
+			ui.Error("%s: %v", c.name(), err)
+			continue
+		}
+		pruned++
+	}
+
+	fmt.Println()
+	ui.Success("Pruned %d item(s)", pruned)
+	if len(skipped) > 0 {
+		ui.Info("Skipped %d (use --force to include them):", len(skipped))
+		for _, line := range skipped {
+			ui.Detail("%s", line)
+		}
+	}
+	return nil
+}
+
+// pruneRisk describes work that pruning exp would throw away, or returns ""
+func pruneRisk(cfg *config.Config, exp *config.Experiment) string {
+	var risks []string
+	if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
+		risks = append(risks, "uncommitted changes")
+	}
+	if count, unmerged := branchHasUnmergedCommits(cfg, exp.Repo, exp.Branch); unmerged {
+		risks = append(risks, fmt.Sprintf("%d unmerged commit(s) on %s", count, exp.Branch))
+	}
+	return strings.Join(risks, ", ")
+}
+
+// parseAge parses an age like "30d", "2w", or any Go duration ("72h")
+func parseAge(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid age '%s' (use e.g. 14d, 2w, or 72h)", value)
+
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, invalid
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return 0, invalid
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var reindexDryRunFlag bool
+
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild missing state entries from metadata files on disk",
+	Long: `Recover experiments, projects, and scratches that state.json has lost.
+
+Scans the experiments, projects, and scratch directories for .clade.json and
+.clade-project.json files and adds an entry for every one that clade isn't
+already tracking. Existing entries are never changed.
+
+Experiments get their repo from the worktree's git metadata and their branch
+from what is checked out.
+
+Examples:
+  clade reindex             # Recover untracked items
+  clade reindex --dry-run   # Preview what would be recovered`,
+	Args: cobra.NoArgs,
+	RunE: runReindex,
+}
+
+func init() {
+	rootCmd.AddCommand(reindexCmd)
+	reindexCmd.Flags().BoolVar(&reindexDryRunFlag, "dry-run", false, "Show what would be recovered without changing anything")
+}
+
+// recovered holds the entries rebuilt from metadata
+type recovered struct {
+	Experiments []*config.Experiment
+	Projects    []*config.Project
+	Scratches   []*config.Scratch
+}
+
+func (r *recovered) count() int {
+	return len(r.Experiments) + len(r.Projects) + len(r.Scratches)
+}
+
+func runReindex(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	tracked := make(map[string]bool)
+	for _, exp := range state.Experiments {
+		tracked[cleanPath(exp.Path)] = true
+	}
+	for _, proj := range state.Projects {
+		tracked[cleanPath(proj.Path)] = true
+	}
+	for _, scratch := range state.Scratches {
+		tracked[cleanPath(scratch.Path)] = true
+	}
+
+	ui.Header("Scanning %s", cfg.GetBaseDir())
+
+	found := &recovered{}
+	for _, dir := range subdirs(cfg.ExperimentsDir()) {
+		if tracked[cleanPath(dir)] {
+			continue
+		}
+		if exp, err := recoverExperiment(dir); err != nil {
+			ui.Warn("Skipping %s: %v", dir, err)
+		} else if exp != nil && reserveName(state, exp.Name, dir) {
+			found.Experiments = append(found.Experiments, exp)
+			state.AddExperiment(exp)
+			fmt.Printf("  %s %s %s\n", ui.Cyan(exp.Name), ui.Dim("[exp]"), ui.Dim("("+exp.Branch+")"))
+		}
+	}
+	for _, dir := range subdirs(cfg.ProjectsDir()) {
+		if tracked[cleanPath(dir)] {
+			continue
+		}
+		if proj, err := recoverProject(dir); err != nil {
+			ui.Warn("Skipping %s: %v", dir, err)
+		} else if proj != nil && reserveName(state, proj.Name, dir) {
+			found.Projects = append(found.Projects, proj)
+			state.Projects[proj.Name] = proj
+			fmt.Printf("  %s %s %s\n", ui.Cyan(proj.Name), ui.Dim("[project]"), ui.Dim(fmt.Sprintf("(%s, %d repos)", proj.Branch, len(proj.Repos))))
+		}
+	}
+	for _, dir := range subdirs(cfg.ScratchDir()) {
+		if tracked[cleanPath(dir)] {
+			continue
+		}
+		if scratch, err := recoverScratch(dir); err != nil {
+			ui.Warn("Skipping %s: %v", dir, err)
+		} else if scratch != nil && reserveName(state, scratch.Name, dir) {
+			found.Scratches = append(found.Scratches, scratch)
+			state.AddScratch(scratch)
+			fmt.Printf("  %s %s\n", ui.Cyan(scratch.Name), ui.Dim("[scratch]"))
+		}
+	}
+
+	if found.count() == 0 {
+		ui.Info("Nothing to recover - state matches what's on disk")
+		return nil
+	}
+
+	fmt.Println()
+	if reindexDryRunFlag {
+		ui.Info("Would recover %d item(s) (dry run)", found.count())
+		return nil
+	}
+
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		for _, exp := range found.Experiments {
+			s.AddExperiment(exp)
+		}
+		for _, proj := range found.Projects {
+			s.Projects[proj.Name] = proj
+		}
+		for _, scratch := range found.Scratches {
+			s.AddScratch(scratch)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("Recovered %d item(s)", found.count())
+	return nil
+}
+
+// reserveName reports whether name is free, warning about dir if it isn't
+func reserveName(state *config.State, name, dir string) bool {
+	if itemType, exists := state.NameExists(name); exists {
+		ui.Warn("Skipping %s: '%s' already exists as a %s", dir, name, itemType)
+		return false
+	}
+	return true
+}
+
+// recoverExperiment rebuilds an experiment from a worktree's .clade.json.
+// Returns nil if the directory has no metadata.
+func recoverExperiment(dir string) (*config.Experiment, error) {
+	metadata, err := context.ReadCladeMetadata(dir)
+	if err != nil {
+		return nil, nil
+	}
+	if metadata.Name == "" {
+		return nil, fmt.Errorf(".clade.json has no name")
+	}
+
+	repo, err := git.GetMainRepoRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("can't find the source repo: %w", err)
+	}
+	branch, err := git.GetCurrentBranch(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	created := metadataTime(metadata.Created, dir)
+	return &config.Experiment{
+		Name:     metadata.Name,
+		Repo:     repo,
+		Path:     dir,
+		Branch:   branch,
+		Ticket:   metadata.Ticket,
+		Created:  created,
+		LastUsed: created,
+	}, nil
+}
+
+// recoverProject rebuilds a project from its .clade-project.json. Returns nil
+// if the directory has no metadata.
+func recoverProject(dir string) (*config.Project, error) {
+	metadata, err := context.ReadProjectMetadata(dir)
+	if err != nil {
+		return nil, nil
+	}
+	if metadata.Name == "" {
+		return nil, fmt.Errorf(".clade-project.json has no name")
+	}
+
+	proj := &config.Project{
+		Name:   metadata.Name,
+		Path:   dir,
+		Branch: metadata.Branch,
+	}
+	for _, repo := range metadata.Repos {
+		source := repo.Source
+		if source == "" {
+			// Older metadata only has folder names; ask the worktree
+			source, err = git.GetMainRepoRoot(filepath.Join(dir, repo.Name))
+			if err != nil {
+				return nil, fmt.Errorf("can't find the source repo for %s: %w", repo.Name, err)
+			}
+		}
+		proj.Repos = append(proj.Repos, config.ProjectRepo{
+			Name:   repo.Name,
+			Source: source,
+			Branch: repo.Branch,
+		})
+	}
+
+	proj.Created = metadataTime(metadata.Created, dir)
+	proj.LastUsed = proj.Created
+	return proj, nil
+}
+
+// recoverScratch rebuilds a scratch from its .clade.json. Returns nil if the
+// directory has no metadata.
+func recoverScratch(dir string) (*config.Scratch, error) {
+	metadata, err := context.ReadCladeMetadata(dir)
+	if err != nil {
+		return nil, nil
+	}
+	if metadata.Name == "" {
+		return nil, fmt.Errorf(".clade.json has no name")
+	}
+
+	created := metadataTime(metadata.Created, dir)
+	return &config.Scratch{
+		Name:       metadata.Name,
+		Path:       dir,
+		Ticket:     metadata.Ticket,
+		LinkedRepo: metadata.LinkedRepo,
+		Created:    created,
+		LastUsed:   created,
+	}, nil
+}
+
+// metadataTime parses a metadata timestamp, falling back to the directory's
+// modification time
+func metadataTime(value, dir string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	if info, err := os.Stat(dir); err == nil {
+		return info.ModTime()
+	}
+	return time.Now()
+}
+
+// subdirs lists the directories directly under dir
+func subdirs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return dirs
+}
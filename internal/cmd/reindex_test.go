@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestReindexRecoversProjectAfterStateLoss(t *testing.T) {
+	cfg := setupTestEnv(t)
+	setFlag(t, &reindexDryRunFlag, false)
+	api := newTestRepo(t, "api")
+	web := newTestRepo(t, "web")
+
+	projectDir := filepath.Join(cfg.ProjectsDir(), "checkout-v2")
+	runTestGit(t, api, "worktree", "add", "-q", "-b", "feat/checkout-v2", filepath.Join(projectDir, "api"))
+	runTestGit(t, web, "worktree", "add", "-q", "-b", "feat/web-v2", filepath.Join(projectDir, "web"))
+	// web predates source paths in the metadata, so reindex asks git for it
+	writeTestFile(t, filepath.Join(projectDir, ".clade-project.json"), `{
+  "type": "project",
+  "name": "checkout-v2",
+  "branch": "feat/checkout-v2",
+  "repos": [
+    {"name": "api", "source": "`+api+`"},
+    {"name": "web", "branch": "feat/web-v2"}
+  ],
+  "created": "2026-01-02T03:04:05Z"
+}`)
+	if err := os.Remove(config.StatePath(cfg)); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	if code := executeArgs(t, "reindex", "--dry-run"); code != 0 {
+		t.Fatalf("reindex --dry-run exited %d", code)
+	}
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Projects) != 0 {
+		t.Fatalf("dry run saved state: %v", state.Projects)
+	}
+
+	setFlag(t, &reindexDryRunFlag, false)
+	if code := executeArgs(t, "reindex"); code != 0 {
+		t.Fatalf("reindex exited %d", code)
+	}
+	state, err = config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := state.Projects["checkout-v2"]
+	if proj == nil {
+		t.Fatalf("project wasn't recovered: %v", state.Projects)
+	}
+	if proj.Path != projectDir || proj.Branch != "feat/checkout-v2" {
+		t.Errorf("got path %s branch %s", proj.Path, proj.Branch)
+	}
+	if proj.Created.Year() != 2026 || proj.Created.Month() != 1 {
+		t.Errorf("Created = %v, want the metadata timestamp", proj.Created)
+	}
+	want := []config.ProjectRepo{
+		{Name: "api", Source: api},
+		{Name: "web", Source: web, Branch: "feat/web-v2"},
+	}
+	if len(proj.Repos) != len(want) {
+		t.Fatalf("repos = %+v, want %+v", proj.Repos, want)
+	}
+	for i := range want {
+		if proj.Repos[i] != want[i] {
+			t.Errorf("repo %d = %+v, want %+v", i, proj.Repos[i], want[i])
+		}
+	}
+
+	// A second run has nothing left to do
+	if code := executeArgs(t, "reindex"); code != 0 {
+		t.Fatalf("second reindex exited %d", code)
+	}
+	state, _ = config.LoadState(cfg)
+	if len(state.Projects) != 1 {
+		t.Errorf("got %d projects after a second run, want 1", len(state.Projects))
+	}
+}
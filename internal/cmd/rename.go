@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var renameKeepBranchFlag bool
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename an experiment, project, or scratch",
+	Long: `Rename an experiment, project, or scratch without recreating it.
+
+Moves the directory, updates .clade.json and state, and optionally renames
+the git branch (e.g. exp/old -> exp/new). Uncommitted work is preserved.
+
+Examples:
+  clade rename try-redis redis-cache
+  clade rename try-redis redis-cache --keep-branch`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runRename,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+	renameCmd.Flags().BoolVar(&renameKeepBranchFlag, "keep-branch", false, "Don't rename the git branch")
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	if !isValidExpName(newName) {
+		return fmt.Errorf("invalid name: use alphanumeric, hyphens, underscores only")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	for key, exp := range state.Experiments {
+		if exp.Name == oldName {
+			if state.GetExperiment(config.ExperimentKey(exp.Repo, newName)) != nil {
+				return fmt.Errorf("'%s' already exists as an experiment in %s", newName, filepath.Base(exp.Repo))
+			}
+			if err := checkNameAvailable(state, newName, "experiment"); err != nil {
+				return err
+			}
+			return renameExperiment(cfg, state, key, exp, newName)
+		}
+	}
+
+	for key, proj := range state.Projects {
+		if proj.Name == oldName {
+			if _, exists := state.Projects[newName]; exists {
+				return fmt.Errorf("'%s' already exists as a project", newName)
+			}
+			if err := checkNameAvailable(state, newName, "project"); err != nil {
+				return err
+			}
+			return renameProject(cfg, state, key, proj, newName)
+		}
+	}
+
+	for key, scratch := range state.Scratches {
+		if scratch.Name == oldName {
+			if state.GetScratch(newName) != nil {
+				return fmt.Errorf("'%s' already exists as a scratch", newName)
+			}
+			if err := checkNameAvailable(state, newName, "scratch"); err != nil {
+				return err
+			}
+			return renameScratch(cfg, state, key, scratch, newName)
+		}
+	}
+
+	return fmt.Errorf("'%s' not found as experiment, project, or scratch", oldName)
+}
+
+func renameExperiment(cfg *config.Config, state *config.State, key string, exp *config.Experiment, newName string) error {
+	oldName := exp.Name
+	newKey := config.ExperimentKey(exp.Repo, newName)
+	newPath := filepath.Join(filepath.Dir(exp.Path), newKey)
+
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("path already exists: %s", newPath)
+	}
+
+	ui.Header("Renaming experiment: %s -> %s", oldName, newName)
+
+	ui.Info("Moving worktree...")
+	if err := git.MoveWorktree(exp.Repo, exp.Path, newPath); err != nil {
+		return err
+	}
+	ui.KeyValue("Path", newPath)
+
+	if newBranch, ok := promptBranchRename(exp.Branch, oldName, newName); ok {
+		if err := git.RenameBranch(exp.Repo, exp.Branch, newBranch); err != nil {
+			ui.Warn("Failed to rename branch: %v", err)
+		} else {
+			ui.Success("Renamed branch %s -> %s", exp.Branch, newBranch)
+			exp.Branch = newBranch
+		}
+	}
+
+	exp.Name = newName
+	exp.Path = newPath
+	exp.Ticket = extractTicket(newName)
+
+	if err := updateMetadataName(filepath.Join(newPath, ".clade.json"), newName, exp.Ticket); err != nil {
+		ui.Warn("Failed to update .clade.json: %v", err)
+	}
+
+	// Re-key the stored entry so fields changed meanwhile (tags, ...) survive
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		stored := s.Experiments[key]
+		if stored == nil {
+			stored = exp
+		}
+		stored.Name, stored.Path, stored.Branch, stored.Ticket = exp.Name, exp.Path, exp.Branch, exp.Ticket
+		s.RemoveExperiment(key)
+		s.AddExperiment(stored)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("Renamed experiment '%s' to '%s'", oldName, newName)
+	return nil
+}
+
+func renameProject(cfg *config.Config, state *config.State, key string, proj *config.Project, newName string) error {
+	oldName := proj.Name
+	newPath := filepath.Join(filepath.Dir(proj.Path), newName)
+
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("path already exists: %s", newPath)
+	}
+
+	ui.Header("Renaming project: %s -> %s", oldName, newName)
+
+	ui.Info("Moving project directory...")
+	if err := os.Rename(proj.Path, newPath); err != nil {
+		return fmt.Errorf("failed to move project directory: %w", err)
+	}
+
+	// The worktrees moved with the directory - point git at the new locations
+	for _, repo := range proj.Repos {
+		if err := git.RepairWorktree(repo.Source, filepath.Join(newPath, repo.Name)); err != nil {
+			ui.Warn("Failed to repair worktree for %s: %v", repo.Name, err)
+		}
+	}
+	ui.KeyValue("Path", newPath)
+
+	if newBranch, ok := promptBranchRename(proj.Branch, oldName, newName); ok {
+		renamed := true
+		for _, repo := range proj.Repos {
+			if repo.Branch != "" {
+				continue // Repos with their own branch keep it
+			}
+			if err := git.RenameBranch(repo.Source, proj.Branch, newBranch); err != nil {
+				ui.Warn("Failed to rename branch in %s: %v", repo.Name, err)
+				renamed = false
+			}
+		}
+		if renamed {
+			ui.Success("Renamed branch %s -> %s", proj.Branch, newBranch)
+			proj.Branch = newBranch
+		}
+	}
+
+	proj.Name = newName
+	proj.Path = newPath
+
+	metaPath := filepath.Join(newPath, ".clade-project.json")
+	if err := updateMetadataName(metaPath, newName, ""); err != nil {
+		ui.Warn("Failed to update .clade-project.json: %v", err)
+	}
+
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		stored := s.Projects[key]
+		if stored == nil {
+			stored = proj
+		}
+		stored.Name, stored.Path, stored.Branch = proj.Name, proj.Path, proj.Branch
+		delete(s.Projects, key)
+		s.Projects[newName] = stored
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("Renamed project '%s' to '%s'", oldName, newName)
+	return nil
+}
+
+func renameScratch(cfg *config.Config, state *config.State, key string, scratch *config.Scratch, newName string) error {
+	oldName := scratch.Name
+	newPath := filepath.Join(filepath.Dir(scratch.Path), newName)
+
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("path already exists: %s", newPath)
+	}
+
+	ui.Header("Renaming scratch: %s -> %s", oldName, newName)
+
+	if err := os.Rename(scratch.Path, newPath); err != nil {
+		return fmt.Errorf("failed to move scratch folder: %w", err)
+	}
+	ui.KeyValue("Path", newPath)
+
+	scratch.Name = newName
+	scratch.Path = newPath
+	scratch.Ticket = extractTicket(newName)
+
+	if err := updateMetadataName(filepath.Join(newPath, ".clade.json"), newName, scratch.Ticket); err != nil {
+		ui.Warn("Failed to update .clade.json: %v", err)
+	}
+
+	err := config.UpdateState(cfg, func(s *config.State) error {
+		stored := s.Scratches[key]
+		if stored == nil {
+			stored = scratch
+		}
+		stored.Name, stored.Path, stored.Ticket = scratch.Name, scratch.Path, scratch.Ticket
+		s.RemoveScratch(key)
+		s.AddScratch(stored)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	ui.Success("Renamed scratch '%s' to '%s'", oldName, newName)
+	return nil
+}
+
+// promptBranchRename suggests a new branch name derived from the new item
+// name and asks whether to rename. Returns false if the branch should be kept.
+func promptBranchRename(branch, oldName, newName string) (string, bool) {
+	if renameKeepBranchFlag || !strings.HasSuffix(branch, oldName) {
+		return "", false
+	}
+
+	suggested := strings.TrimSuffix(branch, oldName) + newName
+	if !ui.Confirm(fmt.Sprintf("Rename branch %s -> %s", branch, suggested), false) {
+		return "", false
+	}
+	return suggested, true
+}
+
+// updateMetadataName rewrites the name (and ticket, for experiments and
+// scratches) in a .clade.json or .clade-project.json file
+func updateMetadataName(path, name, ticket string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return err
+	}
+
+	metadata["name"] = name
+	if _, ok := metadata["ticket"]; ok {
+		metadata["ticket"] = ticket
+	}
+
+	return writeJSON(path, metadata)
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/daniil-lyalko/clade/internal/config"
 	"github.com/daniil-lyalko/clade/internal/git"
@@ -11,12 +13,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var repoNameFlag string
+var (
+	repoNameFlag             string
+	repoDepthFlag            int
+	repoPruneExperimentsFlag bool
+	repoConfigUnsetFlag      bool
+)
 
 var repoCmd = &cobra.Command{
 	Use:   "repo",
 	Short: "Manage registered repositories",
-	Long:  `Register, list, and remove repositories for quick access from anywhere.`,
+	Long:  `Register, list, rename, and remove repositories for quick access from anywhere.`,
 }
 
 var repoAddCmd = &cobra.Command{
@@ -26,13 +33,15 @@ var repoAddCmd = &cobra.Command{
 
 If the path is a git repository, it will be registered directly.
 If the path is a directory containing git repositories, all repos
-in that directory will be registered.
+in that directory will be registered. --depth scans nested folders too;
+the scan never descends into a repo, so submodules aren't registered.
 
 Examples:
   clade repo add ~/repos/my-project
   clade repo add . --name backend
   clade repo add ~/repos/api --name api
-  clade repo add ~/repos              # Scans and adds all repos in folder`,
+  clade repo add ~/repos              # Scans and adds all repos in folder
+  clade repo add ~/work --depth 2     # Also finds ~/work/team/project`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRepoAdd,
 }
@@ -44,23 +53,90 @@ var repoListCmd = &cobra.Command{
 }
 
 var repoRemoveCmd = &cobra.Command{
-	Use:               "remove <name>",
-	Short:             "Unregister a repository",
+	Use:   "remove <name>",
+	Short: "Unregister a repository",
+	Long: `Unregister a repository. The repo itself is left untouched.
+
+Experiments created from it stay tracked unless you choose to clean them up
+(worktrees and branches, like "clade cleanup --repo"), either at the prompt
+or with --prune-experiments.
+
+Examples:
+  clade repo remove api
+  clade repo remove api --prune-experiments`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runRepoRemove,
 	ValidArgsFunction: completeRepoNames,
 }
 
+var repoRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Change a registered repository's name",
+	Long: `Change the name a repository is registered under. The path and its
+repo_settings (copy_files, setup_command) are kept.
+
+Examples:
+  clade repo rename my-backend-service api`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runRepoRename,
+	ValidArgsFunction: completeRepoNames,
+}
+
+var repoConfigCmd = &cobra.Command{
+	Use:   "config <name> [key] [value]",
+	Short: "View or change a repository's settings",
+	Long: `View or change the repo_settings of a registered repository.
+
+Keys:
+  agent          Agent for sessions from this repo (overrides agent)
+  editor         Editor for sessions from this repo (overrides editor; "none" disables)
+  setup_command  Command run in every new worktree
+
+Sessions use --open first, then the repo's setting, then the global config.
+A project uses the settings of its first repo.
+
+Examples:
+  clade repo config api
+  clade repo config api editor nvim
+  clade repo config api agent codex
+  clade repo config api editor --unset`,
+	Args:              cobra.RangeArgs(1, 3),
+	RunE:              runRepoConfig,
+	ValidArgsFunction: completeRepoConfigArgs,
+}
+
+// repoConfigKey is a repo_settings entry editable with repo config
+type repoConfigKey struct {
+	Key string
+	Get func(cfg *config.Config, repoPath string) string
+	Set func(cfg *config.Config, repoPath, value string)
+}
+
+var repoConfigKeys = []repoConfigKey{
+	{"agent", (*config.Config).GetRepoAgent, (*config.Config).SetRepoAgent},
+	{"editor", (*config.Config).GetRepoEditor, (*config.Config).SetRepoEditor},
+	{"setup_command", (*config.Config).GetRepoSetupCommand, (*config.Config).SetRepoSetupCommand},
+}
+
 func init() {
 	rootCmd.AddCommand(repoCmd)
 	repoCmd.AddCommand(repoAddCmd)
 	repoCmd.AddCommand(repoListCmd)
 	repoCmd.AddCommand(repoRemoveCmd)
+	repoCmd.AddCommand(repoRenameCmd)
+	repoCmd.AddCommand(repoConfigCmd)
 
 	repoAddCmd.Flags().StringVar(&repoNameFlag, "name", "", "Custom name for the repository")
+	repoAddCmd.Flags().IntVar(&repoDepthFlag, "depth", 1, "How many directory levels to scan for repos")
+	repoRemoveCmd.Flags().BoolVar(&repoPruneExperimentsFlag, "prune-experiments", false, "Also clean up experiments created from the repo")
+	repoConfigCmd.Flags().BoolVar(&repoConfigUnsetFlag, "unset", false, "Clear the key, falling back to the global config")
 }
 
 func runRepoAdd(cmd *cobra.Command, args []string) error {
+	if repoDepthFlag < 1 {
+		return fmt.Errorf("--depth must be at least 1")
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -74,8 +150,8 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to resolve path: %w", err)
 	}
 
-	// Check if it's a git repo directly
-	if git.IsGitRepo(absPath) {
+	// Check if it's a git repo (or bare repo) directly
+	if git.IsSourceRepo(absPath) {
 		return addSingleRepo(cfg, absPath, repoNameFlag)
 	}
 
@@ -89,12 +165,12 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Scan for git repos in subdirectories
-	return scanAndAddRepos(cfg, absPath)
+	return scanAndAddRepos(cfg, absPath, repoDepthFlag)
 }
 
 func addSingleRepo(cfg *config.Config, absPath, customName string) error {
 	// Get repo root
-	repoRoot, err := git.GetRepoRoot(absPath)
+	repoRoot, err := git.GetRepoRootOrBare(absPath)
 	if err != nil {
 		return err
 	}
@@ -102,7 +178,7 @@ func addSingleRepo(cfg *config.Config, absPath, customName string) error {
 	// Determine name
 	name := customName
 	if name == "" {
-		name = filepath.Base(repoRoot)
+		name = repoDisplayName(repoRoot)
 	}
 
 	// Check if name already exists
@@ -122,53 +198,71 @@ func addSingleRepo(cfg *config.Config, absPath, customName string) error {
 
 	ui.Success("Registered repository '%s'", name)
 	ui.KeyValue("Path", repoRoot)
+	if git.IsBareRepo(repoRoot) {
+		ui.Detail("Bare repository: new branches start from its HEAD unless %s/HEAD is tracked", cfg.Remote)
+	}
 
 	return nil
 }
 
-func scanAndAddRepos(cfg *config.Config, dir string) error {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return fmt.Errorf("failed to read directory: %w", err)
-	}
-
+func scanAndAddRepos(cfg *config.Config, dir string, depth int) error {
 	var added, skipped, alreadyRegistered int
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			// Unreadable subdirectory - skip it rather than abort the scan
+			return nil
+		}
+		if !d.IsDir() || path == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
 		}
 
-		subdir := filepath.Join(dir, entry.Name())
-		if !git.IsGitRepo(subdir) {
-			continue
+		// A repo's own subdirectories (and submodules) are never scanned
+		if _, err := os.Lstat(filepath.Join(path, ".git")); err != nil && !looksLikeBareRepo(path) {
+			if scanDepth(dir, path) >= depth {
+				return filepath.SkipDir
+			}
+			return nil
 		}
 
-		repoRoot, err := git.GetRepoRoot(subdir)
+		repoRoot, err := git.GetRepoRootOrBare(path)
 		if err != nil {
 			skipped++
-			continue
+			return filepath.SkipDir
 		}
 
-		name := filepath.Base(repoRoot)
+		name := repoDisplayName(repoRoot)
 
 		// Check if already registered
 		if existing, ok := cfg.Repos[name]; ok {
 			if config.ExpandPath(existing) == repoRoot {
 				alreadyRegistered++
-				continue
+				return filepath.SkipDir
 			}
 			// Name conflict - skip with unique suffix note
 			ui.Warn("Skipped '%s' - name already used for %s", name, existing)
 			skipped++
-			continue
+			return filepath.SkipDir
 		}
 
 		cfg.Repos[name] = repoRoot
 		added++
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
 	}
 
 	if added == 0 && alreadyRegistered == 0 && skipped == 0 {
+		if depth == 1 {
+			return fmt.Errorf("no git repositories found in %s (search deeper with --depth)", dir)
+		}
 		return fmt.Errorf("no git repositories found in %s", dir)
 	}
 
@@ -190,6 +284,36 @@ func scanAndAddRepos(cfg *config.Config, dir string) error {
 	return nil
 }
 
+// looksLikeBareRepo checks for the layout of a bare repository before
+// asking git, so scanning ordinary folders stays cheap
+func looksLikeBareRepo(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return git.IsBareRepo(path)
+}
+
+// repoDisplayName is the default registered name for a repo: its directory
+// name, without the .git suffix bare clones usually have
+func repoDisplayName(repoRoot string) string {
+	name := filepath.Base(repoRoot)
+	if trimmed := strings.TrimSuffix(name, ".git"); trimmed != "" {
+		return trimmed
+	}
+	return name
+}
+
+// scanDepth returns how many directories below root path is
+func scanDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
+
 func runRepoList(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -223,9 +347,11 @@ func runRepoRemove(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 
-	if _, ok := cfg.Repos[name]; !ok {
+	path, ok := cfg.Repos[name]
+	if !ok {
 		return fmt.Errorf("repository '%s' not found", name)
 	}
+	repoPath := config.ExpandPath(path)
 
 	delete(cfg.Repos, name)
 
@@ -249,9 +375,159 @@ func runRepoRemove(cmd *cobra.Command, args []string) error {
 
 	ui.Success("Removed repository '%s'", name)
 
+	return offerExperimentCleanup(cfg, repoPath)
+}
+
+// offerExperimentCleanup reports the experiments still tracked from a removed
+// repo and cleans them up if asked to. Keeping them is the default.
+func offerExperimentCleanup(cfg *config.Config, repoPath string) error {
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	count := 0
+	for _, exp := range state.Experiments {
+		if cleanPath(exp.Repo) == cleanPath(repoPath) {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+
+	ui.Info("%d experiment(s) from this repo are still tracked", count)
+	prune := repoPruneExperimentsFlag
+	if !prune && !ui.AssumeYes() {
+		prune = ui.Confirm("Clean them up (worktrees and branches)", false)
+	}
+	if !prune {
+		ui.Detail("Clean them up later with: clade cleanup --repo %s", repoPath)
+		return nil
+	}
+
+	return cleanupExperimentsBulk(cfg, state, repoPath, 0)
+}
+
+func runRepoRename(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	oldName, newName := args[0], args[1]
+
+	path, ok := cfg.Repos[oldName]
+	if !ok {
+		return fmt.Errorf("repository '%s' not found", oldName)
+	}
+	if newName == oldName {
+		ui.Info("Repository is already named '%s'", newName)
+		return nil
+	}
+	if strings.TrimSpace(newName) == "" || strings.ContainsAny(newName, " \t/") {
+		return fmt.Errorf("invalid name '%s': can't be empty or contain spaces or slashes", newName)
+	}
+	if existing, ok := cfg.Repos[newName]; ok {
+		return fmt.Errorf("name '%s' already registered for %s", newName, existing)
+	}
+
+	// Settings and last_repo are keyed by path, so only the name moves
+	delete(cfg.Repos, oldName)
+	cfg.Repos[newName] = path
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	ui.Success("Renamed repository '%s' to '%s'", oldName, newName)
+	ui.KeyValue("Path", path)
+	if config.ExpandPath(path) == cfg.LastRepo {
+		ui.Detail("Still your last used repo")
+	}
+
 	return nil
 }
 
+func runRepoConfig(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	path, ok := cfg.Repos[name]
+	if !ok {
+		return fmt.Errorf("repository '%s' not found", name)
+	}
+	repoPath := config.ExpandPath(path)
+
+	if len(args) == 1 {
+		ui.Header("Settings for %s:", name)
+		for _, k := range repoConfigKeys {
+			value := k.Get(cfg, repoPath)
+			if value == "" {
+				value = ui.Dim("(not set)")
+			}
+			ui.KeyValue(k.Key, value)
+		}
+		return nil
+	}
+
+	key := findRepoConfigKey(args[1])
+	if key == nil {
+		return fmt.Errorf("unknown repo setting '%s' (valid: agent, editor, setup_command)", args[1])
+	}
+
+	switch {
+	case repoConfigUnsetFlag:
+		if len(args) == 3 {
+			return fmt.Errorf("--unset doesn't take a value")
+		}
+		key.Set(cfg, repoPath, "")
+	case len(args) == 3:
+		key.Set(cfg, repoPath, strings.TrimSpace(args[2]))
+	default:
+		fmt.Println(key.Get(cfg, repoPath))
+		return nil
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if value := key.Get(cfg, repoPath); value != "" {
+		ui.Success("Set %s.%s = %s", name, key.Key, value)
+	} else {
+		ui.Success("Unset %s.%s", name, key.Key)
+	}
+	return nil
+}
+
+func findRepoConfigKey(key string) *repoConfigKey {
+	for i := range repoConfigKeys {
+		if repoConfigKeys[i].Key == key {
+			return &repoConfigKeys[i]
+		}
+	}
+	return nil
+}
+
+// completeRepoConfigArgs completes the repo name, then the key
+func completeRepoConfigArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeRepoNames(cmd, args, toComplete)
+	case 1:
+		var keys []string
+		for _, k := range repoConfigKeys {
+			keys = append(keys, k.Key)
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // completeRepoNames provides shell completion for registered repo names
 func completeRepoNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) != 0 {
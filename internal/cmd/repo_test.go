@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestRepoAgentAndEditorPrecedence(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &repoNameFlag, "")
+	setFlag(t, &repoConfigUnsetFlag, false)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	cfg.Agent, cfg.Editor = "claude", "code"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	rust := newTestRepo(t, "rust")
+	web := newTestRepo(t, "web")
+
+	for _, args := range [][]string{
+		{"repo", "add", rust},
+		{"repo", "add", web},
+		{"repo", "config", "rust", "editor", "nvim"},
+		{"repo", "config", "rust", "agent", "codex"},
+		{"exp", "spike", "-r", rust, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"},
+		{"exp", "spike", "-r", web, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"},
+	} {
+		if code := executeArgs(t, args...); code != 0 {
+			t.Fatalf("clade %v exited %d", args, code)
+		}
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rustExp := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(rust, "spike"))
+	webExp := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(web, "spike"))
+
+	tests := []struct {
+		workdir, override string
+		wantAgent         string
+		wantEditor        string
+	}{
+		{rustExp, "", "codex", "nvim"},   // repo setting beats global
+		{rustExp, "zed", "codex", "zed"}, // --open beats the repo
+		{webExp, "", "claude", "code"},   // no repo setting: global
+	}
+	for _, tt := range tests {
+		session := repoSessionConfig(cfg, sessionRepo(tt.workdir))
+		if session.Agent != tt.wantAgent {
+			t.Errorf("%s: agent %q, want %q", filepath.Base(tt.workdir), session.Agent, tt.wantAgent)
+		}
+		if got := resolveEditor(session, tt.override); got != tt.wantEditor {
+			t.Errorf("%s, override %q: editor %q, want %q", filepath.Base(tt.workdir), tt.override, got, tt.wantEditor)
+		}
+	}
+	if cfg.Agent != "claude" || cfg.Editor != "code" {
+		t.Errorf("global config was changed to agent %q, editor %q", cfg.Agent, cfg.Editor)
+	}
+
+	// --unset falls back to the global agent again
+	if code := executeArgs(t, "repo", "config", "rust", "agent", "--unset"); code != 0 {
+		t.Fatalf("repo config --unset exited %d", code)
+	}
+	if cfg, err = config.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := repoSessionConfig(cfg, sessionRepo(rustExp)).Agent; got != "claude" {
+		t.Errorf("after --unset: agent %q, want claude", got)
+	}
+}
+
+func TestScanDepth(t *testing.T) {
+	root := filepath.Join("/", "work")
+	tests := []struct {
+		path string
+		want int
+	}{
+		{filepath.Join(root, "api"), 1},
+		{filepath.Join(root, "team", "api"), 2},
+		{filepath.Join(root, "team", "backend", "api"), 3},
+	}
+	for _, tt := range tests {
+		if got := scanDepth(root, tt.path); got != tt.want {
+			t.Errorf("scanDepth(%s) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestScanAndAddReposWalksToDepth(t *testing.T) {
+	cfg := setupTestEnv(t)
+	work, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rel := range []string{
+		"top",
+		"team/project",
+		"team/project/vendor/lib", // inside a repo: never registered
+		".hidden/secret",
+		"team/.cache/cached",
+		"a/b/deep",
+	} {
+		path := filepath.Join(work, rel)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		runTestGit(t, path, "init", "-q")
+	}
+
+	tests := []struct {
+		depth int
+		want  map[string]string
+	}{
+		{1, map[string]string{"top": filepath.Join(work, "top")}},
+		{2, map[string]string{
+			"top":     filepath.Join(work, "top"),
+			"project": filepath.Join(work, "team", "project"),
+		}},
+		{3, map[string]string{
+			"top":     filepath.Join(work, "top"),
+			"project": filepath.Join(work, "team", "project"),
+			"deep":    filepath.Join(work, "a", "b", "deep"),
+		}},
+	}
+	for _, tt := range tests {
+		cfg.Repos = map[string]string{}
+		if err := scanAndAddRepos(cfg, work, tt.depth); err != nil {
+			t.Fatalf("depth %d: %v", tt.depth, err)
+		}
+		if !reflect.DeepEqual(cfg.Repos, tt.want) {
+			t.Errorf("depth %d: registered %v, want %v", tt.depth, cfg.Repos, tt.want)
+		}
+	}
+
+	// A second scan finds everything already registered
+	if err := scanAndAddRepos(cfg, work, 3); err != nil {
+		t.Errorf("rescan: %v", err)
+	}
+	if len(cfg.Repos) != 3 {
+		t.Errorf("rescan changed the repos: %v", cfg.Repos)
+	}
+}
+
+func TestRepoAddRegistersBareClone(t *testing.T) {
+	setupTestEnv(t)
+	setFlag(t, &repoNameFlag, "")
+	origin := newTestRepo(t, "api")
+	bare := filepath.Join(filepath.Dir(origin), "api.git")
+	runTestGit(t, origin, "clone", "-q", "--bare", origin, bare)
+
+	if code := executeArgs(t, "repo", "add", bare); code != 0 {
+		t.Fatalf("repo add exited %d", code)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Repos["api"]; got != bare {
+		t.Errorf("repos[api] = %q, want the bare dir %q (all: %v)", got, bare, cfg.Repos)
+	}
+}
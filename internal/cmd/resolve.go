@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/manifoldco/promptui"
+)
+
+// resolvedItem is a tracked experiment, project, or scratch found by name.
+// Exactly one of Experiment, Project, or Scratch is set.
+type resolvedItem struct {
+	Type       string // "experiment", "project", or "scratch"
+	Name       string
+	Key        string // key in the state map
+	Path       string
+	LastUsed   time.Time
+	Tags       []string
+	Experiment *config.Experiment
+	Project    *config.Project
+	Scratch    *config.Scratch
+}
+
+// touchItem marks the item stored under key as just used. It's saved under
+// the state lock so a slow command (e.g. resume's fetch) can't overwrite
+// changes made meanwhile. A failed save only loses the timestamp, so it's
+// ignored.
+func touchItem(cfg *config.Config, itemType, key string) {
+	now := time.Now()
+	config.UpdateState(cfg, func(s *config.State) error {
+		switch itemType {
+		case "experiment":
+			if exp := s.Experiments[key]; exp != nil {
+				exp.LastUsed = now
+			}
+		case "project":
+			if proj := s.Projects[key]; proj != nil {
+				proj.LastUsed = now
+			}
+		case "scratch":
+			if scratch := s.Scratches[key]; scratch != nil {
+				scratch.LastUsed = now
+			}
+		}
+		return nil
+	})
+}
+
+// resolveItem finds a tracked item by name. If the name is shared by more
+// than one item, the user picks which one. Returns nil if nothing matches.
+func resolveItem(state *config.State, name string) (*resolvedItem, error) {
+	var matches []*resolvedItem
+	for _, item := range recentItems(state) {
+		if item.Name == name {
+			matches = append(matches, item)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	var displayItems []string
+	for _, item := range matches {
+		displayItems = append(displayItems, fmt.Sprintf("%s %s %s", item.Name, item.label(), item.Path))
+	}
+
+	if err := ui.Interactive(fmt.Sprintf("choose between the items named '%s'", name)); err != nil {
+		return nil, err
+	}
+	prompt := promptui.Select{
+		Label:  fmt.Sprintf("'%s' matches more than one item", name),
+		Items:  displayItems,
+		Stdout: os.Stderr, // Keep stdout clean for "clade open"
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+	return matches[idx], nil
+}
+
+// label is the short type tag shown in pickers
+func (r *resolvedItem) label() string {
+	if r.Experiment != nil {
+		return "[exp]"
+	}
+	return "[" + r.Type + "]"
+}
+
+// recentItems returns every tracked item, most recently used first
+func recentItems(state *config.State) []*resolvedItem {
+	var items []*resolvedItem
+	for key, exp := range state.Experiments {
+		items = append(items, experimentItem(key, exp))
+	}
+	for key, proj := range state.Projects {
+		items = append(items, projectItem(key, proj))
+	}
+	for key, scratch := range state.Scratches {
+		items = append(items, scratchItem(key, scratch))
+	}
+
+	sortByLastUsed(items, func(item *resolvedItem) (time.Time, string) {
+		return item.LastUsed, item.Name
+	})
+	return items
+}
+
+// sortByLastUsed orders items most recently used first. Ties are broken by
+// name so items touched in the same second always come out in the same order.
+func sortByLastUsed[T any](items []T, key func(T) (time.Time, string)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		iUsed, iName := key(items[i])
+		jUsed, jName := key(items[j])
+		if !iUsed.Equal(jUsed) {
+			return iUsed.After(jUsed)
+		}
+		return iName < jName
+	})
+}
+
+// mostRecentItem returns the tracked item used most recently
+func mostRecentItem(state *config.State) (*resolvedItem, bool) {
+	items := recentItems(state)
+	if len(items) == 0 {
+		return nil, false
+	}
+	return items[0], true
+}
+
+func experimentItem(key string, exp *config.Experiment) *resolvedItem {
+	return &resolvedItem{Type: "experiment", Name: exp.Name, Key: key, Path: exp.Path, LastUsed: exp.LastUsed, Tags: exp.Tags, Experiment: exp}
+}
+
+func projectItem(key string, proj *config.Project) *resolvedItem {
+	return &resolvedItem{Type: "project", Name: proj.Name, Key: key, Path: proj.Path, LastUsed: proj.LastUsed, Tags: proj.Tags, Project: proj}
+}
+
+func scratchItem(key string, scratch *config.Scratch) *resolvedItem {
+	return &resolvedItem{Type: "scratch", Name: scratch.Name, Key: key, Path: scratch.Path, LastUsed: scratch.LastUsed, Tags: scratch.Tags, Scratch: scratch}
+}
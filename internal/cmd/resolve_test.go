@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestSortByLastUsedBreaksTiesByName(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	exps := map[string]*config.Experiment{}
+	for _, name := range []string{"delta", "alpha", "charlie", "bravo"} {
+		exps[name] = &config.Experiment{Name: name, LastUsed: now}
+	}
+	exps["newest"] = &config.Experiment{Name: "newest", LastUsed: now.Add(time.Minute)}
+	exps["oldest"] = &config.Experiment{Name: "oldest", LastUsed: now.Add(-time.Hour)}
+
+	want := []string{"newest", "alpha", "bravo", "charlie", "delta", "oldest"}
+	// Map iteration order is random, so repeat to catch unstable ties
+	for i := 0; i < 20; i++ {
+		var got []string
+		for _, exp := range sortExperimentsByLastUsed(exps) {
+			got = append(got, exp.Name)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestRecentItemsOrderIsStableAcrossTypes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	state := &config.State{
+		Experiments: map[string]*config.Experiment{"k-try": {Name: "try", LastUsed: now}},
+		Projects:    map[string]*config.Project{"platform": {Name: "platform", LastUsed: now}},
+		Scratches:   map[string]*config.Scratch{"notes": {Name: "notes", LastUsed: now}},
+	}
+
+	want := []string{"notes", "platform", "try"}
+	for i := 0; i < 20; i++ {
+		var got []string
+		for _, item := range recentItems(state) {
+			got = append(got, item.Name)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d: got %q, want %q", i, got, want)
+		}
+	}
+}
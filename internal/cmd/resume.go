@@ -29,7 +29,8 @@ var resumeCmd = &cobra.Command{
 If the experiment exists in clade's state, it resumes directly.
 If not tracked but the branch exists (locally or remotely), it adopts it.
 
-Searches for branches named "exp/<name>" or "feat/<name>". Use --branch to
+Searches for branches named "exp/<name>" or "feat/<name>" (prefixes are
+configurable via exp_branch_prefix and feat_branch_prefix). Use --branch to
 adopt any existing branch regardless of naming convention.
 
 The SessionStart hook will automatically inject context including:
@@ -41,6 +42,7 @@ The SessionStart hook will automatically inject context including:
 Examples:
   clade resume                       # Interactive picker
   clade resume try-redis             # Specific experiment
+  clade resume -                     # Most recently used item
   clade resume try-redis -r backend  # Adopt branch from specific repo
   clade resume price-formula -r backend --branch feat/price-formula-system
   clade resume try-redis -o cursor   # Resume + open Cursor IDE
@@ -78,24 +80,17 @@ func runResume(cmd *cobra.Command, args []string) error {
 	}
 
 	name := args[0]
-
-	// First, check if it's already tracked
-	for _, exp := range state.Experiments {
-		if exp.Name == name {
-			return resumeTrackedExperiment(cfg, state, exp)
-		}
+	if name == "-" {
+		return resumeMostRecent(cfg, state)
 	}
 
-	for _, proj := range state.Projects {
-		if proj.Name == name {
-			return resumeTrackedProject(cfg, state, proj)
-		}
+	// First, check if it's already tracked
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
 	}
-
-	for _, scratch := range state.Scratches {
-		if scratch.Name == name {
-			return resumeTrackedScratch(cfg, state, scratch)
-		}
+	if item != nil {
+		return resumeItem(cfg, state, item)
 	}
 
 	// Not tracked - try to adopt orphaned branch
@@ -103,71 +98,24 @@ func runResume(cmd *cobra.Command, args []string) error {
 }
 
 func resumeInteractive(cfg *config.Config, state *config.State) error {
-	if len(state.Experiments) == 0 && len(state.Projects) == 0 && len(state.Scratches) == 0 {
-		ui.Info("No experiments, projects, or scratch folders to resume")
-		ui.Detail("Create one with: clade exp <name>")
-		ui.Detail("Or for no-git: clade scratch <name>")
-		ui.Detail("Or adopt an existing branch: clade resume <branch-name> -r <repo>")
+	items := recentItems(state)
+	if len(items) == 0 {
+		printNothingToResume()
 		return nil
 	}
 
-	type pickItem struct {
-		Name     string
-		Path     string
-		Type     string
-		LastUsed time.Time
-		Display  string
-	}
-
-	var items []pickItem
-
-	for _, exp := range state.Experiments {
-		age := formatAge(exp.LastUsed)
-		items = append(items, pickItem{
-			Name:     exp.Name,
-			Path:     exp.Path,
-			Type:     "experiment",
-			LastUsed: exp.LastUsed,
-			Display:  fmt.Sprintf("%s %s (%s)", exp.Name, ui.Dim("[exp]"), ui.Dim(age)),
-		})
-	}
-
-	for _, proj := range state.Projects {
-		age := formatAge(proj.LastUsed)
-		items = append(items, pickItem{
-			Name:     proj.Name,
-			Path:     proj.Path,
-			Type:     "project",
-			LastUsed: proj.LastUsed,
-			Display:  fmt.Sprintf("%s %s (%s)", proj.Name, ui.Dim("[project]"), ui.Dim(age)),
-		})
-	}
-
-	for _, scratch := range state.Scratches {
-		age := formatAge(scratch.LastUsed)
-		items = append(items, pickItem{
-			Name:     scratch.Name,
-			Path:     scratch.Path,
-			Type:     "scratch",
-			LastUsed: scratch.LastUsed,
-			Display:  fmt.Sprintf("%s %s (%s)", scratch.Name, ui.Dim("[scratch]"), ui.Dim(age)),
-		})
-	}
-
-	// Sort by last used
-	for i := 0; i < len(items)-1; i++ {
-		for j := i + 1; j < len(items); j++ {
-			if items[j].LastUsed.After(items[i].LastUsed) {
-				items[i], items[j] = items[j], items[i]
-			}
-		}
-	}
-
 	var displayItems []string
 	for _, item := range items {
-		displayItems = append(displayItems, item.Display)
+		display := fmt.Sprintf("%s %s (%s)", item.Name, ui.Dim(item.label()), ui.Dim(formatAge(item.LastUsed)))
+		if len(item.Tags) > 0 {
+			display += " " + ui.Yellow(formatTags(item.Tags))
+		}
+		displayItems = append(displayItems, display)
 	}
 
+	if err := ui.Interactive("pick what to resume without a name"); err != nil {
+		return err
+	}
 	prompt := promptui.Select{
 		Label: "Select to resume",
 		Items: displayItems,
@@ -179,28 +127,37 @@ func resumeInteractive(cfg *config.Config, state *config.State) error {
 		return err
 	}
 
-	switch items[idx].Type {
-	case "experiment":
-		for _, exp := range state.Experiments {
-			if exp.Name == items[idx].Name {
-				return resumeTrackedExperiment(cfg, state, exp)
-			}
-		}
-	case "project":
-		for _, proj := range state.Projects {
-			if proj.Name == items[idx].Name {
-				return resumeTrackedProject(cfg, state, proj)
-			}
-		}
-	case "scratch":
-		for _, scratch := range state.Scratches {
-			if scratch.Name == items[idx].Name {
-				return resumeTrackedScratch(cfg, state, scratch)
-			}
-		}
+	return resumeItem(cfg, state, items[idx])
+}
+
+// resumeMostRecent resumes whichever tracked item was used last
+func resumeMostRecent(cfg *config.Config, state *config.State) error {
+	item, ok := mostRecentItem(state)
+	if !ok {
+		printNothingToResume()
+		return nil
 	}
+	return resumeItem(cfg, state, item)
+}
+
+// resumeItem resumes a tracked experiment, project, or scratch
+func resumeItem(cfg *config.Config, state *config.State, item *resolvedItem) error {
+	switch {
+	case item.Experiment != nil:
+		return resumeTrackedExperiment(cfg, state, item.Experiment)
+	case item.Project != nil:
+		return resumeTrackedProject(cfg, state, item.Project)
+	default:
+		return resumeTrackedScratch(cfg, state, item.Scratch)
+	}
+}
 
-	return nil
+// printNothingToResume explains how to create something to resume
+func printNothingToResume() {
+	ui.Info("No experiments, projects, or scratch folders to resume")
+	ui.Detail("Create one with: clade exp <name>")
+	ui.Detail("Or for no-git: clade scratch <name>")
+	ui.Detail("Or adopt an existing branch: clade resume <branch-name> -r <repo>")
 }
 
 func resumeTrackedExperiment(cfg *config.Config, state *config.State, exp *config.Experiment) error {
@@ -213,19 +170,16 @@ func resumeTrackedExperiment(cfg *config.Config, state *config.State, exp *confi
 	}
 
 	// Check for divergence if remote exists
-	git.Fetch(exp.Repo)
-	branchInfo := git.CheckBranch(exp.Repo, exp.Branch)
+	git.Fetch(exp.Repo, cfg.Remote)
+	branchInfo := git.CheckBranch(exp.Repo, cfg.Remote, exp.Branch)
 	if branchInfo.Diverged {
-		ui.Warn("Branch diverged from origin (%d local, %d remote commits)", branchInfo.LocalAhead, branchInfo.RemoteBehind)
+		ui.Warn("Branch diverged from %s (%d local, %d remote commits)", cfg.Remote, branchInfo.LocalAhead, branchInfo.RemoteBehind)
 		ui.Detail("Resolve in worktree: git pull --rebase OR git merge")
 	} else if branchInfo.RemoteBehind > 0 {
 		ui.Info("Remote has %d new commits - consider: git pull", branchInfo.RemoteBehind)
 	}
 
-	// Update last used
-	exp.LastUsed = time.Now()
-	state.Experiments[config.ExperimentKey(exp.Repo, exp.Name)] = exp
-	state.Save(cfg)
+	touchItem(cfg, "experiment", config.ExperimentKey(exp.Repo, exp.Name))
 
 	ui.Header("Resuming: %s", exp.Name)
 	ui.KeyValue("Path", exp.Path)
@@ -242,17 +196,14 @@ func resumeTrackedProject(cfg *config.Config, state *config.State, proj *config.
 
 	// Check divergence for each repo
 	for _, repo := range proj.Repos {
-		git.Fetch(repo.Source)
-		branchInfo := git.CheckBranch(repo.Source, proj.Branch)
+		git.Fetch(repo.Source, cfg.Remote)
+		branchInfo := git.CheckBranch(repo.Source, cfg.Remote, proj.RepoBranch(repo))
 		if branchInfo.Diverged {
 			ui.Warn("%s: branch diverged (%d local, %d remote)", repo.Name, branchInfo.LocalAhead, branchInfo.RemoteBehind)
 		}
 	}
 
-	// Update last used
-	proj.LastUsed = time.Now()
-	state.Projects[proj.Name] = proj
-	state.Save(cfg)
+	touchItem(cfg, "project", proj.Name)
 
 	ui.Header("Resuming: %s", proj.Name)
 	ui.KeyValue("Path", proj.Path)
@@ -270,32 +221,35 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 	}
 
 	repoName := git.GetRepoName(repoPath)
-	git.Fetch(repoPath)
+	git.Fetch(repoPath, cfg.Remote)
 
-	// Use explicit branch if provided, otherwise search exp/ and feat/ prefixes
+	// Use explicit branch if provided, otherwise search the configured exp and feat prefixes
 	var branch string
 	var branchInfo git.BranchInfo
 
 	if resumeBranchFlag != "" {
 		branch = resumeBranchFlag
 		ui.Info("Checking for branch '%s' in %s...", branch, repoName)
-		branchInfo = git.CheckBranch(repoPath, branch)
+		branchInfo = git.CheckBranch(repoPath, cfg.Remote, branch)
 	} else {
-		// Search for exp/ first, then feat/
-		expBranch := "exp/" + name
-		featBranch := "feat/" + name
+		// Search for the exp prefix first, then feat
+		expBranch := cfg.ExpBranchPrefix + name
+		featBranch := cfg.FeatBranchPrefix + name
 
 		ui.Info("Searching for branches in %s...", repoName)
 
-		expInfo := git.CheckBranch(repoPath, expBranch)
-		featInfo := git.CheckBranch(repoPath, featBranch)
+		expInfo := git.CheckBranch(repoPath, cfg.Remote, expBranch)
+		featInfo := git.CheckBranch(repoPath, cfg.Remote, featBranch)
 
 		expFound := expInfo.Status != git.BranchNotFound
 		featFound := featInfo.Status != git.BranchNotFound
 
 		if expFound && featFound {
 			// Both exist - prompt user to choose
-			ui.Info("Found both exp/%s and feat/%s", name, name)
+			ui.Info("Found both %s and %s", expBranch, featBranch)
+			if err := ui.Interactive("choose between " + expBranch + " and " + featBranch + " without --branch"); err != nil {
+				return err
+			}
 			prompt := promptui.Select{
 				Label: "Which branch",
 				Items: []string{expBranch, featBranch},
@@ -329,8 +283,21 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 		return fmt.Errorf("branch not found")
 	}
 
-	// Branch exists - adopt it
-	expKey := config.ExperimentKey(repoPath, name)
+	// A branch can only be checked out in one worktree at a time
+	if path, ok := git.BranchCheckedOutAt(repoPath, branch); ok {
+		return branchCheckedOutError(repoPath, branch, path)
+	}
+
+	// Branch exists - adopt it. The name becomes both the state key and the
+	// directory, so it's tracked under its path-safe form.
+	itemName := sanitizeForPath(name)
+	if itemName != name {
+		if err := checkNameAvailable(state, itemName, "experiment"); err != nil {
+			return err
+		}
+		ui.Info("Tracking it as '%s'", itemName)
+	}
+	expKey := config.ExperimentKey(repoPath, itemName)
 	expPath := filepath.Join(cfg.ExperimentsDir(), expKey)
 
 	// Ensure experiments directory exists
@@ -346,8 +313,8 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 		}
 
 	case git.BranchRemoteOnly:
-		ui.Info("Tracking remote branch 'origin/%s'", branch)
-		if err := git.CreateWorktreeTrackRemote(repoPath, expPath, branch); err != nil {
+		ui.Info("Tracking remote branch '%s/%s'", cfg.Remote, branch)
+		if err := git.CreateWorktreeTrackRemote(repoPath, cfg.Remote, expPath, branch); err != nil {
 			return err
 		}
 
@@ -357,7 +324,7 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 			return err
 		}
 		if branchInfo.Diverged {
-			ui.Warn("Branch diverged from origin (%d local, %d remote commits)", branchInfo.LocalAhead, branchInfo.RemoteBehind)
+			ui.Warn("Branch diverged from %s (%d local, %d remote commits)", cfg.Remote, branchInfo.LocalAhead, branchInfo.RemoteBehind)
 			ui.Detail("Resolve in worktree: git pull --rebase OR git merge")
 		}
 	}
@@ -368,9 +335,9 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 	}
 
 	// Add to state
-	ticket := extractTicket(name)
+	ticket := extractTicket(itemName)
 	exp := &config.Experiment{
-		Name:     name,
+		Name:     itemName,
 		Repo:     repoPath,
 		Path:     expPath,
 		Branch:   branch,
@@ -378,10 +345,12 @@ func adoptOrphanedBranch(cfg *config.Config, state *config.State, name string) e
 		Created:  time.Now(),
 		LastUsed: time.Now(),
 	}
-	state.AddExperiment(exp)
-	state.Save(cfg)
+	config.UpdateState(cfg, func(s *config.State) error {
+		s.AddExperiment(exp)
+		return nil
+	})
 
-	ui.Success("Adopted experiment '%s'", name)
+	ui.Success("Adopted experiment '%s'", itemName)
 	ui.KeyValue("Path", expPath)
 
 	return launchSession(cfg, expPath, resumeEditorFlag, resumeNoAgentFlag, resumeNoEditorFlag)
@@ -396,10 +365,7 @@ func resumeTrackedScratch(cfg *config.Config, state *config.State, scratch *conf
 		return fmt.Errorf("scratch folder not found")
 	}
 
-	// Update last used
-	scratch.LastUsed = time.Now()
-	state.Scratches[scratch.Name] = scratch
-	state.Save(cfg)
+	touchItem(cfg, "scratch", scratch.Name)
 
 	ui.Header("Resuming: %s", scratch.Name)
 	ui.KeyValue("Path", scratch.Path)
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestSanitizeForPath(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"try-redis", "try-redis"},
+		{"feat/a/b", "feat-a-b"},
+		{"a/b", "a-b"},
+		{"PROJ-12 fix: login", "PROJ-12-fix-login"},
+		{"../escape", "escape"},
+		{"///", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeForPath(tt.in); got != tt.want {
+			t.Errorf("sanitizeForPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAdoptOrphanedBranchUsesSanitizedNameForPathAndState(t *testing.T) {
+	cfg := setupTestEnv(t)
+	repo := newTestRepo(t, "api")
+	runTestGit(t, repo, "branch", "feat/a/b")
+
+	setFlag(t, &resumeRepoFlag, repo)
+	setFlag(t, &resumeBranchFlag, "feat/a/b")
+	setFlag(t, &resumeNoAgentFlag, true)
+	setFlag(t, &resumeNoEditorFlag, true)
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := adoptOrphanedBranch(cfg, state, "a/b"); err != nil {
+		t.Fatalf("adoptOrphanedBranch: %v", err)
+	}
+
+	state, err = config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := config.ExperimentKey(repo, "a-b")
+	exp := state.GetExperiment(key)
+	if exp == nil {
+		t.Fatalf("no experiment stored under %s; have %v", key, state.Experiments)
+	}
+	if exp.Name != "a-b" {
+		t.Errorf("Name = %q, want a-b", exp.Name)
+	}
+	if filepath.Base(exp.Path) != key {
+		t.Errorf("Path %s doesn't match state key %s", exp.Path, key)
+	}
+	if exp.Branch != "feat/a/b" {
+		t.Errorf("Branch = %q, want the real branch feat/a/b", exp.Branch)
+	}
+	if got := runTestGit(t, exp.Path, "rev-parse", "--abbrev-ref", "HEAD"); got != "feat/a/b" {
+		t.Errorf("worktree is on %s, want feat/a/b", got)
+	}
+
+	// Lookups by the tracked name must find it again
+	item, err := resolveItem(state, "a-b")
+	if err != nil || item == nil || item.Path != exp.Path {
+		t.Errorf("resolveItem(a-b) = %+v, %v", item, err)
+	}
+}
+
+func TestAdoptOrphanedBranchRefusesBranchCheckedOutElsewhere(t *testing.T) {
+	cfg := setupTestEnv(t)
+	repo := newTestRepo(t, "api")
+	other := filepath.Join(t.TempDir(), "elsewhere")
+	runTestGit(t, repo, "worktree", "add", "-q", "-b", "exp/busy", other)
+
+	setFlag(t, &resumeRepoFlag, repo)
+	setFlag(t, &resumeNoAgentFlag, true)
+	setFlag(t, &resumeNoEditorFlag, true)
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = adoptOrphanedBranch(cfg, state, "busy")
+	if err == nil || !strings.Contains(err.Error(), "already checked out at") || !strings.Contains(err.Error(), "elsewhere") {
+		t.Fatalf("got %v, want an error pointing at the existing worktree", err)
+	}
+
+	state, err = config.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Experiments) != 0 {
+		t.Errorf("a refused adopt was tracked: %v", state.Experiments)
+	}
+}
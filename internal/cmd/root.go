@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/context"
+	"github.com/daniil-lyalko/clade/internal/files"
 	"github.com/daniil-lyalko/clade/internal/git"
 	"github.com/daniil-lyalko/clade/internal/ui"
 	"github.com/manifoldco/promptui"
@@ -26,16 +31,88 @@ Quick start:
   clade resume try-redis    # Get back to work
   clade cleanup try-redis   # Clean up when done`,
 	RunE: runInteractiveDashboard,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		activeCmd = cmd
+		if err := ui.SetColorMode(rootColorFlag); err != nil {
+			return err
+		}
+		ui.SetAssumeYes(rootYesFlag || envTrue("CLADE_YES"))
+		git.SetVerbose(rootVerboseFlag)
+		applyConfigSettings()
+		return nil
+	},
 }
 
-// Execute runs the root command
-func Execute() error {
-	return rootCmd.Execute()
+var (
+	rootColorFlag   string
+	rootYesFlag     bool
+	rootVerboseFlag bool
+)
+
+// activeCmd is the command being run, set before its RunE
+var activeCmd *cobra.Command
+
+// Execute runs the root command and returns the process exit code: a child
+// process's code if a command passed one through (see exitCodeError), 1 for
+// any other error, and 0 on success.
+func Execute() int {
+	err := rootCmd.Execute()
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// exitCodeError carries a child process's exit code back to Execute
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// silentExit returns an exitCodeError for a child process that has already
+// reported its own failure, so cobra doesn't print an error or usage for it
+func silentExit(code int) error {
+	if activeCmd != nil {
+		activeCmd.SilenceErrors = true
+		activeCmd.SilenceUsage = true
+	}
+	return &exitCodeError{code: code}
 }
 
 func init() {
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	rootCmd.PersistentFlags().StringVar(&rootColorFlag, "color", "auto", "Colorize output: always, never, or auto")
+	rootCmd.PersistentFlags().BoolVarP(&rootYesFlag, "yes", "y", false, "Take each prompt's default and fail where there is none (also CLADE_YES=1)")
+	rootCmd.PersistentFlags().BoolVarP(&rootVerboseFlag, "verbose", "v", false, "Log each git command and how long it took to stderr")
+}
+
+// envTrue reports whether an environment variable is set to a true value
+func envTrue(name string) bool {
+	enabled, err := strconv.ParseBool(os.Getenv(name))
+	return err == nil && enabled
+}
+
+// applyConfigSettings configures the git, files, and context packages from the user's config.
+// Load errors are ignored here - the command itself will report them.
+func applyConfigSettings() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	git.SetTimeout(cfg.GetGitTimeout())
+	files.SetSymlink(cfg.CopyFilesMode == "symlink")
+	context.SetDropbagMaxBytes(cfg.GetDropbagMaxBytes())
+	context.SetSections(cfg.ContextSections)
+	context.SetRemote(cfg.Remote)
+	context.SetDropbagHistory(cfg.DropbagHistory)
+	context.SetTodoOptions(cfg.TodoExtensions, cfg.TodoKeywords)
+	setTicketPattern(cfg.TicketPattern)
 }
 
 // runInteractiveDashboard shows a dashboard and action picker when clade is run with no args
@@ -51,13 +128,13 @@ func runInteractiveDashboard(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show dashboard
-	showDashboard(state)
+	showDashboard(cfg, state)
 
 	// Show action picker
 	return showActionPicker(cfg, state)
 }
 
-func showDashboard(state *config.State) {
+func showDashboard(cfg *config.Config, state *config.State) {
 	hasContent := false
 
 	// Show experiments (most recent first, limit to 5)
@@ -72,7 +149,7 @@ func showDashboard(state *config.State) {
 				ui.Detail("%s", ui.Dim(fmt.Sprintf("  ... and %d more", remaining)))
 				break
 			}
-			printDashboardExperiment(exp)
+			printDashboardExperiment(exp, cfg.Remote, cfg.StaleAfter())
 			shown++
 		}
 	}
@@ -81,8 +158,8 @@ func showDashboard(state *config.State) {
 	if len(state.Projects) > 0 {
 		hasContent = true
 		ui.Header("Active projects:")
-		for _, proj := range state.Projects {
-			printDashboardProject(proj)
+		for _, proj := range sortProjectsByLastUsed(state.Projects) {
+			printDashboardProject(proj, cfg.Remote)
 		}
 	}
 
@@ -98,7 +175,7 @@ func showDashboard(state *config.State) {
 				ui.Detail("%s", ui.Dim(fmt.Sprintf("  ... and %d more", remaining)))
 				break
 			}
-			printDashboardScratch(scratch)
+			printDashboardScratch(scratch, cfg.StaleAfter())
 			shown++
 		}
 	}
@@ -111,13 +188,13 @@ func showDashboard(state *config.State) {
 	fmt.Println()
 }
 
-func printDashboardExperiment(exp *config.Experiment) {
+func printDashboardExperiment(exp *config.Experiment, remote string, staleAfter time.Duration) {
 	repoName := filepath.Base(exp.Repo)
 	age := formatAge(exp.LastUsed)
 
 	// Check if stale
 	staleMarker := ""
-	if time.Since(exp.LastUsed) > 7*24*time.Hour {
+	if time.Since(exp.LastUsed) > staleAfter {
 		staleMarker = " " + ui.Yellow("(stale)")
 	}
 
@@ -126,6 +203,9 @@ func printDashboardExperiment(exp *config.Experiment) {
 	if hasChanges, _ := git.HasUncommittedChanges(exp.Path); hasChanges {
 		statusMarker = " " + ui.Yellow("*")
 	}
+	if git.CheckBranchLocal(exp.Repo, remote, exp.Branch).RemoteBehind > 0 {
+		statusMarker += " " + ui.Yellow("↓")
+	}
 
 	fmt.Printf("  %s %s - %s%s%s\n",
 		ui.Cyan(exp.Name),
@@ -136,25 +216,49 @@ func printDashboardExperiment(exp *config.Experiment) {
 	)
 }
 
-func printDashboardProject(proj *config.Project) {
+func printDashboardProject(proj *config.Project, remote string) {
 	age := formatAge(proj.LastUsed)
 
-	var repoNames []string
+	// Local checks only - the dashboard never fetches
+	dirty, behind := 0, 0
 	for _, r := range proj.Repos {
-		repoNames = append(repoNames, r.Name)
+		if hasChanges, _ := git.HasUncommittedChanges(filepath.Join(proj.Path, r.Name)); hasChanges {
+			dirty++
+		}
+		if git.CheckBranchLocal(r.Source, remote, proj.RepoBranch(r)).RemoteBehind > 0 {
+			behind++
+		}
+	}
+
+	summary := fmt.Sprintf("%d repos", len(proj.Repos))
+	if dirty > 0 {
+		summary += fmt.Sprintf(", %d dirty", dirty)
+	}
+	if behind > 0 {
+		summary += fmt.Sprintf(", %d behind", behind)
+	}
+
+	statusMarker := ""
+	if dirty > 0 {
+		statusMarker = " " + ui.Yellow("*")
+	}
+	if behind > 0 {
+		statusMarker += " " + ui.Yellow("↓")
 	}
 
-	fmt.Printf("  %s - %s\n",
+	fmt.Printf("  %s %s - %s%s\n",
 		ui.Cyan(proj.Name),
+		ui.Dim("("+summary+")"),
 		ui.Dim(age),
+		statusMarker,
 	)
 }
 
-func printDashboardScratch(scratch *config.Scratch) {
+func printDashboardScratch(scratch *config.Scratch, staleAfter time.Duration) {
 	age := formatAge(scratch.LastUsed)
 
 	staleMarker := ""
-	if time.Since(scratch.LastUsed) > 7*24*time.Hour {
+	if time.Since(scratch.LastUsed) > staleAfter {
 		staleMarker = " " + ui.Yellow("(stale)")
 	}
 
@@ -258,6 +362,9 @@ func showActionPicker(cfg *config.Config, state *config.State) error {
 		}
 	}
 
+	if err := ui.Interactive("pick an action"); err != nil {
+		return err
+	}
 	prompt := promptui.Select{
 		Label: "What would you like to do",
 		Items: items,
@@ -275,12 +382,7 @@ func showActionPicker(cfg *config.Config, state *config.State) error {
 
 // runInteractiveRepoAdd prompts for a path and adds a repo
 func runInteractiveRepoAdd() error {
-	prompt := promptui.Prompt{
-		Label:   "Repository path",
-		Default: ".",
-	}
-
-	path, err := prompt.Run()
+	path, err := ui.Input("Repository path", ".")
 	if err != nil {
 		return nil
 	}
@@ -293,16 +395,20 @@ func sortExperimentsByLastUsed(exps map[string]*config.Experiment) []*config.Exp
 	for _, exp := range exps {
 		result = append(result, exp)
 	}
+	sortByLastUsed(result, func(exp *config.Experiment) (time.Time, string) {
+		return exp.LastUsed, exp.Name
+	})
+	return result
+}
 
-	// Bubble sort by last used (descending)
-	for i := 0; i < len(result)-1; i++ {
-		for j := i + 1; j < len(result); j++ {
-			if result[j].LastUsed.After(result[i].LastUsed) {
-				result[i], result[j] = result[j], result[i]
-			}
-		}
+func sortProjectsByLastUsed(projects map[string]*config.Project) []*config.Project {
+	result := make([]*config.Project, 0, len(projects))
+	for _, proj := range projects {
+		result = append(result, proj)
 	}
-
+	sortByLastUsed(result, func(proj *config.Project) (time.Time, string) {
+		return proj.LastUsed, proj.Name
+	})
 	return result
 }
 
@@ -311,15 +417,8 @@ func sortScratchesByLastUsed(scratches map[string]*config.Scratch) []*config.Scr
 	for _, s := range scratches {
 		result = append(result, s)
 	}
-
-	// Bubble sort by last used (descending)
-	for i := 0; i < len(result)-1; i++ {
-		for j := i + 1; j < len(result); j++ {
-			if result[j].LastUsed.After(result[i].LastUsed) {
-				result[i], result[j] = result[j], result[i]
-			}
-		}
-	}
-
+	sortByLastUsed(result, func(s *config.Scratch) (time.Time, string) {
+		return s.LastUsed, s.Name
+	})
 	return result
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+)
+
+// executeArgs runs the root command with args and returns its exit code
+func executeArgs(t *testing.T, args ...string) int {
+	t.Helper()
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	return Execute()
+}
+
+func TestExecuteReturnsAgentExitCode(t *testing.T) {
+	cfg := setupTestEnv(t)
+	fake := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(fake, []byte("#!/bin/sh\nexit 2\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Agent = fake
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	setFlag(t, &scratchNoEditorFlag, false)
+	setFlag(t, &scratchNoAgentFlag, false)
+
+	if code := executeArgs(t, "scratch", "agent-fails", "--no-editor"); code != 2 {
+		t.Errorf("agent exited 2, clade exited %d", code)
+	}
+	if code := executeArgs(t, "scratch", "bad/name", "--no-editor"); code != 1 {
+		t.Errorf("invalid name: exit code %d, want 1", code)
+	}
+	if code := executeArgs(t, "scratch", "no-agent", "--no-editor", "--no-agent"); code != 0 {
+		t.Errorf("success: exit code %d, want 0", code)
+	}
+}
+
+func TestVerboseLogsGitCommandsToStderr(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &rootVerboseFlag, false)
+	t.Cleanup(func() { git.SetVerbose(false) })
+	repo := newTestRepo(t, "api")
+	if code := executeArgs(t, "exp", "spike", "-r", repo, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+		t.Fatalf("exp exited %d", code)
+	}
+	expPath := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(repo, "spike"))
+	t.Chdir(expPath)
+
+	var stdout string
+	stderr := captureStderr(t, func() {
+		stdout = captureStdout(t, func() {
+			if code := executeArgs(t, "--color", "never", "status", "-v"); code != 0 {
+				t.Errorf("status -v exited %d", code)
+			}
+		})
+	})
+	if !strings.Contains(stderr, "+ git status --porcelain") || !strings.Contains(stderr, "(in "+expPath+")") {
+		t.Errorf("stderr doesn't log the git commands:\n%s", stderr)
+	}
+	if !strings.Contains(stderr, "  git status took ") {
+		t.Errorf("stderr doesn't log how long git took:\n%s", stderr)
+	}
+	if strings.Contains(stdout, "+ git ") {
+		t.Errorf("git log leaked into stdout:\n%s", stdout)
+	}
+
+	setFlag(t, &rootVerboseFlag, false)
+	stderr = captureStderr(t, func() {
+		captureStdout(t, func() { executeArgs(t, "--color", "never", "status") })
+	})
+	if strings.Contains(stderr, "+ git ") {
+		t.Errorf("git commands logged without -v:\n%s", stderr)
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runParallelFlag        bool
+	runContinueOnErrorFlag bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <project> -- <command> [args...]",
+	Short: "Run a command in every repo of a project",
+	Long: `Run the same command in each repo worktree of a project.
+
+By default repos run one after another and clade stops at the first failure.
+Use --continue-on-error to keep going. With --parallel every repo runs at
+once and each output line is prefixed with the repo name.
+
+Examples:
+  clade run my-project -- git status --short
+  clade run my-project --continue-on-error -- pnpm install
+  clade run my-project --parallel -- make test`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              runRun,
+	ValidArgsFunction: completeProjectNames,
+	SilenceUsage:      true,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().BoolVarP(&runParallelFlag, "parallel", "p", false, "Run in all repos concurrently")
+	runCmd.Flags().BoolVar(&runContinueOnErrorFlag, "continue-on-error", false, "Keep going after a repo fails")
+}
+
+// repoRunResult is the outcome of running the command in one repo
+type repoRunResult struct {
+	Repo string
+	Err  error
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 {
+		return fmt.Errorf("usage: clade run <project> -- <command> [args...]")
+	}
+	name, command := args[0], args[1:]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	proj, ok := state.Projects[name]
+	if !ok {
+		return fmt.Errorf("project '%s' not found", name)
+	}
+	if len(proj.Repos) == 0 {
+		return fmt.Errorf("project '%s' has no repos", name)
+	}
+
+	var results []repoRunResult
+	if runParallelFlag {
+		results = runProjectParallel(proj, command, runContinueOnErrorFlag)
+	} else {
+		results = runProjectSequential(proj, command, runContinueOnErrorFlag)
+	}
+
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Repo)
+		}
+	}
+
+	fmt.Println()
+	if len(failed) == 0 {
+		ui.Success("Command succeeded in %d repo(s)", len(results))
+		return nil
+	}
+
+	ui.Error("Command failed in %d of %d repo(s):", len(failed), len(proj.Repos))
+	for _, r := range results {
+		if r.Err != nil {
+			ui.Detail("%s: %v", r.Repo, r.Err)
+		}
+	}
+	if skipped := len(proj.Repos) - len(results); skipped > 0 {
+		ui.Detail("%d repo(s) skipped after the first failure", skipped)
+	}
+	return fmt.Errorf("command failed in: %s", strings.Join(failed, ", "))
+}
+
+// runProjectSequential runs the command in each repo in order, attached to
+// the terminal
+func runProjectSequential(proj *config.Project, command []string, continueOnError bool) []repoRunResult {
+	var results []repoRunResult
+	for _, repo := range proj.Repos {
+		ui.Header("%s", repo.Name)
+		err := runInDir(filepath.Join(proj.Path, repo.Name), command)
+		results = append(results, repoRunResult{Repo: repo.Name, Err: err})
+		if err != nil && !continueOnError {
+			break
+		}
+	}
+	return results
+}
+
+// runProjectParallel runs the command in all repos at once, labeling each
+// line of output. Without continueOnError, the first failure cancels the rest.
+func runProjectParallel(proj *config.Project, command []string, continueOnError bool) []repoRunResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	width := 0
+	for _, repo := range proj.Repos {
+		if len(repo.Name) > width {
+			width = len(repo.Name)
+		}
+	}
+
+	var outMu sync.Mutex
+	results := make([]repoRunResult, len(proj.Repos))
+	var wg sync.WaitGroup
+	for i, repo := range proj.Repos {
+		wg.Add(1)
+		go func(i int, repoName string) {
+			defer wg.Done()
+
+			prefix := ui.Cyan(fmt.Sprintf("[%-*s]", width, repoName)) + " "
+			stdout := &prefixWriter{w: os.Stdout, prefix: prefix, mu: &outMu}
+			stderr := &prefixWriter{w: os.Stderr, prefix: prefix, mu: &outMu}
+
+			workdir := filepath.Join(proj.Path, repoName)
+			var err error
+			if _, statErr := os.Stat(workdir); os.IsNotExist(statErr) {
+				err = fmt.Errorf("path no longer exists: %s", workdir)
+			} else {
+				c := exec.CommandContext(ctx, command[0], command[1:]...)
+				c.Dir = workdir
+				c.Stdout = stdout
+				c.Stderr = stderr
+				err = c.Run()
+				if ctx.Err() != nil && err != nil {
+					err = errors.New("cancelled after another repo failed")
+				}
+			}
+			stdout.Flush()
+			stderr.Flush()
+
+			results[i] = repoRunResult{Repo: repoName, Err: err}
+			if err != nil && !continueOnError {
+				cancel()
+			}
+		}(i, repo.Name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// prefixWriter writes complete lines to w with a label in front, so output
+// from concurrent commands stays readable
+type prefixWriter struct {
+	w      io.Writer
+	prefix string
+	mu     *sync.Mutex
+	buf    bytes.Buffer
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf.Write(data)
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// Incomplete line - keep it for the next write
+			p.buf.Write(line)
+			break
+		}
+		p.mu.Lock()
+		fmt.Fprintf(p.w, "%s%s", p.prefix, line)
+		p.mu.Unlock()
+	}
+	return len(data), nil
+}
+
+// Flush writes any trailing output that didn't end in a newline
+func (p *prefixWriter) Flush() {
+	if p.buf.Len() == 0 {
+		return
+	}
+	p.mu.Lock()
+	fmt.Fprintf(p.w, "%s%s\n", p.prefix, p.buf.String())
+	p.mu.Unlock()
+	p.buf.Reset()
+}
+
+// completeProjectNames provides shell completion for project names
+func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, proj := range state.Projects {
+		names = append(names, proj.Name)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -6,12 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/daniil-lyalko/clade/internal/config"
 	"github.com/daniil-lyalko/clade/internal/ui"
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +17,9 @@ var (
 	scratchEditorFlag   string
 	scratchNoAgentFlag  bool
 	scratchNoEditorFlag bool
+	scratchDryRunFlag   bool
+	scratchJSONFlag     bool
+	scratchRepoFlag     string
 )
 
 var scratchCmd = &cobra.Command{
@@ -31,12 +32,17 @@ Unlike experiments, scratch folders:
   - Are for temporary document analysis, file sharing, etc.
   - Still get .claude/ config for hooks and context
 
+With --repo, the scratch is linked to a source repo: inject-context then
+includes that repo's git status and recent commits, for analysis about it.
+
 Examples:
   clade scratch doc-analysis       # Quick scratch folder
   clade scratch PROJ-1234          # Ticket investigation (no code)
   clade scratch meeting-notes      # Temporary workspace
   clade scratch foo -o cursor      # Open Cursor IDE
-  clade scratch foo --no-agent     # Skip launching Claude`,
+  clade scratch foo --no-agent     # Skip launching Claude
+  clade scratch foo --dry-run      # Show what would be created
+  clade scratch perf-notes -r api  # Linked to the api repo for context`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runScratch,
 }
@@ -47,6 +53,9 @@ func init() {
 	scratchCmd.Flags().StringVarP(&scratchEditorFlag, "editor", "e", "", "Alias for --open")
 	scratchCmd.Flags().BoolVar(&scratchNoAgentFlag, "no-agent", false, "Skip launching the AI agent")
 	scratchCmd.Flags().BoolVar(&scratchNoEditorFlag, "no-editor", false, "Skip opening the editor")
+	scratchCmd.Flags().BoolVar(&scratchDryRunFlag, "dry-run", false, "Show what would be created without creating anything")
+	scratchCmd.Flags().BoolVar(&scratchJSONFlag, "json", false, "Print the dry-run plan as JSON")
+	scratchCmd.Flags().StringVarP(&scratchRepoFlag, "repo", "r", "", "Link a repository (path or registered name) whose git state is included in context")
 }
 
 func runScratch(cmd *cobra.Command, args []string) error {
@@ -60,10 +69,7 @@ func runScratch(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		scratchName = args[0]
 	} else {
-		prompt := promptui.Prompt{
-			Label: "Scratch folder name",
-		}
-		scratchName, err = prompt.Run()
+		scratchName, err = ui.Input("Scratch folder name", "")
 		if err != nil {
 			return err
 		}
@@ -83,24 +89,60 @@ func runScratch(cmd *cobra.Command, args []string) error {
 	}
 
 	if existing := state.GetScratch(scratchName); existing != nil {
+		if scratchDryRunFlag {
+			return printCreatePlan(&createPlan{
+				Type:         "scratch",
+				Name:         scratchName,
+				Repo:         existing.LinkedRepo,
+				Path:         existing.Path,
+				Ticket:       existing.Ticket,
+				ClaudeConfig: planClaudeConfig(cfg, "", false),
+				Errors:       alreadyTracked("scratch", scratchName, existing.Path),
+			}, scratchJSONFlag)
+		}
 		ui.Warn("Scratch '%s' already exists", scratchName)
 		ui.KeyValue("Path", existing.Path)
 
-		prompt := promptui.Prompt{
-			Label:     "Resume existing scratch",
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
-		if err == nil {
+		if ui.Confirm("Resume existing scratch", false) {
 			// User wants to resume
 			return launchSession(cfg, existing.Path, scratchEditorFlag, scratchNoAgentFlag, scratchNoEditorFlag)
 		}
 		return nil
 	}
 
+	if err := checkNameAvailable(state, scratchName, "scratch"); err != nil {
+		return err
+	}
+
+	var linkedRepo string
+	if scratchRepoFlag != "" {
+		linkedRepo, err = resolveRepo(cfg, scratchRepoFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if scratchDryRunFlag {
+		plan := &createPlan{
+			Type:         "scratch",
+			Name:         scratchName,
+			Repo:         linkedRepo,
+			Path:         scratchPath,
+			Ticket:       extractTicket(scratchName),
+			ClaudeConfig: planClaudeConfig(cfg, "", false),
+		}
+		if _, err := os.Stat(scratchPath); err == nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("path already exists: %s", scratchPath))
+		}
+		return printCreatePlan(plan, scratchJSONFlag)
+	}
+
 	// Create scratch directory
 	ui.Header("Creating scratch: %s", scratchName)
 	ui.KeyValue("Path", scratchPath)
+	if linkedRepo != "" {
+		ui.KeyValue("Linked repo", linkedRepo)
+	}
 
 	// Ensure scratch directory exists
 	if err := os.MkdirAll(scratchPath, 0755); err != nil {
@@ -114,27 +156,34 @@ func runScratch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create .clade.json metadata
-	ticket := extractTicketFromName(scratchName)
+	ticket := extractTicket(scratchName)
 	cladeMetadata := map[string]interface{}{
 		"type":    "scratch",
 		"name":    scratchName,
 		"ticket":  ticket,
 		"created": time.Now().Format(time.RFC3339),
 	}
+	if linkedRepo != "" {
+		cladeMetadata["linked_repo"] = linkedRepo
+	}
 	if err := writeScratchJSON(filepath.Join(scratchPath, ".clade.json"), cladeMetadata); err != nil {
 		ui.Warn("Failed to write .clade.json: %v", err)
 	}
 
 	// Update state
 	scratch := &config.Scratch{
-		Name:     scratchName,
-		Path:     scratchPath,
-		Ticket:   ticket,
-		Created:  time.Now(),
-		LastUsed: time.Now(),
-	}
-	state.AddScratch(scratch)
-	if err := state.Save(cfg); err != nil {
+		Name:       scratchName,
+		Path:       scratchPath,
+		Ticket:     ticket,
+		LinkedRepo: linkedRepo,
+		Created:    time.Now(),
+		LastUsed:   time.Now(),
+	}
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		s.AddScratch(scratch)
+		return nil
+	})
+	if err != nil {
 		ui.Warn("Failed to save state: %v", err)
 	}
 
@@ -152,17 +201,6 @@ func isValidScratchName(name string) bool {
 	return matched
 }
 
-// extractTicketFromName extracts a JIRA-style ticket ID from the scratch name
-func extractTicketFromName(name string) string {
-	// Match patterns like PROJ-1234, ABC-123, etc.
-	re := regexp.MustCompile(`^([A-Z]+-\d+)`)
-	matches := re.FindStringSubmatch(strings.ToUpper(name))
-	if len(matches) > 1 {
-		return matches[1]
-	}
-	return ""
-}
-
 func writeScratchJSON(path string, data interface{}) error {
 	file, err := os.Create(path)
 	if err != nil {
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init [bash|zsh|fish]",
+	Short: "Print shell integration that adds a cdo function",
+	Long: `Print a cdo function that jumps to an experiment, project, or scratch.
+
+cdo runs "clade open" and only changes directory when it succeeds, so
+cancelling the picker leaves your shell where it was. Any arguments are
+passed through (e.g. "cdo try-redis" or "cdo -").
+
+The shell defaults to the basename of $SHELL.
+
+Add one of these to your shell rc file:
+  eval "$(clade shell-init bash)"     # ~/.bashrc
+  eval "$(clade shell-init zsh)"      # ~/.zshrc
+  clade shell-init fish | source      # ~/.config/fish/config.fish`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE:      runShellInit,
+}
+
+func init() {
+	rootCmd.AddCommand(shellInitCmd)
+}
+
+const posixShellInit = `cdo() {
+  local dir
+  dir="$(command clade open "$@")" || return $?
+  [ -n "$dir" ] || return 1
+  cd -- "$dir"
+}
+`
+
+const fishShellInit = `function cdo --description 'cd to a clade experiment, project, or scratch'
+    set -l dir (command clade open $argv)
+    or return $status
+    test -n "$dir"
+    or return 1
+    cd -- $dir
+end
+`
+
+func runShellInit(cmd *cobra.Command, args []string) error {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if len(args) > 0 {
+		shell = args[0]
+	}
+
+	switch shell {
+	case "bash", "zsh":
+		fmt.Print(posixShellInit)
+	case "fish":
+		fmt.Print(fishShellInit)
+	default:
+		return fmt.Errorf("unsupported shell '%s' (use bash, zsh, or fish)", shell)
+	}
+	return nil
+}
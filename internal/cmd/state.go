@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateExportPortableFlag  bool
+	stateImportOverwriteFlag bool
+	stateImportRecreateFlag  bool
+)
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Back up or transfer clade config and state",
+	Long:  `Export and import clade's config and state (not the worktrees themselves).`,
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write config and state to a portable bundle",
+	Long: `Write config.json and state.json to a single bundle file.
+
+Use --portable to rewrite paths under your home directory as ~/... so the
+bundle can be imported on a machine with a different home directory.
+
+Examples:
+  clade state export clade-backup.json
+  clade state export clade.json --portable
+  clade state export - > clade.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStateExport,
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge a bundle into the current config and state",
+	Long: `Merge an exported bundle into the current config and state.
+
+Entries that already exist are kept unless --overwrite is given. Settings
+still at their defaults take the bundle's value. Items whose name is taken
+by another type of item are skipped.
+With --recreate, worktrees are recreated for imported experiments and
+projects whose directories are missing but whose branches still exist.
+
+Examples:
+  clade state import clade-backup.json
+  clade state import clade.json --recreate
+  clade state import clade.json --overwrite`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStateImport,
+}
+
+func init() {
+	rootCmd.AddCommand(stateCmd)
+	stateCmd.AddCommand(stateExportCmd)
+	stateCmd.AddCommand(stateImportCmd)
+
+	stateExportCmd.Flags().BoolVar(&stateExportPortableFlag, "portable", false, "Rewrite home directory paths as ~/...")
+	stateImportCmd.Flags().BoolVar(&stateImportOverwriteFlag, "overwrite", false, "Replace existing entries with imported ones")
+	stateImportCmd.Flags().BoolVar(&stateImportRecreateFlag, "recreate", false, "Recreate missing worktrees whose branches exist")
+}
+
+func runStateExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	bundle, err := config.NewBundle(cfg, state, stateExportPortableFlag)
+	if err != nil {
+		return fmt.Errorf("failed to build bundle: %w", err)
+	}
+
+	path := args[0]
+	if err := bundle.Write(path); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	// Keep stdout clean when writing the bundle there
+	if path != "-" {
+		ui.Success("Exported %d experiments, %d projects, %d scratches",
+			len(state.Experiments), len(state.Projects), len(state.Scratches))
+		ui.KeyValue("File", path)
+	}
+
+	return nil
+}
+
+func runStateImport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	bundle, err := config.ReadBundle(args[0])
+	if err != nil {
+		return err
+	}
+
+	ui.Header("Importing %s", args[0])
+	ui.KeyValue("Exported", bundle.Exported.Format("Jan 2, 2006 15:04"))
+	fmt.Println()
+
+	cfgResult := bundle.MergeInto(cfg, stateImportOverwriteFlag)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	var state *config.State
+	var stateResult config.ImportResult
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		stateResult = bundle.MergeStateInto(s, stateImportOverwriteFlag)
+		state = s
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	for _, item := range append(cfgResult.Added, stateResult.Added...) {
+		ui.Success("Imported %s", item)
+	}
+	for _, item := range append(cfgResult.Skipped, stateResult.Skipped...) {
+		ui.Warn("Skipped %s (already exists)", item)
+	}
+	if len(cfgResult.Skipped)+len(stateResult.Skipped) > 0 {
+		ui.Detail("Use --overwrite to replace existing entries")
+	}
+	for _, item := range append(cfgResult.Rejected, stateResult.Rejected...) {
+		ui.Warn("Skipped %s", item)
+	}
+
+	if stateImportRecreateFlag {
+		fmt.Println()
+		recreateImportedWorktrees(cfg, bundle, state)
+	}
+
+	return nil
+}
+
+// recreateImportedWorktrees recreates missing worktrees for entries that were
+// actually imported (not skipped as conflicts)
+func recreateImportedWorktrees(cfg *config.Config, bundle *config.Bundle, state *config.State) {
+	for key, exp := range bundle.State.Experiments {
+		if state.Experiments[key] != exp {
+			continue
+		}
+		if _, err := os.Stat(exp.Path); err == nil {
+			continue
+		}
+		ui.Info("Recreating %s...", exp.Name)
+		if err := recreateWorktree(exp.Repo, cfg.Remote, exp.Path, exp.Branch); err != nil {
+			ui.Warn("Could not recreate %s: %v", exp.Name, err)
+			continue
+		}
+		ui.Success("Recreated %s", exp.Name)
+	}
+
+	for key, proj := range bundle.State.Projects {
+		if state.Projects[key] != proj {
+			continue
+		}
+		for _, repo := range proj.Repos {
+			worktreePath := filepath.Join(proj.Path, repo.Name)
+			if _, err := os.Stat(worktreePath); err == nil {
+				continue
+			}
+			ui.Info("Recreating %s/%s...", proj.Name, repo.Name)
+			if err := recreateWorktree(repo.Source, cfg.Remote, worktreePath, proj.RepoBranch(repo)); err != nil {
+				ui.Warn("Could not recreate %s/%s: %v", proj.Name, repo.Name, err)
+				continue
+			}
+			ui.Success("Recreated %s/%s", proj.Name, repo.Name)
+		}
+	}
+}
+
+// recreateWorktree creates a worktree for an existing branch, local or remote
+func recreateWorktree(repoPath, remote, worktreePath, branch string) error {
+	if !git.IsSourceRepo(repoPath) {
+		return fmt.Errorf("source repo not found: %s", repoPath)
+	}
+
+	git.Fetch(repoPath, remote) // Ignore error - might be offline
+	info := git.CheckBranch(repoPath, remote, branch)
+
+	if err := os.MkdirAll(filepath.Dir(worktreePath), 0755); err != nil {
+		return err
+	}
+
+	switch info.Status {
+	case git.BranchLocalOnly, git.BranchBoth:
+		return git.CreateWorktreeFromBranch(repoPath, worktreePath, branch)
+	case git.BranchRemoteOnly:
+		return git.CreateWorktreeTrackRemote(repoPath, remote, worktreePath, branch)
+	default:
+		return fmt.Errorf("branch '%s' not found", branch)
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/files"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var statsJSONFlag bool
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize experiments, projects, scratches, and disk usage",
+	Long: `Show a one-glance overview of everything clade is tracking.
+
+Counts each type (and how many are stale, see stale_after_days), the total
+size of base_dir, and a breakdown by source repo.
+
+Examples:
+  clade stats
+  clade stats --json`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().BoolVar(&statsJSONFlag, "json", false, "Output as JSON")
+}
+
+// statsOutput is the aggregated overview
+type statsOutput struct {
+	Experiments statsCount  `json:"experiments"`
+	Projects    statsCount  `json:"projects"`
+	Scratches   statsCount  `json:"scratches"`
+	BaseDir     string      `json:"base_dir"`
+	SizeBytes   int64       `json:"size_bytes"`
+	Repos       []repoStats `json:"repos"`
+}
+
+// statsCount is the number of items of one type
+type statsCount struct {
+	Total int `json:"total"`
+	Stale int `json:"stale"`
+}
+
+func (c *statsCount) add(lastUsed time.Time, staleAfter time.Duration) {
+	c.Total++
+	if time.Since(lastUsed) > staleAfter {
+		c.Stale++
+	}
+}
+
+// repoStats is how many experiments and projects use a source repo
+type repoStats struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Experiments int    `json:"experiments"`
+	Projects    int    `json:"projects"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	stats := gatherStats(cfg, state)
+	if statsJSONFlag {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printStats(stats)
+	return nil
+}
+
+// gatherStats aggregates counts from state and measures base_dir
+func gatherStats(cfg *config.Config, state *config.State) *statsOutput {
+	staleAfter := cfg.StaleAfter()
+	stats := &statsOutput{BaseDir: cfg.GetBaseDir()}
+
+	repos := make(map[string]*repoStats)
+	repoFor := func(path string) *repoStats {
+		path = filepath.Clean(path)
+		if repos[path] == nil {
+			repos[path] = &repoStats{Name: filepath.Base(path), Path: path}
+		}
+		return repos[path]
+	}
+
+	for _, exp := range state.Experiments {
+		stats.Experiments.add(exp.LastUsed, staleAfter)
+		repoFor(exp.Repo).Experiments++
+	}
+	for _, proj := range state.Projects {
+		stats.Projects.add(proj.LastUsed, staleAfter)
+		seen := make(map[*repoStats]bool)
+		for _, repo := range proj.Repos {
+			if r := repoFor(repo.Source); !seen[r] {
+				seen[r] = true
+				r.Projects++
+			}
+		}
+	}
+	for _, scratch := range state.Scratches {
+		stats.Scratches.add(scratch.LastUsed, staleAfter)
+	}
+
+	stats.SizeBytes, _ = files.DirSize(stats.BaseDir)
+
+	stats.Repos = []repoStats{}
+	for _, repo := range repos {
+		stats.Repos = append(stats.Repos, *repo)
+	}
+	sort.Slice(stats.Repos, func(i, j int) bool {
+		a, b := stats.Repos[i], stats.Repos[j]
+		if a.Experiments+a.Projects != b.Experiments+b.Projects {
+			return a.Experiments+a.Projects > b.Experiments+b.Projects
+		}
+		return a.Path < b.Path
+	})
+
+	return stats
+}
+
+func printStats(stats *statsOutput) {
+	ui.Header("Clade stats")
+	ui.KeyValue("Experiments", describeCount(stats.Experiments))
+	ui.KeyValue("Projects", describeCount(stats.Projects))
+	ui.KeyValue("Scratches", describeCount(stats.Scratches))
+	ui.KeyValue("Disk used", fmt.Sprintf("%s in %s", formatSize(stats.SizeBytes), stats.BaseDir))
+
+	if len(stats.Repos) == 0 {
+		return
+	}
+
+	ui.Header("By repo:")
+	width := 0
+	for _, repo := range stats.Repos {
+		if len(repo.Name) > width {
+			width = len(repo.Name)
+		}
+	}
+	for _, repo := range stats.Repos {
+		fmt.Printf("  %s  %d experiment(s), %d project(s) %s\n",
+			ui.Cyan(fmt.Sprintf("%-*s", width, repo.Name)),
+			repo.Experiments,
+			repo.Projects,
+			ui.Dim(repo.Path),
+		)
+	}
+}
+
+// describeCount formats a count with its stale share
+func describeCount(c statsCount) string {
+	if c.Stale == 0 {
+		return fmt.Sprintf("%d", c.Total)
+	}
+	return fmt.Sprintf("%d (%d stale)", c.Total, c.Stale)
+}
@@ -19,10 +19,10 @@ var statusCmd = &cobra.Command{
 Shows:
   - Experiment/project metadata
   - Context files (CLAUDE.md, DROPBAG.md, TICKET.md)
-  - Git status summary
+  - Git status summary and stash count
   - Recent commits
 
-Works in any directory:
+Works in any directory, including subdirectories of a worktree:
   - In a clade experiment: Full context info
   - In a regular git repo: Basic info + suggestion to init
   - Not in git repo: Clear message`,
@@ -105,6 +105,7 @@ func printCladeStatus(repoRoot string, metadata *context.CladeMetadata) {
 	fmt.Println()
 	ui.Header("Git Status:")
 	printGitStatus(repoRoot)
+	printStashCount(repoRoot)
 
 	// Recent commits
 	fmt.Println()
@@ -145,6 +146,7 @@ func printBasicStatus(repoRoot string) {
 	fmt.Println()
 	ui.Header("Git Status:")
 	printGitStatus(repoRoot)
+	printStashCount(repoRoot)
 
 	// Suggestion
 	fmt.Println()
@@ -183,6 +185,12 @@ func printGitStatus(repoRoot string) {
 	if len(status.ModifiedFiles) > 0 {
 		parts = append(parts, fmt.Sprintf("%d modified", len(status.ModifiedFiles)))
 	}
+	if len(status.DeletedFiles) > 0 {
+		parts = append(parts, fmt.Sprintf("%d deleted", len(status.DeletedFiles)))
+	}
+	if len(status.RenamedFiles) > 0 {
+		parts = append(parts, fmt.Sprintf("%d renamed", len(status.RenamedFiles)))
+	}
 	if len(status.UntrackedFiles) > 0 {
 		parts = append(parts, fmt.Sprintf("%d untracked", len(status.UntrackedFiles)))
 	}
@@ -207,6 +215,20 @@ func printGitStatus(repoRoot string) {
 		fmt.Printf("    %s %s\n", ui.Yellow("M"), f)
 		shown++
 	}
+	for _, f := range status.DeletedFiles {
+		if shown >= maxShow {
+			break
+		}
+		fmt.Printf("    %s %s\n", ui.Red("D"), f)
+		shown++
+	}
+	for _, r := range status.RenamedFiles {
+		if shown >= maxShow {
+			break
+		}
+		fmt.Printf("    %s %s -> %s\n", ui.Cyan("R"), r.From, r.To)
+		shown++
+	}
 	for _, f := range status.UntrackedFiles {
 		if shown >= maxShow {
 			break
@@ -219,3 +241,12 @@ func printGitStatus(repoRoot string) {
 		fmt.Printf("    %s\n", ui.Dim(fmt.Sprintf("... and %d more", status.UncommittedCount-maxShow)))
 	}
 }
+
+// printStashCount notes stashed work, which is easy to forget about
+func printStashCount(repoRoot string) {
+	stashes, err := git.GetStashList(repoRoot)
+	if err != nil || len(stashes) == 0 {
+		return
+	}
+	fmt.Printf("  %s %s\n", ui.Yellow(fmt.Sprintf("%d stash(es)", len(stashes))), ui.Dim("(git stash list)"))
+}
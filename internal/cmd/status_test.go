@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestStatusAndInjectFromNestedSubdirectory(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &rootColorFlag, "auto")
+	repo := newTestRepo(t, "api")
+	if code := executeArgs(t, "exp", "PROJ-12-login", "-r", repo, "-b", "exp/login", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+		t.Fatalf("exp exited %d", code)
+	}
+	expPath := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(repo, "PROJ-12-login"))
+	writeTestFile(t, filepath.Join(expPath, "TICKET.md"), "# PROJ-12\n")
+	writeTestFile(t, filepath.Join(expPath, "DROPBAG.md"), "# Notes\n\nleft off in auth\n")
+	nested := filepath.Join(expPath, "src", "auth", "deep")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+
+	out := captureStdout(t, func() {
+		if code := executeArgs(t, "--color", "never", "status"); code != 0 {
+			t.Errorf("status exited %d", code)
+		}
+	})
+	for _, want := range []string{"Experiment: PROJ-12-login", "✓ DROPBAG.md", "✓ TICKET.md", "Branch: exp/login"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status output is missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if code := executeArgs(t, "inject-context"); code != 0 {
+			t.Errorf("inject-context exited %d", code)
+		}
+	})
+	if !strings.Contains(out, "See TICKET.md for details.") {
+		t.Errorf("inject-context didn't find TICKET.md at the worktree root:\n%s", out)
+	}
+	if !strings.Contains(out, "left off in auth") {
+		t.Errorf("inject-context didn't include the root DROPBAG.md:\n%s", out)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/git"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var syncMergeFlag bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <name>",
+	Short: "Update an experiment or project with the latest default branch",
+	Long: `Fetch the remote and rebase the worktree's branch onto the default branch.
+
+Use --merge to merge the default branch in instead of rebasing. For projects,
+every repo is synced. On conflicts the rebase/merge is left in progress so you
+can resolve it in the worktree.
+
+Examples:
+  clade sync try-redis           # Rebase onto origin/main
+  clade sync try-redis --merge   # Merge origin/main into the branch
+  clade sync my-project          # Sync every repo in a project`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runSync,
+	ValidArgsFunction: completeResumableNames,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVar(&syncMergeFlag, "merge", false, "Merge the default branch instead of rebasing")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment or project", name)
+	}
+
+	switch {
+	case item.Experiment != nil:
+		exp := item.Experiment
+		ui.Header("Syncing: %s", exp.Name)
+		return syncWorktree(cfg, exp.Repo, exp.Path, exp.Name)
+
+	case item.Project != nil:
+		proj := item.Project
+		ui.Header("Syncing project: %s", proj.Name)
+		failed := 0
+		for _, repo := range proj.Repos {
+			fmt.Println()
+			if err := syncWorktree(cfg, repo.Source, filepath.Join(proj.Path, repo.Name), repo.Name); err != nil {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d repos did not sync", failed, len(proj.Repos))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("'%s' is a scratch - it has no branch to sync", name)
+}
+
+// syncWorktree fetches and rebases/merges the remote default branch into a worktree
+func syncWorktree(cfg *config.Config, repoPath, worktreePath, label string) error {
+	if hasChanges, _ := git.HasUncommittedChanges(worktreePath); hasChanges {
+		ui.Warn("%s: uncommitted changes, skipping (commit or stash first)", label)
+		return fmt.Errorf("uncommitted changes in %s", worktreePath)
+	}
+
+	ui.Info("%s: fetching from %s...", label, cfg.Remote)
+	if err := git.Fetch(repoPath, cfg.Remote); err != nil {
+		ui.Warn("%s: fetch %s, using last fetched state", label, describeFetchError(err))
+	}
+
+	ref := cfg.Remote + "/" + git.GetDefaultBranch(repoPath, cfg.Remote)
+
+	if syncMergeFlag {
+		ui.Info("%s: merging %s...", label, ref)
+		err := git.MergeBranch(worktreePath, ref, false)
+		return reportSyncResult(label, worktreePath, ref, err)
+	}
+
+	ui.Info("%s: rebasing onto %s...", label, ref)
+	err := git.RebaseOnto(worktreePath, ref)
+	return reportSyncResult(label, worktreePath, ref, err)
+}
+
+// describeFetchError explains a failed fetch for warnings
+func describeFetchError(err error) string {
+	if git.IsTimeout(err) {
+		return "timed out (treating as offline)"
+	}
+	return "failed (offline?)"
+}
+
+// reportSyncResult prints the outcome of a sync, separating conflicts from
+// other failures
+func reportSyncResult(label, worktreePath, ref string, err error) error {
+	if err == nil {
+		ui.Success("%s: up to date with %s", label, ref)
+		return nil
+	}
+
+	var conflict *git.ConflictError
+	if !errors.As(err, &conflict) {
+		ui.Error("%s: %v", label, err)
+		return err
+	}
+
+	ui.Error("%s: %s conflicts:", label, conflict.Op)
+	for _, file := range conflict.Files {
+		ui.Detail("%s", file)
+	}
+	ui.Detail("Resolve in: %s", worktreePath)
+	if conflict.Op == "rebase" {
+		ui.Detail("Then run: git add <files> && git rebase --continue")
+		ui.Detail("Or abort with: git rebase --abort")
+	} else {
+		ui.Detail("Then run: git commit")
+		ui.Detail("Or abort with: git merge --abort")
+	}
+	return err
+}
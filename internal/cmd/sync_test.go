@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+)
+
+func TestSyncResolvesTheNamedItem(t *testing.T) {
+	cfg := setupTestEnv(t)
+	resetExpFlags(t)
+	setFlag(t, &syncMergeFlag, false)
+	setFlag(t, &scratchNoAgentFlag, false)
+	setFlag(t, &scratchNoEditorFlag, false)
+	t.Setenv("CLADE_YES", "1")
+	origin := newTestRepo(t, "origin")
+	repo := filepath.Join(filepath.Dir(origin), "api")
+	runTestGit(t, origin, "clone", "-q", origin, repo)
+	other := newTestRepo(t, "api")
+	for _, r := range []string{repo, other} {
+		if code := executeArgs(t, "exp", "spike", "-r", r, "-b", "exp/spike", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+			t.Fatalf("exp exited %d", code)
+		}
+	}
+	if code := executeArgs(t, "scratch", "notes", "--no-agent", "--no-editor"); code != 0 {
+		t.Fatalf("scratch exited %d", code)
+	}
+	writeTestFile(t, filepath.Join(origin, "upstream.txt"), "new\n")
+	runTestGit(t, origin, "add", "-A")
+	runTestGit(t, origin, "commit", "-q", "-m", "upstream work")
+	upstream := runTestGit(t, origin, "rev-parse", "HEAD")
+
+	// Two experiments are named spike: picking one needs a prompt
+	if code := executeArgs(t, "sync", "spike"); code == 0 {
+		t.Error("sync picked one of two experiments named spike")
+	}
+	if code := executeArgs(t, "sync", "notes"); code == 0 {
+		t.Error("sync accepted a scratch")
+	}
+
+	if code := executeArgs(t, "exp", "solo", "-r", repo, "-b", "exp/solo", "--no-agent", "--no-editor", "--no-setup"); code != 0 {
+		t.Fatalf("exp exited %d", code)
+	}
+	solo := filepath.Join(cfg.ExperimentsDir(), config.ExperimentKey(repo, "solo"))
+	// Commit .clade.json so the worktree counts as clean
+	runTestGit(t, solo, "add", "-A")
+	runTestGit(t, solo, "commit", "-q", "-m", "metadata")
+	if code := executeArgs(t, "sync", "solo"); code != 0 {
+		t.Fatalf("sync solo exited %d", code)
+	}
+	runTestGit(t, solo, "merge-base", "--is-ancestor", upstream, "HEAD")
+}
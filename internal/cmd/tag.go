@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/config"
+	"github.com/daniil-lyalko/clade/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var tagRemoveFlag bool
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <name> [tag...]",
+	Short: "Add or remove tags on an experiment, project, or scratch",
+	Long: `Label items to group them, e.g. spike, reviewed, or blocked.
+
+With no tags, prints the item's current tags. Filter by tag with
+"clade list --tag <tag>".
+
+Examples:
+  clade tag try-redis spike
+  clade tag try-redis reviewed blocked
+  clade tag try-redis blocked --remove
+  clade tag try-redis`,
+	Args:              cobra.MinimumNArgs(1),
+	RunE:              runTag,
+	ValidArgsFunction: completeTagArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.Flags().BoolVarP(&tagRemoveFlag, "remove", "d", false, "Remove the given tags instead of adding them")
+}
+
+func runTag(cmd *cobra.Command, args []string) error {
+	name, tags := args[0], args[1:]
+	for _, tag := range tags {
+		if !isValidTag(tag) {
+			return fmt.Errorf("invalid tag '%s': tags can't be empty or contain spaces or commas", tag)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	item, err := resolveItem(state, name)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("'%s' not found as experiment, project, or scratch", name)
+	}
+
+	if len(tags) == 0 {
+		if len(item.Tags) == 0 {
+			ui.Info("'%s' has no tags", item.Name)
+			return nil
+		}
+		fmt.Println(strings.Join(item.Tags, " "))
+		return nil
+	}
+
+	var updated []string
+	err = config.UpdateState(cfg, func(s *config.State) error {
+		tagsPtr := itemTags(s, item)
+		if tagsPtr == nil {
+			return fmt.Errorf("'%s' is no longer tracked", item.Name)
+		}
+		if tagRemoveFlag {
+			*tagsPtr = slices.DeleteFunc(*tagsPtr, func(t string) bool { return slices.Contains(tags, t) })
+		} else {
+			for _, tag := range tags {
+				if !slices.Contains(*tagsPtr, tag) {
+					*tagsPtr = append(*tagsPtr, tag)
+				}
+			}
+			sort.Strings(*tagsPtr)
+		}
+		updated = *tagsPtr
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	if len(updated) == 0 {
+		ui.Success("'%s' has no tags", item.Name)
+		return nil
+	}
+	ui.Success("Tags for '%s': %s", item.Name, strings.Join(updated, ", "))
+	return nil
+}
+
+// itemTags returns the tags of item as stored in s, or nil if it's gone
+func itemTags(s *config.State, item *resolvedItem) *[]string {
+	switch {
+	case item.Experiment != nil:
+		if exp := s.Experiments[item.Key]; exp != nil {
+			return &exp.Tags
+		}
+	case item.Project != nil:
+		if proj := s.Projects[item.Key]; proj != nil {
+			return &proj.Tags
+		}
+	case item.Scratch != nil:
+		if scratch := s.Scratches[item.Key]; scratch != nil {
+			return &scratch.Tags
+		}
+	}
+	return nil
+}
+
+func isValidTag(tag string) bool {
+	return tag != "" && !strings.ContainsAny(tag, " \t\n,")
+}
+
+// hasAllTags reports whether tags includes every wanted tag
+func hasAllTags(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatTags renders tags for display, e.g. "#spike #blocked"
+func formatTags(tags []string) string {
+	marked := make([]string, len(tags))
+	for i, tag := range tags {
+		marked[i] = "#" + tag
+	}
+	return strings.Join(marked, " ")
+}
+
+// allTags returns every tag in use, sorted
+func allTags(state *config.State) []string {
+	var tags []string
+	for _, item := range recentItems(state) {
+		for _, tag := range item.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// completeTags provides shell completion for tags already in use
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return allTags(state), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTagArgs completes the item name, then tags
+func completeTagArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeResumableNames(cmd, args, toComplete)
+	}
+	return completeTags(cmd, args, toComplete)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/daniil-lyalko/clade/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var versionJSONFlag bool
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the clade version and build details",
+	Long: `Show the version, git commit, build date, and Go version of this binary.
+
+Include this when filing a bug.
+
+Examples:
+  clade version
+  clade version --json
+  clade --version`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&versionJSONFlag, "json", false, "Output as JSON")
+
+	rootCmd.Version = version.Get().String()
+	rootCmd.SetVersionTemplate("clade {{.Version}}\n")
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := version.Get()
+	if versionJSONFlag {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("clade %s\n", info.Version)
+	fmt.Printf("  commit: %s\n", info.Commit)
+	fmt.Printf("  built:  %s\n", info.Date)
+	fmt.Printf("  go:     %s\n", info.GoVersion)
+	return nil
+}
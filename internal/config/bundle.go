@@ -0,0 +1,283 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BundleVersion is the schema version written by export
+const BundleVersion = 1
+
+// stateVersion is the state.json schema version this build understands
+const stateVersion = 1
+
+// Bundle is a portable snapshot of config and state for backup or transfer
+type Bundle struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+	Config   *Config   `json:"config"`
+	State    *State    `json:"state"`
+}
+
+// ImportResult lists what happened to each entry during an import
+type ImportResult struct {
+	Added    []string
+	Skipped  []string // already exist (kept unless overwrite)
+	Rejected []string // can't be imported, with the reason
+}
+
+// NewBundle creates a bundle from the current config and state.
+// When portable is true, paths under the home directory are rewritten to ~/...
+func NewBundle(cfg *Config, state *State, portable bool) (*Bundle, error) {
+	bundle := &Bundle{
+		Version:  BundleVersion,
+		Exported: time.Now(),
+	}
+
+	// Deep copy so rewriting paths never touches the live config/state
+	if err := deepCopy(cfg, &bundle.Config); err != nil {
+		return nil, err
+	}
+	if err := deepCopy(state, &bundle.State); err != nil {
+		return nil, err
+	}
+
+	if portable {
+		bundle.rewritePaths(contractPath)
+	}
+
+	return bundle, nil
+}
+
+// Write saves the bundle to a file, or stdout if path is "-"
+func (b *Bundle) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadBundle reads and validates a bundle file, expanding any ~ paths
+func ReadBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	if bundle.Version == 0 {
+		return nil, fmt.Errorf("invalid bundle: missing version")
+	}
+	if bundle.Version > BundleVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than supported version %d", bundle.Version, BundleVersion)
+	}
+	if bundle.Config == nil || bundle.State == nil {
+		return nil, fmt.Errorf("invalid bundle: missing config or state")
+	}
+	if bundle.State.Version != stateVersion {
+		return nil, fmt.Errorf("unsupported state version %d", bundle.State.Version)
+	}
+
+	// Ensure maps are initialized
+	if bundle.Config.Repos == nil {
+		bundle.Config.Repos = make(map[string]string)
+	}
+	if bundle.Config.RepoSettings == nil {
+		bundle.Config.RepoSettings = make(map[string]RepoSettings)
+	}
+	if bundle.State.Experiments == nil {
+		bundle.State.Experiments = make(map[string]*Experiment)
+	}
+	if bundle.State.Projects == nil {
+		bundle.State.Projects = make(map[string]*Project)
+	}
+	if bundle.State.Scratches == nil {
+		bundle.State.Scratches = make(map[string]*Scratch)
+	}
+
+	bundle.rewritePaths(ExpandPath)
+	bundle.State.migrateExperimentKeys()
+
+	return &bundle, nil
+}
+
+// MergeInto merges the bundle's settings, agents, repos, and repo settings
+// into cfg. Existing entries are kept unless overwrite is set; a setting
+// still at its default counts as unset and takes the bundle's value.
+func (b *Bundle) MergeInto(cfg *Config, overwrite bool) ImportResult {
+	var result ImportResult
+	defaults := DefaultConfig()
+
+	for _, setting := range Settings {
+		value := setting.Get(b.Config)
+		current := setting.Get(cfg)
+		// An unset value (e.g. a key from a newer build) has nothing to import
+		if value == "" || value == current {
+			continue
+		}
+		if current != setting.Get(defaults) && !overwrite {
+			result.Skipped = append(result.Skipped, "setting "+setting.Key)
+			continue
+		}
+		if err := setting.Set(cfg, value); err != nil {
+			result.Rejected = append(result.Rejected, fmt.Sprintf("setting %s: %v", setting.Key, err))
+			continue
+		}
+		result.Added = append(result.Added, "setting "+setting.Key)
+	}
+
+	for name, spec := range b.Config.Agents {
+		if _, exists := cfg.Agents[name]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "agent "+name)
+			continue
+		}
+		if cfg.Agents == nil {
+			cfg.Agents = make(map[string]AgentSpec)
+		}
+		cfg.Agents[name] = spec
+		result.Added = append(result.Added, "agent "+name)
+	}
+
+	for name, path := range b.Config.Repos {
+		if _, exists := cfg.Repos[name]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "repo "+name)
+			continue
+		}
+		cfg.Repos[name] = path
+		result.Added = append(result.Added, "repo "+name)
+	}
+
+	for path, settings := range b.Config.RepoSettings {
+		if _, exists := cfg.RepoSettings[path]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "repo settings for "+path)
+			continue
+		}
+		cfg.RepoSettings[path] = settings
+		result.Added = append(result.Added, "repo settings for "+path)
+	}
+
+	return result
+}
+
+// MergeStateInto merges the bundle's experiments, projects, and scratches
+// into state. Existing entries are kept unless overwrite is set. Entries
+// whose name is taken by another type of item are rejected, as they would
+// be when created.
+func (b *Bundle) MergeStateInto(state *State, overwrite bool) ImportResult {
+	var result ImportResult
+
+	// available reports whether name is free for itemType, rejecting it if not
+	available := func(name, itemType string) bool {
+		if other, taken := state.NameUsedByOtherType(name, itemType); taken {
+			result.Rejected = append(result.Rejected, fmt.Sprintf("%s %s: name is already taken by a tracked %s", itemType, name, other))
+			return false
+		}
+		return true
+	}
+
+	for key, exp := range b.State.Experiments {
+		if _, exists := state.Experiments[key]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "experiment "+exp.Name)
+			continue
+		}
+		if !available(exp.Name, "experiment") {
+			continue
+		}
+		state.Experiments[key] = exp
+		result.Added = append(result.Added, "experiment "+exp.Name)
+	}
+
+	for key, proj := range b.State.Projects {
+		if _, exists := state.Projects[key]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "project "+proj.Name)
+			continue
+		}
+		if !available(proj.Name, "project") {
+			continue
+		}
+		state.Projects[key] = proj
+		result.Added = append(result.Added, "project "+proj.Name)
+	}
+
+	for key, scratch := range b.State.Scratches {
+		if _, exists := state.Scratches[key]; exists && !overwrite {
+			result.Skipped = append(result.Skipped, "scratch "+scratch.Name)
+			continue
+		}
+		if !available(scratch.Name, "scratch") {
+			continue
+		}
+		state.Scratches[key] = scratch
+		result.Added = append(result.Added, "scratch "+scratch.Name)
+	}
+
+	return result
+}
+
+// rewritePaths applies fn to every filesystem path in the bundle
+func (b *Bundle) rewritePaths(fn func(string) string) {
+	b.Config.BaseDir = fn(b.Config.BaseDir)
+	b.Config.LastRepo = fn(b.Config.LastRepo)
+
+	for name, path := range b.Config.Repos {
+		b.Config.Repos[name] = fn(path)
+	}
+
+	settings := make(map[string]RepoSettings, len(b.Config.RepoSettings))
+	for path, s := range b.Config.RepoSettings {
+		settings[fn(path)] = s
+	}
+	b.Config.RepoSettings = settings
+
+	for _, exp := range b.State.Experiments {
+		exp.Repo = fn(exp.Repo)
+		exp.Path = fn(exp.Path)
+	}
+	for _, proj := range b.State.Projects {
+		proj.Path = fn(proj.Path)
+		for i := range proj.Repos {
+			proj.Repos[i].Source = fn(proj.Repos[i].Source)
+		}
+	}
+	for _, scratch := range b.State.Scratches {
+		scratch.Path = fn(scratch.Path)
+	}
+}
+
+// contractPath rewrites a path under the home directory to ~/...
+func contractPath(path string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || path == "" {
+		return path
+	}
+	if path == homeDir {
+		return "~"
+	}
+	if strings.HasPrefix(path, homeDir+string(filepath.Separator)) {
+		return "~" + strings.TrimPrefix(path, homeDir)
+	}
+	return path
+}
+
+func deepCopy(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeIntoImportsSettings(t *testing.T) {
+	exported := DefaultConfig()
+	exported.Agent = "codex"
+	exported.Editor = "nvim"
+	exported.ExpBranchPrefix = "try/"
+	exported.TicketPattern = `gh-(\d+)`
+	exported.ContextSections = []string{"git_status", "dropbag"}
+	exported.GitTimeout = "soon"
+	exported.Agents = map[string]AgentSpec{"aider": {Command: "aider"}}
+	exported.RepoSettings = map[string]RepoSettings{"/src/api": {SetupCommand: "make deps"}}
+	bundle := &Bundle{Config: exported, State: &State{}}
+
+	cfg := DefaultConfig()
+	cfg.Editor = "code" // chosen here, so kept without --overwrite
+	cfg.RepoSettings["/src/api"] = RepoSettings{SetupCommand: "npm ci"}
+
+	result := bundle.MergeInto(cfg, false)
+	if cfg.Agent != "codex" || cfg.ExpBranchPrefix != "try/" || cfg.TicketPattern != `gh-(\d+)` {
+		t.Errorf("settings at their defaults weren't imported: agent %q, prefix %q, ticket %q", cfg.Agent, cfg.ExpBranchPrefix, cfg.TicketPattern)
+	}
+	if !slices.Equal(cfg.ContextSections, []string{"git_status", "dropbag"}) {
+		t.Errorf("context_sections = %q", cfg.ContextSections)
+	}
+	if cfg.Agents["aider"].Command != "aider" {
+		t.Errorf("custom agent wasn't imported: %v", cfg.Agents)
+	}
+	if cfg.Editor != "code" || cfg.RepoSettings["/src/api"].SetupCommand != "npm ci" {
+		t.Errorf("existing entries were replaced: editor %q, repo settings %+v", cfg.Editor, cfg.RepoSettings)
+	}
+	if cfg.GitTimeout != "30s" || len(result.Rejected) != 1 || !strings.HasPrefix(result.Rejected[0], "setting git_timeout: ") {
+		t.Errorf("invalid git_timeout: got %q, rejected %q", cfg.GitTimeout, result.Rejected)
+	}
+	for _, want := range []string{"setting editor", "repo settings for /src/api"} {
+		if !slices.Contains(result.Skipped, want) {
+			t.Errorf("Skipped = %q, missing %q", result.Skipped, want)
+		}
+	}
+
+	result = bundle.MergeInto(cfg, true)
+	if cfg.Editor != "nvim" || cfg.RepoSettings["/src/api"].SetupCommand != "make deps" {
+		t.Errorf("--overwrite kept editor %q, repo settings %+v", cfg.Editor, cfg.RepoSettings)
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("--overwrite skipped %q", result.Skipped)
+	}
+}
+
+func TestMergeStateIntoRejectsNamesTakenByOtherTypes(t *testing.T) {
+	state := &State{
+		Experiments: map[string]*Experiment{},
+		Projects:    map[string]*Project{"platform": {Name: "platform"}},
+		Scratches:   map[string]*Scratch{},
+	}
+	bundle := &Bundle{State: &State{
+		Experiments: map[string]*Experiment{"api-abc123-platform": {Name: "platform", Repo: "/src/api"}},
+		Projects:    map[string]*Project{},
+		Scratches:   map[string]*Scratch{"notes": {Name: "notes"}},
+	}}
+
+	result := bundle.MergeStateInto(state, true)
+	if len(state.Experiments) != 0 {
+		t.Errorf("experiment 'platform' was imported next to the project: %v", state.Experiments)
+	}
+	if len(result.Rejected) != 1 || !strings.Contains(result.Rejected[0], "taken by a tracked project") {
+		t.Errorf("Rejected = %q, want the experiment named after the project", result.Rejected)
+	}
+	if state.Scratches["notes"] == nil || !slices.Equal(result.Added, []string{"scratch notes"}) {
+		t.Errorf("Added = %q, want the scratch", result.Added)
+	}
+}
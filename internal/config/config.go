@@ -2,13 +2,25 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
+// AgentSpec describes how to launch an agent
+type AgentSpec struct {
+	Command    string   `json:"command"`                // Binary to run
+	AddDirFlag string   `json:"add_dir_flag,omitempty"` // e.g. "--add-dir {dir}"; empty if unsupported
+	Flags      []string `json:"flags,omitempty"`        // Always passed, before agent_flags
+}
+
 // RepoSettings holds per-repo configuration
 type RepoSettings struct {
-	CopyFiles []string `json:"copy_files,omitempty"`
+	CopyFiles    []string `json:"copy_files,omitempty"`
+	SetupCommand string   `json:"setup_command,omitempty"`
+	Agent        string   `json:"agent,omitempty"`
+	Editor       string   `json:"editor,omitempty"`
 }
 
 // Config holds the user configuration for clade
@@ -16,12 +28,29 @@ type Config struct {
 	BaseDir            string                  `json:"base_dir"`
 	Agent              string                  `json:"agent"`
 	AgentFlags         []string                `json:"agent_flags"`
+	AgentAddDirFlag    string                  `json:"agent_add_dir_flag,omitempty"`
+	Agents             map[string]AgentSpec    `json:"agents,omitempty"`
 	Editor             string                  `json:"editor,omitempty"`
 	AutoInit           bool                    `json:"auto_init"`
 	Repos              map[string]string       `json:"repos"`
 	RepoSettings       map[string]RepoSettings `json:"repo_settings,omitempty"`
 	LastRepo           string                  `json:"last_repo"`
 	TmuxSplitDirection string                  `json:"tmux_split_direction,omitempty"`
+	TmuxEditorMode     string                  `json:"tmux_editor_mode,omitempty"`
+	TmuxSplitPercent   int                     `json:"tmux_split_percent,omitempty"`
+	ExpBranchPrefix    string                  `json:"exp_branch_prefix,omitempty"`
+	FeatBranchPrefix   string                  `json:"feat_branch_prefix,omitempty"`
+	Remote             string                  `json:"remote,omitempty"`
+	GitTimeout         string                  `json:"git_timeout,omitempty"`
+	CopyFilesMode      string                  `json:"copy_files_mode,omitempty"`
+	StaleAfterDays     int                     `json:"stale_after_days,omitempty"`
+	DropbagMaxBytes    int                     `json:"dropbag_max_bytes,omitempty"`
+	InjectFormat       string                  `json:"inject_format,omitempty"`
+	ContextSections    []string                `json:"context_sections,omitempty"`
+	DropbagHistory     bool                    `json:"dropbag_history,omitempty"`
+	TodoExtensions     []string                `json:"todo_extensions,omitempty"`
+	TodoKeywords       []string                `json:"todo_keywords,omitempty"`
+	TicketPattern      string                  `json:"ticket_pattern,omitempty"`
 }
 
 // DefaultConfig returns a config with default values
@@ -37,11 +66,32 @@ func DefaultConfig() *Config {
 		RepoSettings:       make(map[string]RepoSettings),
 		LastRepo:           "",
 		TmuxSplitDirection: "horizontal",
+		TmuxEditorMode:     "split",
+		ExpBranchPrefix:    "exp/",
+		FeatBranchPrefix:   "feat/",
+		Remote:             "origin",
+		GitTimeout:         "30s",
+		CopyFilesMode:      "copy",
+		StaleAfterDays:     DefaultStaleAfterDays,
+		DropbagMaxBytes:    DefaultDropbagMaxBytes,
+		InjectFormat:       "markdown",
+		ContextSections:    append([]string{}, ContextSectionNames...),
+		TicketPattern:      DefaultTicketPattern,
 	}
 }
 
-// ConfigPath returns the path to the config file
+// Environment variables that take precedence over the config file
+const (
+	BaseDirEnv = "CLADE_BASE_DIR"
+	ConfigEnv  = "CLADE_CONFIG"
+)
+
+// ConfigPath returns the path to the config file: $CLADE_CONFIG if set,
+// otherwise clade/config.json in the user config dir
 func ConfigPath() (string, error) {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return filepath.Abs(ExpandPath(path))
+	}
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
@@ -49,8 +99,23 @@ func ConfigPath() (string, error) {
 	return filepath.Join(configDir, "clade", "config.json"), nil
 }
 
-// Load reads the config from disk, creating default if not exists
+// Load reads the config from disk, creating default if not exists, and
+// validates it
 func Load() (*Config, error) {
+	cfg, err := LoadUnvalidated()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		configPath, _ := ConfigPath()
+		return nil, fmt.Errorf("invalid %s: %w", configPath, err)
+	}
+	return cfg, nil
+}
+
+// LoadUnvalidated is Load without Validate, so `clade config` can still
+// read and repair a config with bad values
+func LoadUnvalidated() (*Config, error) {
 	configPath, err := ConfigPath()
 	if err != nil {
 		return nil, err
@@ -114,8 +179,12 @@ func ExpandPath(path string) string {
 	return path
 }
 
-// GetBaseDir returns the expanded base directory
+// GetBaseDir returns the expanded base directory. $CLADE_BASE_DIR takes
+// precedence over base_dir, so every path derived from it follows too.
 func (c *Config) GetBaseDir() string {
+	if dir := os.Getenv(BaseDirEnv); dir != "" {
+		return ExpandPath(dir)
+	}
 	return ExpandPath(c.BaseDir)
 }
 
@@ -134,6 +203,44 @@ func (c *Config) ScratchDir() string {
 	return filepath.Join(c.GetBaseDir(), "scratch")
 }
 
+// GetGitTimeout returns the per-command git timeout (0 if unset or invalid)
+func (c *Config) GetGitTimeout() time.Duration {
+	d, err := time.ParseDuration(c.GitTimeout)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// DefaultStaleAfterDays is how long an item can go unused before it's marked stale
+const DefaultStaleAfterDays = 7
+
+// StaleAfter returns how long an item can go unused before it's marked stale
+func (c *Config) StaleAfter() time.Duration {
+	days := c.StaleAfterDays
+	if days <= 0 {
+		days = DefaultStaleAfterDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// DefaultTicketPattern matches JIRA/Linear-style IDs like PROJ-1234
+const DefaultTicketPattern = `([A-Z]+-\d+)`
+
+// DefaultDropbagMaxBytes caps how much of DROPBAG.md is injected into a session
+const DefaultDropbagMaxBytes = 8 * 1024
+
+// ContextSectionNames lists the sections inject-context can include, in output order
+var ContextSectionNames = []string{"dropbag", "git_status", "stashes", "commits", "todos", "ticket"}
+
+// GetDropbagMaxBytes returns the DROPBAG.md injection cap
+func (c *Config) GetDropbagMaxBytes() int {
+	if c.DropbagMaxBytes <= 0 {
+		return DefaultDropbagMaxBytes
+	}
+	return c.DropbagMaxBytes
+}
+
 // GetRepoCopyFiles returns the copy_files setting for a repo
 func (c *Config) GetRepoCopyFiles(repoPath string) []string {
 	if settings, ok := c.RepoSettings[repoPath]; ok {
@@ -147,5 +254,52 @@ func (c *Config) SetRepoCopyFiles(repoPath string, files []string) {
 	if c.RepoSettings == nil {
 		c.RepoSettings = make(map[string]RepoSettings)
 	}
-	c.RepoSettings[repoPath] = RepoSettings{CopyFiles: files}
+	settings := c.RepoSettings[repoPath]
+	settings.CopyFiles = files
+	c.RepoSettings[repoPath] = settings
+}
+
+// GetRepoSetupCommand returns the setup_command setting for a repo
+func (c *Config) GetRepoSetupCommand(repoPath string) string {
+	return c.RepoSettings[repoPath].SetupCommand
+}
+
+// SetRepoSetupCommand saves the setup_command setting for a repo
+func (c *Config) SetRepoSetupCommand(repoPath, command string) {
+	if c.RepoSettings == nil {
+		c.RepoSettings = make(map[string]RepoSettings)
+	}
+	settings := c.RepoSettings[repoPath]
+	settings.SetupCommand = command
+	c.RepoSettings[repoPath] = settings
+}
+
+// GetRepoAgent returns the agent setting for a repo ("" means use agent)
+func (c *Config) GetRepoAgent(repoPath string) string {
+	return c.RepoSettings[repoPath].Agent
+}
+
+// SetRepoAgent saves the agent setting for a repo
+func (c *Config) SetRepoAgent(repoPath, agent string) {
+	if c.RepoSettings == nil {
+		c.RepoSettings = make(map[string]RepoSettings)
+	}
+	settings := c.RepoSettings[repoPath]
+	settings.Agent = agent
+	c.RepoSettings[repoPath] = settings
+}
+
+// GetRepoEditor returns the editor setting for a repo ("" means use editor)
+func (c *Config) GetRepoEditor(repoPath string) string {
+	return c.RepoSettings[repoPath].Editor
+}
+
+// SetRepoEditor saves the editor setting for a repo
+func (c *Config) SetRepoEditor(repoPath, editor string) {
+	if c.RepoSettings == nil {
+		c.RepoSettings = make(map[string]RepoSettings)
+	}
+	settings := c.RepoSettings[repoPath]
+	settings.Editor = editor
+	c.RepoSettings[repoPath] = settings
 }
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	t.Setenv(ConfigEnv, path)
+	t.Setenv(BaseDirEnv, "")
+
+	// No file: defaults, written out for next time
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Remote != "origin" || cfg.GitTimeout != "30s" {
+		t.Errorf("defaults not applied: remote=%q git_timeout=%q", cfg.Remote, cfg.GitTimeout)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("default config wasn't saved: %v", err)
+	}
+
+	// The file overrides defaults; keys it leaves out keep theirs
+	base := filepath.Join(dir, "from-file")
+	if err := os.WriteFile(path, []byte(`{"base_dir": "`+base+`", "remote": "upstream"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Remote != "upstream" {
+		t.Errorf("remote = %q, want upstream from the file", cfg.Remote)
+	}
+	if cfg.GitTimeout != "30s" {
+		t.Errorf("git_timeout = %q, want the default 30s", cfg.GitTimeout)
+	}
+	if cfg.GetBaseDir() != base {
+		t.Errorf("base dir = %q, want %q from the file", cfg.GetBaseDir(), base)
+	}
+
+	// The environment overrides the file
+	envBase := filepath.Join(dir, "from-env")
+	t.Setenv(BaseDirEnv, envBase)
+	if cfg.GetBaseDir() != envBase {
+		t.Errorf("base dir = %q, want %q from %s", cfg.GetBaseDir(), envBase, BaseDirEnv)
+	}
+	if got := StatePath(cfg); got != filepath.Join(envBase, "state.json") {
+		t.Errorf("state path = %q doesn't follow %s", got, BaseDirEnv)
+	}
+}
+
+func TestLoadRejectsMalformedConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(ConfigEnv, path)
+	if err := os.WriteFile(path, []byte(`{"remote": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Fatal("Load accepted malformed JSON")
+	}
+	if err := os.WriteFile(path, []byte(`{"git_timeout": 30}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Fatal("Load accepted a number for git_timeout")
+	}
+}
+
+func TestEnvOverridesReachEveryDerivedPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(BaseDirEnv, "~/ssd/clade")
+	cfg := &Config{BaseDir: "~/clade"}
+
+	base := filepath.Join(home, "ssd", "clade")
+	for name, got := range map[string]string{
+		"GetBaseDir":     cfg.GetBaseDir(),
+		"ExperimentsDir": filepath.Dir(cfg.ExperimentsDir()),
+		"ProjectsDir":    filepath.Dir(cfg.ProjectsDir()),
+		"ScratchDir":     filepath.Dir(cfg.ScratchDir()),
+		"StatePath":      filepath.Dir(StatePath(cfg)),
+	} {
+		if got != base {
+			t.Errorf("%s is under %q, want %q", name, got, base)
+		}
+	}
+
+	t.Setenv(ConfigEnv, "~/alt/config.json")
+	if path, err := ConfigPath(); err != nil || path != filepath.Join(home, "alt", "config.json") {
+		t.Errorf("ConfigPath() = %q, %v; want it under $HOME", path, err)
+	}
+	t.Chdir(home)
+	t.Setenv(ConfigEnv, "rel.json")
+	if path, err := ConfigPath(); err != nil || path != filepath.Join(home, "rel.json") {
+		t.Errorf("ConfigPath() = %q, %v; want a relative path made absolute", path, err)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// LockPath returns the path to the state lock file
+func LockPath(cfg *Config) string {
+	return filepath.Join(cfg.GetBaseDir(), "state.json.lock")
+}
+
+// LockState acquires an exclusive advisory lock on the state file, blocking
+// until it is available. Call the returned function to release it.
+func LockState(cfg *Config) (func(), error) {
+	lockPath := LockPath(cfg)
+
+	// Ensure directory exists
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
+
+// UpdateState runs a read-modify-write cycle on the state under the lock.
+// The state is reloaded from disk after locking so changes made by other
+// clade processes are not lost.
+func UpdateState(cfg *Config, fn func(*State) error) error {
+	unlock, err := LockState(cfg)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(state); err != nil {
+		return err
+	}
+
+	return state.Save(cfg)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestUpdateStateConcurrentWritersKeepAllChanges(t *testing.T) {
+	t.Setenv(BaseDirEnv, "")
+	cfg := DefaultConfig()
+	cfg.BaseDir = t.TempDir()
+
+	const perWriter = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*perWriter)
+	for _, writer := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(writer string) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				name := fmt.Sprintf("%s-%d", writer, i)
+				errs <- UpdateState(cfg, func(s *State) error {
+					s.AddExperiment(&Experiment{Name: name, Repo: "/repo"})
+					return nil
+				})
+			}
+		}(writer)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateState: %v", err)
+		}
+	}
+
+	state, err := LoadState(cfg)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if got, want := len(state.Experiments), 2*perWriter; got != want {
+		t.Fatalf("got %d experiments, want %d (concurrent updates were lost)", got, want)
+	}
+	for _, writer := range []string{"a", "b"} {
+		for i := 0; i < perWriter; i++ {
+			name := fmt.Sprintf("%s-%d", writer, i)
+			if state.GetExperiment(ExperimentKey("/repo", name)) == nil {
+				t.Errorf("experiment %s is missing", name)
+			}
+		}
+	}
+}
+
+func TestUpdateStateErrorLeavesStateUnchanged(t *testing.T) {
+	t.Setenv(BaseDirEnv, "")
+	cfg := DefaultConfig()
+	cfg.BaseDir = t.TempDir()
+
+	err := UpdateState(cfg, func(s *State) error {
+		s.AddExperiment(&Experiment{Name: "kept", Repo: "/repo"})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateState: %v", err)
+	}
+
+	wantErr := fmt.Errorf("boom")
+	err = UpdateState(cfg, func(s *State) error {
+		s.AddExperiment(&Experiment{Name: "dropped", Repo: "/repo"})
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+
+	state, err := LoadState(cfg)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if state.GetExperiment(ExperimentKey("/repo", "dropped")) != nil {
+		t.Error("experiment from a failed update was saved")
+	}
+	if state.GetExperiment(ExperimentKey("/repo", "kept")) == nil {
+		t.Error("earlier experiment is missing")
+	}
+}
@@ -0,0 +1,348 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Setting describes a config key that can be read and written by name
+type Setting struct {
+	Key         string
+	Description string
+	Get         func(c *Config) string
+	Set         func(c *Config, value string) error
+}
+
+// Settings lists the config keys exposed through `clade config`
+var Settings = []Setting{
+	{
+		Key:         "base_dir",
+		Description: "Where experiments/projects live",
+		Get:         func(c *Config) string { return c.BaseDir },
+		Set: func(c *Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("base_dir cannot be empty")
+			}
+			if !filepath.IsAbs(ExpandPath(value)) {
+				return fmt.Errorf("base_dir must be an absolute path or start with ~")
+			}
+			c.BaseDir = value
+			return nil
+		},
+	},
+	{
+		Key:         "agent",
+		Description: "AI agent command",
+		Get:         func(c *Config) string { return c.Agent },
+		Set: func(c *Config, value string) error {
+			c.Agent = value
+			return nil
+		},
+	},
+	{
+		Key:         "agent_flags",
+		Description: "Extra flags for the agent (space-separated)",
+		Get:         func(c *Config) string { return strings.Join(c.AgentFlags, " ") },
+		Set: func(c *Config, value string) error {
+			c.AgentFlags = strings.Fields(value)
+			return nil
+		},
+	},
+	{
+		Key:         "agent_add_dir_flag",
+		Description: "Flag a custom agent command takes per extra project repo (e.g. --add-dir or \"-d {dir}\")",
+		Get:         func(c *Config) string { return c.AgentAddDirFlag },
+		Set: func(c *Config, value string) error {
+			c.AgentAddDirFlag = value
+			return nil
+		},
+	},
+	{
+		Key:         "editor",
+		Description: "Editor/IDE to open (cursor, code, zed, nvim, hx, emacs, idea, ... or none; empty uses $VISUAL/$EDITOR)",
+		Get:         func(c *Config) string { return c.Editor },
+		Set: func(c *Config, value string) error {
+			c.Editor = value
+			return nil
+		},
+	},
+	{
+		Key:         "auto_init",
+		Description: "Auto-setup .claude/ in new worktrees (true/false)",
+		Get:         func(c *Config) string { return strconv.FormatBool(c.AutoInit) },
+		Set: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("auto_init must be true or false")
+			}
+			c.AutoInit = b
+			return nil
+		},
+	},
+	{
+		Key:         "tmux_split_direction",
+		Description: "tmux split for terminal editors (horizontal/vertical)",
+		Get:         func(c *Config) string { return c.TmuxSplitDirection },
+		Set: func(c *Config, value string) error {
+			if value != "horizontal" && value != "vertical" {
+				return fmt.Errorf("tmux_split_direction must be horizontal or vertical")
+			}
+			c.TmuxSplitDirection = value
+			return nil
+		},
+	},
+	{
+		Key:         "tmux_editor_mode",
+		Description: "Open terminal editors in a tmux split or a new window (split/window)",
+		Get:         func(c *Config) string { return c.TmuxEditorMode },
+		Set: func(c *Config, value string) error {
+			if value != "split" && value != "window" {
+				return fmt.Errorf("tmux_editor_mode must be split or window")
+			}
+			c.TmuxEditorMode = value
+			return nil
+		},
+	},
+	{
+		Key:         "tmux_split_percent",
+		Description: "Share of the window the editor split takes, 1-99 (0 = tmux default)",
+		Get:         func(c *Config) string { return strconv.Itoa(c.TmuxSplitPercent) },
+		Set: func(c *Config, value string) error {
+			percent, err := strconv.Atoi(value)
+			if err != nil || percent < 0 || percent > 99 {
+				return fmt.Errorf("tmux_split_percent must be between 1 and 99 (or 0 for the tmux default)")
+			}
+			c.TmuxSplitPercent = percent
+			return nil
+		},
+	},
+	{
+		Key:         "exp_branch_prefix",
+		Description: "Default branch prefix for experiments",
+		Get:         func(c *Config) string { return c.ExpBranchPrefix },
+		Set: func(c *Config, value string) error {
+			c.ExpBranchPrefix = value
+			return nil
+		},
+	},
+	{
+		Key:         "feat_branch_prefix",
+		Description: "Default branch prefix for features and projects",
+		Get:         func(c *Config) string { return c.FeatBranchPrefix },
+		Set: func(c *Config, value string) error {
+			c.FeatBranchPrefix = value
+			return nil
+		},
+	},
+	{
+		Key:         "remote",
+		Description: "Git remote to fetch from and base new branches on",
+		Get:         func(c *Config) string { return c.Remote },
+		Set: func(c *Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("remote cannot be empty")
+			}
+			c.Remote = value
+			return nil
+		},
+	},
+	{
+		Key:         "git_timeout",
+		Description: "Limit for a single git query or fetch (e.g. 30s, 2m); commands that change the repo aren't limited",
+		Get:         func(c *Config) string { return c.GitTimeout },
+		Set: func(c *Config, value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("git_timeout must be a positive duration like 30s or 2m")
+			}
+			c.GitTimeout = value
+			return nil
+		},
+	},
+	{
+		Key:         "copy_files_mode",
+		Description: "How gitignored files reach worktrees (copy/symlink)",
+		Get:         func(c *Config) string { return c.CopyFilesMode },
+		Set: func(c *Config, value string) error {
+			if value != "copy" && value != "symlink" {
+				return fmt.Errorf("copy_files_mode must be copy or symlink")
+			}
+			c.CopyFilesMode = value
+			return nil
+		},
+	},
+	{
+		Key:         "stale_after_days",
+		Description: "Days unused before an item is marked stale (and pruned by default)",
+		Get:         func(c *Config) string { return intOrDefault(c.StaleAfterDays, DefaultStaleAfterDays) },
+		Set: func(c *Config, value string) error {
+			days, err := strconv.Atoi(value)
+			if err != nil || days <= 0 {
+				return fmt.Errorf("stale_after_days must be a positive number of days")
+			}
+			c.StaleAfterDays = days
+			return nil
+		},
+	},
+	{
+		Key:         "dropbag_max_bytes",
+		Description: "Max bytes of DROPBAG.md injected into a session (older notes are cut)",
+		Get:         func(c *Config) string { return intOrDefault(c.DropbagMaxBytes, DefaultDropbagMaxBytes) },
+		Set: func(c *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("dropbag_max_bytes must be a positive number of bytes")
+			}
+			c.DropbagMaxBytes = n
+			return nil
+		},
+	},
+	{
+		Key:         "inject_format",
+		Description: "inject-context output (markdown/json hook envelope)",
+		Get:         func(c *Config) string { return c.InjectFormat },
+		Set: func(c *Config, value string) error {
+			if value != "markdown" && value != "json" {
+				return fmt.Errorf("inject_format must be markdown or json")
+			}
+			c.InjectFormat = value
+			return nil
+		},
+	},
+	{
+		Key:         "context_sections",
+		Description: "Sections inject-context includes (comma-separated: " + strings.Join(ContextSectionNames, ",") + ")",
+		Get:         func(c *Config) string { return strings.Join(c.ContextSections, ",") },
+		Set: func(c *Config, value string) error {
+			var sections []string
+			for _, name := range splitList(value) {
+				if !slices.Contains(ContextSectionNames, name) {
+					return fmt.Errorf("unknown context section '%s' (use %s)", name, strings.Join(ContextSectionNames, ", "))
+				}
+				if !slices.Contains(sections, name) {
+					sections = append(sections, name)
+				}
+			}
+			if len(sections) == 0 {
+				return fmt.Errorf("context_sections needs at least one section")
+			}
+			c.ContextSections = sections
+			return nil
+		},
+	},
+	{
+		Key:         "dropbag_history",
+		Description: "Archive each DROPBAG.md to .clade/dropbags and list past sessions (true/false)",
+		Get:         func(c *Config) string { return strconv.FormatBool(c.DropbagHistory) },
+		Set: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("dropbag_history must be true or false")
+			}
+			c.DropbagHistory = b
+			return nil
+		},
+	},
+	{
+		Key:         "todo_extensions",
+		Description: "Extra file extensions to scan for TODOs (comma-separated, e.g. .kt,.swift)",
+		Get:         func(c *Config) string { return strings.Join(c.TodoExtensions, ",") },
+		Set: func(c *Config, value string) error {
+			c.TodoExtensions = splitList(value)
+			return nil
+		},
+	},
+	{
+		Key:         "todo_keywords",
+		Description: "Extra TODO keywords besides TODO/FIXME/HACK/XXX/BUG (comma-separated)",
+		Get:         func(c *Config) string { return strings.Join(c.TodoKeywords, ",") },
+		Set: func(c *Config, value string) error {
+			c.TodoKeywords = splitList(value)
+			return nil
+		},
+	},
+	{
+		Key:         "ticket_pattern",
+		Description: "Regex that finds ticket IDs in names; the first group is the ticket (default " + DefaultTicketPattern + ")",
+		Get:         func(c *Config) string { return c.TicketPattern },
+		Set: func(c *Config, value string) error {
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("ticket_pattern is not a valid regex: %v", err)
+			}
+			c.TicketPattern = value
+			return nil
+		},
+	},
+}
+
+// validatedKeys are the settings whose values Validate checks on load. Free-form
+// keys (agent, editor, branch prefixes, ...) accept anything.
+var validatedKeys = []string{
+	"base_dir",
+	"tmux_split_direction",
+	"tmux_editor_mode",
+	"tmux_split_percent",
+	"remote",
+	"git_timeout",
+	"copy_files_mode",
+	"inject_format",
+	"context_sections",
+	"ticket_pattern",
+	"stale_after_days",
+	"dropbag_max_bytes",
+}
+
+// Validate checks the loaded values with the same rules as `clade config set`
+// and names the first bad key. Unset optional keys are skipped.
+func (c *Config) Validate() error {
+	for _, key := range validatedKeys {
+		setting, _ := LookupSetting(key)
+		value := setting.Get(c)
+		if value == "" && key != "base_dir" && key != "remote" {
+			continue
+		}
+		check := *c
+		if err := setting.Set(&check, value); err != nil {
+			return fmt.Errorf("%w (got %q; fix with: clade config set %s <value>)", err, value, key)
+		}
+	}
+	return nil
+}
+
+// intOrDefault formats a numeric setting, showing 0 (unset) as def. Other
+// values are shown as-is so Validate can reject negative ones.
+func intOrDefault(n, def int) string {
+	if n == 0 {
+		n = def
+	}
+	return strconv.Itoa(n)
+}
+
+// splitList parses a comma- or space-separated list value
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// LookupSetting finds a setting by key
+func LookupSetting(key string) (*Setting, bool) {
+	for i := range Settings {
+		if Settings[i].Key == key {
+			return &Settings[i], true
+		}
+	}
+	return nil, false
+}
+
+// SettingKeys returns all known setting keys
+func SettingKeys() []string {
+	keys := make([]string, 0, len(Settings))
+	for _, s := range Settings {
+		keys = append(keys, s.Key)
+	}
+	return keys
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Config)
+		key    string
+	}{
+		{"empty base_dir", func(c *Config) { c.BaseDir = "" }, "base_dir"},
+		{"relative base_dir", func(c *Config) { c.BaseDir = "clade" }, "base_dir"},
+		{"tmux_split_direction typo", func(c *Config) { c.TmuxSplitDirection = "horizonal" }, "tmux_split_direction"},
+		{"unknown tmux_editor_mode", func(c *Config) { c.TmuxEditorMode = "popup" }, "tmux_editor_mode"},
+		{"tmux_split_percent out of range", func(c *Config) { c.TmuxSplitPercent = 150 }, "tmux_split_percent"},
+		{"empty remote", func(c *Config) { c.Remote = "" }, "remote"},
+		{"unparsable git_timeout", func(c *Config) { c.GitTimeout = "soon" }, "git_timeout"},
+		{"unknown copy_files_mode", func(c *Config) { c.CopyFilesMode = "hardlink" }, "copy_files_mode"},
+		{"unknown inject_format", func(c *Config) { c.InjectFormat = "yaml" }, "inject_format"},
+		{"unknown context section", func(c *Config) { c.ContextSections = []string{"dropbag", "weather"} }, "context_sections"},
+		{"invalid ticket_pattern", func(c *Config) { c.TicketPattern = "([A-Z" }, "ticket_pattern"},
+		{"negative stale_after_days", func(c *Config) { c.StaleAfterDays = -1 }, "stale_after_days"},
+		{"negative dropbag_max_bytes", func(c *Config) { c.DropbagMaxBytes = -100 }, "dropbag_max_bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatal("Validate accepted an invalid value")
+			}
+			if !strings.Contains(err.Error(), "clade config set "+tt.key) {
+				t.Errorf("error doesn't name %s: %v", tt.key, err)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsDefaultsAndUnsetValues(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("defaults are invalid: %v", err)
+	}
+
+	// Zero means unset for the numeric settings and falls back to the default
+	cfg.StaleAfterDays = 0
+	cfg.DropbagMaxBytes = 0
+	cfg.TmuxSplitPercent = 0
+	cfg.TicketPattern = ""
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unset values are invalid: %v", err)
+	}
+	if got := cfg.StaleAfter().Hours() / 24; got != DefaultStaleAfterDays {
+		t.Errorf("StaleAfter = %v days, want %d", got, DefaultStaleAfterDays)
+	}
+	if got := cfg.GetDropbagMaxBytes(); got != DefaultDropbagMaxBytes {
+		t.Errorf("GetDropbagMaxBytes = %d, want %d", got, DefaultDropbagMaxBytes)
+	}
+}
+
+func TestLoadRejectsInvalidConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(ConfigEnv, path)
+	if err := os.WriteFile(path, []byte(`{"base_dir": "/tmp/clade", "stale_after_days": -3}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load accepted stale_after_days = -3")
+	}
+	if !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "stale_after_days") {
+		t.Errorf("error should name the file and the key: %v", err)
+	}
+
+	// config commands still need to read it to repair it
+	cfg, err := LoadUnvalidated()
+	if err != nil {
+		t.Fatalf("LoadUnvalidated: %v", err)
+	}
+	if cfg.StaleAfterDays != -3 {
+		t.Errorf("StaleAfterDays = %d, want -3", cfg.StaleAfterDays)
+	}
+}
+
+func TestSetTmuxSplitPercentRange(t *testing.T) {
+	cfg := DefaultConfig()
+	setting, _ := LookupSetting("tmux_split_percent")
+	for _, value := range []string{"100", "-5", "abc"} {
+		if err := setting.Set(cfg, value); err == nil {
+			t.Errorf("tmux_split_percent %q was accepted", value)
+		}
+	}
+	// 0 unsets it, leaving the size to tmux
+	for _, value := range []string{"0", "1", "70", "99"} {
+		if err := setting.Set(cfg, value); err != nil {
+			t.Errorf("tmux_split_percent %q: %v", value, err)
+		}
+	}
+	if cfg.TmuxSplitPercent != 99 {
+		t.Errorf("TmuxSplitPercent = %d, want 99", cfg.TmuxSplitPercent)
+	}
+}
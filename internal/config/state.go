@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -14,6 +16,7 @@ type Experiment struct {
 	Path     string    `json:"path"`
 	Branch   string    `json:"branch"`
 	Ticket   string    `json:"ticket,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
 	Created  time.Time `json:"created"`
 	LastUsed time.Time `json:"last_used"`
 }
@@ -22,6 +25,7 @@ type Experiment struct {
 type ProjectRepo struct {
 	Name   string `json:"name"`
 	Source string `json:"source"`
+	Branch string `json:"branch,omitempty"` // Overrides the project branch
 }
 
 // Project represents a tracked multi-repo project
@@ -30,17 +34,29 @@ type Project struct {
 	Path     string        `json:"path"`
 	Branch   string        `json:"branch"`
 	Repos    []ProjectRepo `json:"repos"`
+	Tags     []string      `json:"tags,omitempty"`
 	Created  time.Time     `json:"created"`
 	LastUsed time.Time     `json:"last_used"`
 }
 
+// RepoBranch returns the branch checked out in repo: its own override, or
+// the project branch
+func (p *Project) RepoBranch(repo ProjectRepo) string {
+	if repo.Branch != "" {
+		return repo.Branch
+	}
+	return p.Branch
+}
+
 // Scratch represents a no-git scratch folder
 type Scratch struct {
-	Name     string    `json:"name"`
-	Path     string    `json:"path"`
-	Ticket   string    `json:"ticket,omitempty"`
-	Created  time.Time `json:"created"`
-	LastUsed time.Time `json:"last_used"`
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Ticket     string    `json:"ticket,omitempty"`
+	LinkedRepo string    `json:"linked_repo,omitempty"` // repo whose git context is injected, if any
+	Tags       []string  `json:"tags,omitempty"`
+	Created    time.Time `json:"created"`
+	LastUsed   time.Time `json:"last_used"`
 }
 
 // State holds the runtime state of clade
@@ -61,7 +77,7 @@ func LoadState(cfg *Config) (*State, error) {
 	statePath := StatePath(cfg)
 
 	state := &State{
-		Version:     1,
+		Version:     stateVersion,
 		Experiments: make(map[string]*Experiment),
 		Projects:    make(map[string]*Project),
 		Scratches:   make(map[string]*Scratch),
@@ -90,6 +106,8 @@ func LoadState(cfg *Config) (*State, error) {
 		state.Scratches = make(map[string]*Scratch)
 	}
 
+	state.migrateExperimentKeys()
+
 	return state, nil
 }
 
@@ -107,7 +125,35 @@ func (s *State) Save(cfg *Config) error {
 		return err
 	}
 
-	return os.WriteFile(statePath, data, 0644)
+	return writeFileAtomic(statePath, data, 0644)
+}
+
+// writeFileAtomic replaces path with data by writing a temp file next to it
+// and renaming it over path, so readers that don't take the state lock never
+// see a truncated or half-written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// A no-op once the rename has happened
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 // AddExperiment adds or updates an experiment in state
@@ -126,10 +172,27 @@ func (s *State) RemoveExperiment(key string) {
 	delete(s.Experiments, key)
 }
 
-// ExperimentKey generates a unique key for an experiment
+// ExperimentKey generates a unique key for an experiment. A short hash of
+// the full repo path keeps repos that share a basename apart.
 func ExperimentKey(repo, name string) string {
-	repoName := filepath.Base(repo)
-	return repoName + "-" + name
+	sum := sha1.Sum([]byte(filepath.Clean(repo)))
+	return filepath.Base(repo) + "-" + hex.EncodeToString(sum[:])[:6] + "-" + name
+}
+
+// migrateExperimentKeys re-keys experiments stored under an older key
+// format. Paths on disk are left where they are.
+func (s *State) migrateExperimentKeys() {
+	for key, exp := range s.Experiments {
+		newKey := ExperimentKey(exp.Repo, exp.Name)
+		if key == newKey {
+			continue
+		}
+		if _, taken := s.Experiments[newKey]; taken {
+			continue
+		}
+		delete(s.Experiments, key)
+		s.Experiments[newKey] = exp
+	}
 }
 
 // AddScratch adds or updates a scratch in state
@@ -146,3 +209,52 @@ func (s *State) GetScratch(name string) *Scratch {
 func (s *State) RemoveScratch(name string) {
 	delete(s.Scratches, name)
 }
+
+// NameExists checks whether a name is already used by any experiment, project,
+// or scratch. Returns the type of the existing item if found.
+func (s *State) NameExists(name string) (string, bool) {
+	for _, exp := range s.Experiments {
+		if exp.Name == name {
+			return "experiment", true
+		}
+	}
+	for _, proj := range s.Projects {
+		if proj.Name == name {
+			return "project", true
+		}
+	}
+	for _, scratch := range s.Scratches {
+		if scratch.Name == name {
+			return "scratch", true
+		}
+	}
+	return "", false
+}
+
+// NameUsedByOtherType is like NameExists but ignores items of itemType
+// ("experiment", "project", or "scratch"), whose own keys already tell
+// same-type items apart.
+func (s *State) NameUsedByOtherType(name, itemType string) (string, bool) {
+	if itemType != "experiment" {
+		for _, exp := range s.Experiments {
+			if exp.Name == name {
+				return "experiment", true
+			}
+		}
+	}
+	if itemType != "project" {
+		for _, proj := range s.Projects {
+			if proj.Name == name {
+				return "project", true
+			}
+		}
+	}
+	if itemType != "scratch" {
+		for _, scratch := range s.Scratches {
+			if scratch.Name == name {
+				return "scratch", true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExperimentKeySeparatesReposWithSameBasename(t *testing.T) {
+	work := ExperimentKey("/home/me/work/api", "spike")
+	personal := ExperimentKey("/home/me/personal/api", "spike")
+	if work == personal {
+		t.Fatalf("both repos map to %s", work)
+	}
+	for _, key := range []string{work, personal} {
+		if !strings.HasPrefix(key, "api-") || !strings.HasSuffix(key, "-spike") {
+			t.Errorf("key %s should read as <repo>-<hash>-<name>", key)
+		}
+	}
+	if again := ExperimentKey("/home/me/work/api/", "spike"); again != work {
+		t.Errorf("key isn't stable across equivalent paths: %s vs %s", again, work)
+	}
+}
+
+func TestLoadStateMigratesLegacyExperimentKeys(t *testing.T) {
+	t.Setenv(BaseDirEnv, "")
+	cfg := DefaultConfig()
+	cfg.BaseDir = t.TempDir()
+	legacy := `{"version": 1, "experiments": {
+		"api-spike": {"name": "spike", "repo": "/work/api", "path": "/base/experiments/api-spike", "branch": "exp/spike"}
+	}}`
+	if err := os.WriteFile(filepath.Join(cfg.BaseDir, "state.json"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := LoadState(cfg)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	exp := state.GetExperiment(ExperimentKey("/work/api", "spike"))
+	if exp == nil {
+		t.Fatalf("legacy entry wasn't re-keyed: %v", state.Experiments)
+	}
+	if exp.Path != "/base/experiments/api-spike" {
+		t.Errorf("Path = %s; migration must leave it where it is", exp.Path)
+	}
+	if len(state.Experiments) != 1 {
+		t.Errorf("got %d experiments, want 1", len(state.Experiments))
+	}
+}
+
+func TestSaveNeverExposesPartialState(t *testing.T) {
+	t.Setenv(BaseDirEnv, "")
+	cfg := DefaultConfig()
+	cfg.BaseDir = t.TempDir()
+	state := &State{Version: stateVersion, Experiments: map[string]*Experiment{}}
+	for i := 0; i < 200; i++ {
+		state.AddExperiment(&Experiment{Name: fmt.Sprintf("exp-%03d-%s", i, strings.Repeat("x", 100)), Repo: "/repo"})
+	}
+	if err := state.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Readers like list and completion load state without the lock
+	done := make(chan struct{})
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(readErr)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := LoadState(cfg); err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if err := state.Save(cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	if err := <-readErr; err != nil {
+		t.Fatalf("reader saw a partial state.json: %v", err)
+	}
+
+	entries, err := os.ReadDir(cfg.GetBaseDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp-") {
+			t.Errorf("temp file %s was left behind", e.Name())
+		}
+	}
+	if info, err := os.Stat(StatePath(cfg)); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("state.json mode = %v, %v; want 0644", info.Mode().Perm(), err)
+	}
+}
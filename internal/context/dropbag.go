@@ -1,18 +1,101 @@
 package context
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/daniil-lyalko/clade/internal/git"
 )
 
 // DropbagInfo contains information about a DROPBAG.md file
 type DropbagInfo struct {
-	Content    string
-	ModTime    time.Time
-	Exists     bool
+	Content     string
+	ModTime     time.Time
+	Exists      bool
 	RelativeAge string
+	History     []DropbagArchive
+}
+
+// DropbagTemplate is the section outline for DROPBAG.md, shared by the /drop
+// command and "clade drop"
+const DropbagTemplate = `## Summary
+What we accomplished this session. Be specific about changes made.
+
+## Current State
+What's working, what's broken, what's partially implemented.
+
+## Next Steps
+Exact actions to continue (be specific - file names, function names, etc.).
+
+## Key Files
+Files to look at first when resuming. Include line numbers if relevant.
+
+## Open Questions
+Anything unresolved or decisions that need to be made.
+`
+
+// GitSnapshot returns a DROPBAG.md section with the branch and working tree
+// status of dir
+func GitSnapshot(dir string) (string, error) {
+	status, err := git.GetStatus(dir)
+	if err != nil {
+		return "", err
+	}
+	branch, err := git.GetCurrentBranch(dir)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Git Snapshot (%s)\n\n", time.Now().Format("2006-01-02 15:04")))
+	writeGitStatus(&sb, branch, "", status)
+	return sb.String(), nil
+}
+
+// dropbagAutoMarker starts the section "clade drop --auto" maintains
+const dropbagAutoMarker = "<!-- clade drop --auto: generated below, replaced on every run -->"
+
+// AutoSnapshot returns an auto-generated DROPBAG.md section with the git
+// status, recent commits, and TODOs for dir
+func AutoSnapshot(dir string) (string, error) {
+	ctx, err := GatherContext(dir)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Auto Snapshot (%s)\n\n", time.Now().Format("2006-01-02 15:04")))
+	writeGitStatusSection(&sb, ctx, "###")
+	writeCommitsSection(&sb, ctx, "###")
+	writeTodosSection(&sb, ctx, "###")
+	return sb.String(), nil
+}
+
+// ReplaceAutoSnapshot puts snapshot at the end of content, replacing any
+// earlier auto snapshot but keeping everything written above it
+func ReplaceAutoSnapshot(content, snapshot string) string {
+	if idx := strings.Index(content, dropbagAutoMarker); idx >= 0 {
+		content = content[:idx]
+	}
+	content = strings.TrimRight(content, "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	return content + dropbagAutoMarker + "\n\n" + strings.TrimRight(snapshot, "\n") + "\n"
+}
+
+// dropbagMaxBytes caps the DROPBAG.md content written by FormatContext
+var dropbagMaxBytes = 8 * 1024
+
+// SetDropbagMaxBytes sets how much of DROPBAG.md FormatContext includes
+func SetDropbagMaxBytes(n int) {
+	if n > 0 {
+		dropbagMaxBytes = n
+	}
 }
 
 // ReadDropbag reads the DROPBAG.md file from a directory
@@ -44,6 +127,29 @@ func ReadDropbag(dir string) (*DropbagInfo, error) {
 	return info, nil
 }
 
+// TruncateDropbag keeps the most recent maxBytes of content, which is where new
+// notes are appended. The cut moves forward to the next "## " section heading
+// when one is close enough, otherwise to the next line.
+func TruncateDropbag(content string, maxBytes int) string {
+	if maxBytes <= 0 || len(content) <= maxBytes {
+		return content
+	}
+
+	start := len(content) - maxBytes
+	tail := content[start:]
+	if idx := strings.Index(tail, "\n## "); idx >= 0 && idx < maxBytes/2 {
+		start += idx + 1
+	} else if idx := strings.IndexByte(tail, '\n'); idx >= 0 {
+		start += idx + 1
+	} else {
+		for start < len(content) && !utf8.RuneStart(content[start]) {
+			start++
+		}
+	}
+
+	return fmt.Sprintf("...(truncated, %d bytes omitted)...\n\n%s", start, content[start:])
+}
+
 func formatRelativeTime(t time.Time) string {
 	d := time.Since(t)
 
@@ -51,25 +157,25 @@ func formatRelativeTime(t time.Time) string {
 		return "just now"
 	}
 	if d < time.Hour {
-		mins := int(d.Minutes())
-		if mins == 1 {
-			return "1 minute ago"
-		}
-		return string(rune('0'+mins/10)) + string(rune('0'+mins%10)) + " minutes ago"
+		return pluralize(int(d.Minutes()), "minute") + " ago"
 	}
 	if d < 24*time.Hour {
-		hours := int(d.Hours())
-		if hours == 1 {
-			return "1 hour ago"
-		}
-		return string(rune('0'+hours/10)) + string(rune('0'+hours%10)) + " hours ago"
+		return pluralize(int(d.Hours()), "hour") + " ago"
 	}
 	if d < 48*time.Hour {
 		return "yesterday"
 	}
 	days := int(d.Hours() / 24)
 	if days < 7 {
-		return string(rune('0'+days)) + " days ago"
+		return pluralize(days, "day") + " ago"
 	}
 	return t.Format("Jan 2, 2006")
 }
+
+// pluralize formats a count with a singular or plural unit
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
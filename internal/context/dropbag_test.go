@@ -0,0 +1,96 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	old := time.Now().Add(-20 * 24 * time.Hour)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{1 * time.Minute, "1 minute ago"},
+		{9 * time.Minute, "9 minutes ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{150 * time.Minute, "2 hours ago"},
+		{1 * time.Hour, "1 hour ago"},
+		{12 * time.Hour, "12 hours ago"},
+		{36 * time.Hour, "yesterday"},
+		{24 * time.Hour, "yesterday"},
+		{6 * 24 * time.Hour, "6 days ago"},
+		{20 * 24 * time.Hour, old.Format("Jan 2, 2006")},
+	}
+	for _, tt := range tests {
+		// Pad by a second so the boundary cases don't round down
+		got := formatRelativeTime(time.Now().Add(-tt.ago - time.Second))
+		if got != tt.want {
+			t.Errorf("%s ago: got %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+// largeDropbag builds a DROPBAG.md with sessions sections of about 1KB each
+func largeDropbag(sessions int) string {
+	var sb strings.Builder
+	sb.WriteString("# DROPBAG\n")
+	for i := 1; i <= sessions; i++ {
+		fmt.Fprintf(&sb, "\n## Session %d\n\n", i)
+		for j := 0; j < 16; j++ {
+			fmt.Fprintf(&sb, "- note %d.%d about what was tried and why\n", i, j)
+		}
+	}
+	return sb.String()
+}
+
+func TestTruncateDropbagKeepsRecentSections(t *testing.T) {
+	content := largeDropbag(40)
+	const maxBytes = 8 * 1024
+
+	got := TruncateDropbag(content, maxBytes)
+	marker, kept, ok := strings.Cut(got, "\n\n")
+	if !ok {
+		t.Fatalf("no marker in output: %.80q", got)
+	}
+	omitted := len(content) - len(kept)
+	if want := fmt.Sprintf("...(truncated, %d bytes omitted)...", omitted); marker != want {
+		t.Errorf("marker = %q, want %q", marker, want)
+	}
+	if len(kept) > maxBytes {
+		t.Errorf("kept %d bytes, cap is %d", len(kept), maxBytes)
+	}
+	if !strings.HasPrefix(kept, "## Session ") {
+		t.Errorf("cut isn't at a section boundary: %.40q", kept)
+	}
+	if !strings.HasSuffix(content, kept) {
+		t.Error("kept content isn't the end of the file")
+	}
+	if !strings.Contains(kept, "## Session 40\n") {
+		t.Error("most recent session was dropped")
+	}
+}
+
+func TestTruncateDropbagLeavesSmallContentAlone(t *testing.T) {
+	content := largeDropbag(2)
+	if got := TruncateDropbag(content, 8*1024); got != content {
+		t.Errorf("small DROPBAG was changed:\n%s", got)
+	}
+}
+
+func TestFormatContextTruncatesDropbag(t *testing.T) {
+	SetDropbagMaxBytes(4 * 1024)
+	t.Cleanup(func() { SetDropbagMaxBytes(8 * 1024) })
+
+	content := largeDropbag(40)
+	out := FormatContext(&ContextOutput{Dropbag: &DropbagInfo{Exists: true, Content: content, RelativeAge: "just now"}})
+	if !strings.Contains(out, "bytes omitted)...") {
+		t.Error("FormatContext didn't truncate the DROPBAG")
+	}
+	if len(out) > len(content)/2 {
+		t.Errorf("output is %d bytes for a %d byte DROPBAG capped at 4KB", len(out), len(content))
+	}
+}
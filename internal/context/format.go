@@ -16,54 +16,137 @@ type CladeMetadata struct {
 	Name    string `json:"name"`
 	Ticket  string `json:"ticket,omitempty"`
 	Repo    string `json:"repo"`
+	Base    string `json:"base,omitempty"`
 	Created string `json:"created"`
+
+	// LinkedRepo is a source repo a scratch is about; its git state stands
+	// in for the scratch's own, which has none
+	LinkedRepo string `json:"linked_repo,omitempty"`
 }
 
 // ContextOutput holds all the context to be injected
 type ContextOutput struct {
+	Dir        string // root the context was gathered from
+	GitDir     string // where git sections came from: Dir, or a scratch's linked repo
 	Dropbag    *DropbagInfo
 	GitStatus  *git.Status
+	Stashes    []git.Stash
 	Commits    []string
 	Todos      []TodoItem
 	Metadata   *CladeMetadata
 	RepoName   string
 	BranchName string
+	Upstream   string // remote tracking ref, empty if there is none
+	Ahead      int
+	Behind     int
+}
+
+// Section names for SetSections
+const (
+	SectionDropbag   = "dropbag"
+	SectionGitStatus = "git_status"
+	SectionStashes   = "stashes"
+	SectionCommits   = "commits"
+	SectionTodos     = "todos"
+	SectionTicket    = "ticket"
+)
+
+// remote is the remote whose branches upstream divergence is reported against
+var remote = "origin"
+
+// SetRemote sets the remote used for ahead/behind counts
+func SetRemote(name string) {
+	if name != "" {
+		remote = name
+	}
+}
+
+// enabledSections holds the sections to gather and format; nil means all
+var enabledSections map[string]bool
+
+// SetSections limits GatherContext and FormatContext to the named sections.
+// An empty list enables every section.
+func SetSections(names []string) {
+	if len(names) == 0 {
+		enabledSections = nil
+		return
+	}
+	enabledSections = make(map[string]bool, len(names))
+	for _, name := range names {
+		enabledSections[name] = true
+	}
 }
 
-// GatherContext collects all context information for a directory
+// sectionEnabled reports whether a section should be included
+func sectionEnabled(name string) bool {
+	return enabledSections == nil || enabledSections[name]
+}
+
+// findTodos is the TODO scan GatherContext runs (overridable in tests)
+var findTodos = FindTodos
+
+// GatherContext collects the enabled context information for a directory
 func GatherContext(dir string) (*ContextOutput, error) {
-	ctx := &ContextOutput{}
+	ctx := &ContextOutput{Dir: dir, GitDir: dir}
+
+	// Read .clade.json metadata
+	metadata, _ := ReadCladeMetadata(dir)
+	ctx.Metadata = metadata
+	if metadata != nil && metadata.Type == "scratch" && metadata.LinkedRepo != "" && git.IsGitRepo(metadata.LinkedRepo) {
+		ctx.GitDir = metadata.LinkedRepo
+	}
+	gitDir := ctx.GitDir
 
 	// Get repo name and branch
-	ctx.RepoName = git.GetRepoName(dir)
-	if branch, err := git.GetCurrentBranch(dir); err == nil {
+	ctx.RepoName = git.GetRepoName(gitDir)
+	if branch, err := git.GetCurrentBranch(gitDir); err == nil {
 		ctx.BranchName = branch
 	}
 
 	// Read DROPBAG.md
-	if dropbag, err := ReadDropbag(dir); err == nil {
-		ctx.Dropbag = dropbag
+	if sectionEnabled(SectionDropbag) {
+		if dropbag, err := ReadDropbag(dir); err == nil {
+			if dropbagHistory {
+				dropbag.History = previousDropbags(dir, dropbag)
+			}
+			ctx.Dropbag = dropbag
+		}
 	}
 
 	// Get git status
-	if status, err := git.GetStatus(dir); err == nil {
-		ctx.GitStatus = status
+	if sectionEnabled(SectionGitStatus) {
+		if status, err := git.GetStatus(gitDir); err == nil {
+			ctx.GitStatus = status
+		}
+		if ctx.BranchName != "" {
+			if info := git.CheckBranchLocal(gitDir, remote, ctx.BranchName); info.Status == git.BranchBoth {
+				ctx.Upstream = remote + "/" + ctx.BranchName
+				ctx.Ahead, ctx.Behind = info.LocalAhead, info.RemoteBehind
+			}
+		}
+	}
+
+	// Get stashes
+	if sectionEnabled(SectionStashes) {
+		if stashes, err := git.GetStashList(gitDir); err == nil {
+			ctx.Stashes = stashes
+		}
 	}
 
 	// Get recent commits
-	if commits, err := git.GetRecentCommits(dir, 5); err == nil {
-		ctx.Commits = commits
+	if sectionEnabled(SectionCommits) {
+		if commits, err := git.GetRecentCommits(gitDir, 5); err == nil {
+			ctx.Commits = commits
+		}
 	}
 
 	// Find TODOs
-	if todos, err := FindTodos(dir, 10); err == nil {
-		ctx.Todos = todos
+	if sectionEnabled(SectionTodos) {
+		if todos, err := findTodos(dir, 10); err == nil {
+			ctx.Todos = todos
+		}
 	}
 
-	// Read .clade.json metadata
-	metadata, _ := ReadCladeMetadata(dir)
-	ctx.Metadata = metadata
-
 	return ctx, nil
 }
 
@@ -73,77 +156,175 @@ func FormatContext(ctx *ContextOutput) string {
 
 	sb.WriteString("# Session Context\n\n")
 
-	// DROPBAG.md section
-	if ctx.Dropbag != nil && ctx.Dropbag.Exists {
-		sb.WriteString(fmt.Sprintf("## DROPBAG.md (from %s)\n\n", ctx.Dropbag.RelativeAge))
-		sb.WriteString(ctx.Dropbag.Content)
-		sb.WriteString("\n\n")
+	if ctx.GitDir != ctx.Dir {
+		sb.WriteString(fmt.Sprintf("This scratch folder is linked to the repo at %s. Git status and commits below are from that repo.\n\n", ctx.GitDir))
 	}
 
-	// Git Status section
-	if ctx.GitStatus != nil {
-		sb.WriteString("## Git Status\n\n")
-		sb.WriteString(fmt.Sprintf("On branch %s\n", ctx.BranchName))
+	writeDropbagSection(&sb, ctx.Dropbag)
+	writeGitStatusSection(&sb, ctx, "##")
+	writeStashesSection(&sb, ctx, "##")
+	writeCommitsSection(&sb, ctx, "##")
+	writeTodosSection(&sb, ctx, "##")
 
-		if ctx.GitStatus.Clean {
-			sb.WriteString("Working tree clean\n")
+	// Ticket section
+	if sectionEnabled(SectionTicket) && ctx.Metadata != nil && ctx.Metadata.Ticket != "" {
+		sb.WriteString("## Ticket\n\n")
+		sb.WriteString(fmt.Sprintf("%s detected. ", ctx.Metadata.Ticket))
+
+		// Check the gathered root, not cwd, which may be a subdirectory
+		ticketPath := filepath.Join(ctx.Dir, "TICKET.md")
+		if _, err := os.Stat(ticketPath); os.IsNotExist(err) {
+			sb.WriteString("Please fetch from JIRA and save to TICKET.md for reference.\n")
 		} else {
-			if len(ctx.GitStatus.StagedFiles) > 0 {
-				sb.WriteString("\nStaged changes:\n")
-				for _, f := range ctx.GitStatus.StagedFiles {
-					sb.WriteString(fmt.Sprintf("  %s\n", f))
-				}
-			}
-			if len(ctx.GitStatus.ModifiedFiles) > 0 {
-				sb.WriteString("\nModified files:\n")
-				for _, f := range ctx.GitStatus.ModifiedFiles {
-					sb.WriteString(fmt.Sprintf("  modified: %s\n", f))
-				}
-			}
-			if len(ctx.GitStatus.UntrackedFiles) > 0 {
-				sb.WriteString("\nUntracked files:\n")
-				for _, f := range ctx.GitStatus.UntrackedFiles {
-					sb.WriteString(fmt.Sprintf("  %s\n", f))
-				}
-			}
+			sb.WriteString("See TICKET.md for details.\n")
 		}
 		sb.WriteString("\n")
 	}
 
-	// Recent Commits section
-	if len(ctx.Commits) > 0 {
-		sb.WriteString("## Recent Commits\n\n")
-		for _, commit := range ctx.Commits {
-			sb.WriteString(fmt.Sprintf("%s\n", commit))
-		}
-		sb.WriteString("\n")
+	return sb.String()
+}
+
+// writeDropbagSection writes the DROPBAG.md section
+func writeDropbagSection(sb *strings.Builder, dropbag *DropbagInfo) {
+	if !sectionEnabled(SectionDropbag) || dropbag == nil || !dropbag.Exists {
+		return
 	}
+	sb.WriteString(fmt.Sprintf("## DROPBAG.md (from %s)\n\n", dropbag.RelativeAge))
+	sb.WriteString(TruncateDropbag(dropbag.Content, dropbagMaxBytes))
+	sb.WriteString("\n\n")
 
-	// Open TODOs section
-	if len(ctx.Todos) > 0 {
-		sb.WriteString("## Open TODOs\n\n")
-		for _, todo := range ctx.Todos {
-			sb.WriteString(fmt.Sprintf("%s:%d: %s\n", todo.File, todo.Line, todo.Content))
+	if len(dropbag.History) > 0 {
+		sb.WriteString("### Previous Sessions\n\n")
+		for i, archive := range dropbag.History {
+			if i == dropbagHistoryLimit {
+				sb.WriteString(fmt.Sprintf("- ...and %d more in %s\n", len(dropbag.History)-i, DropbagArchiveDir))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", archive.Time.Format("2006-01-02 15:04"), archive.Title))
 		}
 		sb.WriteString("\n")
 	}
+}
 
-	// Ticket section
-	if ctx.Metadata != nil && ctx.Metadata.Ticket != "" {
-		sb.WriteString("## Ticket\n\n")
-		sb.WriteString(fmt.Sprintf("%s detected. ", ctx.Metadata.Ticket))
+// writeGitStatusSection writes the git status section under a heading of the given level
+func writeGitStatusSection(sb *strings.Builder, ctx *ContextOutput, level string) {
+	if !sectionEnabled(SectionGitStatus) || ctx.GitStatus == nil {
+		return
+	}
+	sb.WriteString(level + " Git Status\n\n")
+	tracking := "no upstream"
+	if ctx.Upstream != "" {
+		tracking = fmt.Sprintf("%d ahead, %d behind %s", ctx.Ahead, ctx.Behind, ctx.Upstream)
+	}
+	writeGitStatus(sb, ctx.BranchName, tracking, ctx.GitStatus)
+	sb.WriteString("\n")
+}
 
-		// Check if TICKET.md exists
-		ticketPath := filepath.Join(".", "TICKET.md")
-		if _, err := os.Stat(ticketPath); os.IsNotExist(err) {
-			sb.WriteString("Please fetch from JIRA and save to TICKET.md for reference.\n")
-		} else {
-			sb.WriteString("See TICKET.md for details.\n")
+// writeGitStatus writes the branch, its tracking summary if any, and changed
+// files
+func writeGitStatus(sb *strings.Builder, branch, tracking string, status *git.Status) {
+	sb.WriteString(fmt.Sprintf("On branch %s\n", branch))
+	if tracking != "" {
+		sb.WriteString(fmt.Sprintf("(%s)\n", tracking))
+	}
+
+	if status.Clean {
+		sb.WriteString("Working tree clean\n")
+	} else {
+		if len(status.StagedFiles) > 0 {
+			sb.WriteString("\nStaged changes:\n")
+			for _, f := range status.StagedFiles {
+				sb.WriteString(fmt.Sprintf("  %s\n", f))
+			}
+		}
+		if len(status.ModifiedFiles) > 0 {
+			sb.WriteString("\nModified files:\n")
+			for _, f := range status.ModifiedFiles {
+				sb.WriteString(fmt.Sprintf("  modified: %s\n", f))
+			}
+		}
+		if len(status.DeletedFiles) > 0 {
+			sb.WriteString("\nDeleted files:\n")
+			for _, f := range status.DeletedFiles {
+				sb.WriteString(fmt.Sprintf("  deleted: %s\n", f))
+			}
+		}
+		if len(status.RenamedFiles) > 0 {
+			sb.WriteString("\nRenamed files:\n")
+			for _, r := range status.RenamedFiles {
+				sb.WriteString(fmt.Sprintf("  renamed: %s -> %s\n", r.From, r.To))
+			}
+		}
+		if len(status.UntrackedFiles) > 0 {
+			sb.WriteString("\nUntracked files:\n")
+			for _, f := range status.UntrackedFiles {
+				sb.WriteString(fmt.Sprintf("  %s\n", f))
+			}
 		}
-		sb.WriteString("\n")
 	}
+}
 
-	return sb.String()
+// writeStashesSection writes the stash list under a heading of the given level
+func writeStashesSection(sb *strings.Builder, ctx *ContextOutput, level string) {
+	if !sectionEnabled(SectionStashes) || len(ctx.Stashes) == 0 {
+		return
+	}
+	sb.WriteString(level + " Stashes\n\n")
+	for _, stash := range ctx.Stashes {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", stash.Ref, stash.Message))
+	}
+	sb.WriteString("\n")
+}
+
+// writeCommitsSection writes the recent commits section under a heading of the given level
+func writeCommitsSection(sb *strings.Builder, ctx *ContextOutput, level string) {
+	if !sectionEnabled(SectionCommits) || len(ctx.Commits) == 0 {
+		return
+	}
+	sb.WriteString(level + " Recent Commits\n\n")
+	for _, commit := range ctx.Commits {
+		sb.WriteString(fmt.Sprintf("%s\n", commit))
+	}
+	sb.WriteString("\n")
+}
+
+// writeTodosSection writes the open TODOs section under a heading of the given level
+func writeTodosSection(sb *strings.Builder, ctx *ContextOutput, level string) {
+	if !sectionEnabled(SectionTodos) || len(ctx.Todos) == 0 {
+		return
+	}
+	sb.WriteString(level + " Open TODOs\n\n")
+	for _, todo := range ctx.Todos {
+		sb.WriteString(fmt.Sprintf("%s:%d: %s\n", todo.File, todo.Line, todo.Content))
+	}
+	sb.WriteString("\n")
+}
+
+// HookOutput is the JSON a Claude Code hook can print instead of raw text
+type HookOutput struct {
+	HookSpecificOutput HookSpecificOutput `json:"hookSpecificOutput"`
+}
+
+// HookSpecificOutput carries the context added at session start
+type HookSpecificOutput struct {
+	HookEventName     string `json:"hookEventName"`
+	AdditionalContext string `json:"additionalContext"`
+}
+
+// FormatHookJSON wraps formatted context markdown in the SessionStart hook
+// JSON envelope
+func FormatHookJSON(markdown string) (string, error) {
+	output := HookOutput{
+		HookSpecificOutput: HookSpecificOutput{
+			HookEventName:     "SessionStart",
+			AdditionalContext: markdown,
+		},
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
 }
 
 // ReadCladeMetadata reads the .clade.json file from a directory
@@ -0,0 +1,186 @@
+package context
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daniil-lyalko/clade/internal/git"
+)
+
+func TestFormatHookJSONEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{"empty sections", FormatContext(&ContextOutput{})},
+		{"special characters", "# Session Context\n\n\"quoted\" <tags> & \\ backslash\ttab\n"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := FormatHookJSON(tt.markdown)
+			if err != nil {
+				t.Fatalf("FormatHookJSON: %v", err)
+			}
+			if !strings.HasSuffix(out, "}\n") {
+				t.Errorf("output should be one JSON line: %q", out)
+			}
+
+			var envelope map[string]map[string]any
+			if err := json.Unmarshal([]byte(out), &envelope); err != nil {
+				t.Fatalf("invalid JSON %q: %v", out, err)
+			}
+			if len(envelope) != 1 {
+				t.Errorf("top-level keys = %v, want only hookSpecificOutput", envelope)
+			}
+			hook, ok := envelope["hookSpecificOutput"]
+			if !ok {
+				t.Fatalf("no hookSpecificOutput in %s", out)
+			}
+			if hook["hookEventName"] != "SessionStart" {
+				t.Errorf("hookEventName = %v, want SessionStart", hook["hookEventName"])
+			}
+			if hook["additionalContext"] != tt.markdown {
+				t.Errorf("additionalContext = %q, want %q", hook["additionalContext"], tt.markdown)
+			}
+		})
+	}
+}
+
+func TestGatherContextSkipsDisabledTodoScan(t *testing.T) {
+	dir := t.TempDir()
+	var scans int
+	old := findTodos
+	findTodos = func(dir string, maxResults int) ([]TodoItem, error) {
+		scans++
+		return []TodoItem{{File: "main.go", Line: 1, Content: "TODO: x"}}, nil
+	}
+	t.Cleanup(func() {
+		findTodos = old
+		SetSections(nil)
+	})
+
+	SetSections([]string{SectionDropbag, SectionGitStatus, SectionCommits, SectionTicket})
+	ctx, err := GatherContext(dir)
+	if err != nil {
+		t.Fatalf("GatherContext: %v", err)
+	}
+	if scans != 0 {
+		t.Errorf("FindTodos ran %d times with the todos section disabled", scans)
+	}
+	if strings.Contains(FormatContext(ctx), "Open TODOs") {
+		t.Error("FormatContext included a disabled section")
+	}
+
+	SetSections(nil)
+	ctx, err = GatherContext(dir)
+	if err != nil {
+		t.Fatalf("GatherContext: %v", err)
+	}
+	if scans != 1 || len(ctx.Todos) != 1 {
+		t.Errorf("with all sections enabled: %d scans, %d todos; want 1 and 1", scans, len(ctx.Todos))
+	}
+	if !strings.Contains(FormatContext(ctx), "Open TODOs") {
+		t.Error("FormatContext omitted an enabled section")
+	}
+}
+
+// gitRun runs git in dir with a test identity, failing the test on error
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestGatherContextListsStashes(t *testing.T) {
+	dir := t.TempDir()
+	initRepo(t, dir)
+	writeTree(t, dir, map[string]string{"app.txt": "v1\n"})
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "-q", "-m", "initial")
+
+	ctx, err := GatherContext(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ctx.Stashes) != 0 || strings.Contains(FormatContext(ctx), "Stashes") {
+		t.Errorf("stashes reported for a repo without any: %+v", ctx.Stashes)
+	}
+
+	writeTree(t, dir, map[string]string{"app.txt": "v2\n"})
+	gitRun(t, dir, "stash", "push", "-q", "-m", "half-done retry logic")
+	writeTree(t, dir, map[string]string{"app.txt": "v3\n"})
+	gitRun(t, dir, "stash", "push", "-q", "-m", "debug prints")
+
+	ctx, err = GatherContext(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ctx.Stashes) != 2 {
+		t.Fatalf("got %d stashes, want 2: %+v", len(ctx.Stashes), ctx.Stashes)
+	}
+	out := FormatContext(ctx)
+	section := "## Stashes\n\n" +
+		"stash@{0}: On main: debug prints\n" +
+		"stash@{1}: On main: half-done retry logic\n"
+	if !strings.Contains(out, section) {
+		t.Errorf("FormatContext is missing\n%s\nin\n%s", section, out)
+	}
+}
+
+func TestFormatContextAheadBehind(t *testing.T) {
+	clean := &git.Status{Clean: true}
+	tests := []struct {
+		ctx  ContextOutput
+		want string
+	}{
+		{ContextOutput{BranchName: "exp/a", GitStatus: clean, Upstream: "origin/exp/a", Ahead: 2, Behind: 1}, "On branch exp/a\n(2 ahead, 1 behind origin/exp/a)\n"},
+		{ContextOutput{BranchName: "exp/a", GitStatus: clean, Upstream: "upstream/exp/a"}, "(0 ahead, 0 behind upstream/exp/a)\n"},
+		{ContextOutput{BranchName: "exp/b", GitStatus: clean}, "On branch exp/b\n(no upstream)\n"},
+	}
+	for _, tt := range tests {
+		if out := FormatContext(&tt.ctx); !strings.Contains(out, tt.want) {
+			t.Errorf("want %q in:\n%s", tt.want, out)
+		}
+	}
+}
+
+func TestGatherContextCountsAheadBehind(t *testing.T) {
+	origin := t.TempDir()
+	initRepo(t, origin)
+	writeTree(t, origin, map[string]string{"a.txt": "1\n"})
+	gitRun(t, origin, "add", "-A")
+	gitRun(t, origin, "commit", "-q", "-m", "initial")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	gitRun(t, origin, "clone", "-q", origin, clone)
+	for _, name := range []string{"b.txt", "c.txt"} {
+		writeTree(t, clone, map[string]string{name: "local\n"})
+		gitRun(t, clone, "add", "-A")
+		gitRun(t, clone, "commit", "-q", "-m", "local "+name)
+	}
+	writeTree(t, origin, map[string]string{"d.txt": "remote\n"})
+	gitRun(t, origin, "add", "-A")
+	gitRun(t, origin, "commit", "-q", "-m", "remote work")
+	gitRun(t, clone, "fetch", "-q")
+
+	ctx, err := GatherContext(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Upstream != "origin/main" || ctx.Ahead != 2 || ctx.Behind != 1 {
+		t.Errorf("got upstream %q, %d ahead, %d behind; want origin/main, 2, 1", ctx.Upstream, ctx.Ahead, ctx.Behind)
+	}
+}
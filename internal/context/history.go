@@ -0,0 +1,157 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DropbagArchiveDir is where archived dropbags are kept, relative to a worktree
+const DropbagArchiveDir = ".clade/dropbags"
+
+// dropbagArchiveLayout is the timestamp format in archived dropbag file names
+const dropbagArchiveLayout = "20060102-150405"
+
+// dropbagHistoryLimit caps how many previous sessions FormatContext lists
+const dropbagHistoryLimit = 5
+
+// dropbagHistory enables archiving and the previous sessions index
+var dropbagHistory bool
+
+// SetDropbagHistory enables listing archived dropbags in the session context
+func SetDropbagHistory(enabled bool) {
+	dropbagHistory = enabled
+}
+
+// DropbagArchive is one archived DROPBAG.md
+type DropbagArchive struct {
+	Path  string
+	Time  time.Time
+	Title string
+}
+
+// ArchiveDropbag copies DROPBAG.md in dir to the archive, named after its
+// modification time. It returns the archive path, or "" when there is no
+// DROPBAG.md or it was already archived.
+func ArchiveDropbag(dir string) (string, error) {
+	src := filepath.Join(dir, "DROPBAG.md")
+	stat, err := os.Stat(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	archiveDir := filepath.Join(dir, DropbagArchiveDir)
+	dst := filepath.Join(archiveDir, "DROPBAG-"+stat.ModTime().Format(dropbagArchiveLayout)+".md")
+	if _, err := os.Stat(dst); err == nil {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return "", err
+	}
+	// Keep the archive's time in step with the original for the file name check above
+	_ = os.Chtimes(dst, stat.ModTime(), stat.ModTime())
+
+	return dst, nil
+}
+
+// ListDropbags returns the archived dropbags in dir, newest first
+func ListDropbags(dir string) ([]DropbagArchive, error) {
+	archiveDir := filepath.Join(dir, DropbagArchiveDir)
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var archives []DropbagArchive
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "DROPBAG-") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, "DROPBAG-"), ".md")
+		t, err := time.ParseInLocation(dropbagArchiveLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(archiveDir, name)
+		archive := DropbagArchive{Path: path, Time: t}
+		if data, err := os.ReadFile(path); err == nil {
+			archive.Title = dropbagTitle(string(data))
+		}
+		archives = append(archives, archive)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Time.After(archives[j].Time)
+	})
+	return archives, nil
+}
+
+// dropbagTitle picks a one-line title for a dropbag: the first line of its
+// Summary section, or failing that its first line of text
+func dropbagTitle(content string) string {
+	var first string
+	inSummary := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			inSummary = strings.EqualFold(strings.TrimSpace(strings.TrimLeft(line, "#")), "summary")
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		if inSummary {
+			return truncateTitle(line)
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	return truncateTitle(first)
+}
+
+// truncateTitle shortens a title to fit on one index line
+func truncateTitle(title string) string {
+	const maxLen = 80
+	runes := []rune(title)
+	if len(runes) <= maxLen {
+		return title
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
+
+// previousDropbags lists archived dropbags in dir other than the current one
+func previousDropbags(dir string, current *DropbagInfo) []DropbagArchive {
+	archives, err := ListDropbags(dir)
+	if err != nil {
+		return nil
+	}
+
+	var previous []DropbagArchive
+	for _, archive := range archives {
+		if current != nil && current.Exists && archive.Time.Equal(current.ModTime.Truncate(time.Second)) {
+			continue
+		}
+		previous = append(previous, archive)
+	}
+	return previous
+}
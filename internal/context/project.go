@@ -0,0 +1,137 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectMetadata represents the .clade-project.json file
+type ProjectMetadata struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Branch string `json:"branch"`
+	Repos  []struct {
+		Name   string `json:"name"`
+		Source string `json:"source"`
+		Branch string `json:"branch,omitempty"`
+	} `json:"repos"`
+	Created string `json:"created"`
+}
+
+// ProjectContextOutput holds the context for every repo in a project
+type ProjectContextOutput struct {
+	Name        string
+	Branch      string
+	Dropbag     *DropbagInfo
+	CurrentRepo string
+	Repos       []*ContextOutput
+}
+
+// ReadProjectMetadata reads the .clade-project.json file from a project directory
+func ReadProjectMetadata(dir string) (*ProjectMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ".clade-project.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata ProjectMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+// FindProjectRoot returns the project directory for dir, which is either the
+// project itself or one of its repo worktrees
+func FindProjectRoot(dir string) (string, bool) {
+	for _, candidate := range []string{dir, filepath.Dir(dir)} {
+		if _, err := os.Stat(filepath.Join(candidate, ".clade-project.json")); err == nil {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// GatherProjectContext collects context for each repo in a project. currentDir
+// is the repo the session started in, or the project root. DROPBAG.md comes
+// from the project root, falling back to the current repo's.
+func GatherProjectContext(projectDir, currentDir string) (*ProjectContextOutput, error) {
+	metadata, err := ReadProjectMetadata(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project metadata: %w", err)
+	}
+
+	ctx := &ProjectContextOutput{
+		Name:   metadata.Name,
+		Branch: metadata.Branch,
+	}
+	if currentDir != projectDir {
+		ctx.CurrentRepo = filepath.Base(currentDir)
+	}
+
+	if sectionEnabled(SectionDropbag) {
+		if dropbag, err := ReadDropbag(projectDir); err == nil {
+			if dropbagHistory {
+				dropbag.History = previousDropbags(projectDir, dropbag)
+			}
+			ctx.Dropbag = dropbag
+		}
+	}
+
+	for _, repo := range metadata.Repos {
+		repoCtx, err := GatherContext(filepath.Join(projectDir, repo.Name))
+		if err != nil {
+			continue
+		}
+		repoCtx.RepoName = repo.Name
+		ctx.Repos = append(ctx.Repos, repoCtx)
+
+		if repo.Name == ctx.CurrentRepo && (ctx.Dropbag == nil || !ctx.Dropbag.Exists) {
+			ctx.Dropbag = repoCtx.Dropbag
+		}
+	}
+
+	return ctx, nil
+}
+
+// FormatProjectContext formats project context for output to Claude, with a
+// section per repo
+func FormatProjectContext(ctx *ProjectContextOutput) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Session Context: project %s\n\n", ctx.Name))
+	if ctx.Branch != "" {
+		sb.WriteString(fmt.Sprintf("Branch %s in %d repo(s).", ctx.Branch, len(ctx.Repos)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%d repo(s).", len(ctx.Repos)))
+	}
+	if ctx.CurrentRepo != "" {
+		sb.WriteString(fmt.Sprintf(" This session started in %s.", ctx.CurrentRepo))
+	}
+	sb.WriteString("\n\n")
+
+	writeDropbagSection(&sb, ctx.Dropbag)
+
+	for _, repo := range ctx.Repos {
+		heading := fmt.Sprintf("## Repo: %s", repo.RepoName)
+		if repo.RepoName == ctx.CurrentRepo {
+			heading += " (current)"
+		}
+		sb.WriteString(heading + "\n\n")
+
+		if repo.GitStatus == nil && len(repo.Commits) == 0 && len(repo.Todos) == 0 {
+			sb.WriteString("No git information available.\n\n")
+			continue
+		}
+		writeGitStatusSection(&sb, repo, "###")
+		writeStashesSection(&sb, repo, "###")
+		writeCommitsSection(&sb, repo, "###")
+		writeTodosSection(&sb, repo, "###")
+	}
+
+	return sb.String()
+}
@@ -5,7 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+
+	"github.com/daniil-lyalko/clade/internal/git"
 )
 
 // TodoItem represents a TODO comment found in code
@@ -35,6 +39,57 @@ var scanExtensions = map[string]bool{
 	".yml":  true,
 }
 
+// Keywords that mark a TODO comment
+var defaultTodoKeywords = []string{"TODO", "FIXME", "HACK", "XXX", "BUG"}
+
+// todoPattern matches a TODO keyword and the rest of the line
+var todoPattern = compileTodoPattern(defaultTodoKeywords)
+
+// SetTodoOptions adds file extensions and keywords to the TODO scan on top of
+// the defaults
+func SetTodoOptions(extensions, keywords []string) {
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		scanExtensions[ext] = true
+	}
+
+	if len(keywords) > 0 {
+		todoPattern = compileTodoPattern(append(append([]string{}, defaultTodoKeywords...), keywords...))
+	}
+}
+
+// compileTodoPattern builds a case-insensitive pattern for the keywords. Word
+// boundaries are only added next to word characters, so keywords like
+// "@todo" still match.
+func compileTodoPattern(keywords []string) *regexp.Regexp {
+	var alternatives []string
+	for _, kw := range keywords {
+		kw = strings.TrimSuffix(strings.TrimSpace(kw), ":")
+		if kw == "" {
+			continue
+		}
+		alt := regexp.QuoteMeta(kw)
+		if isWordChar(kw[0]) {
+			alt = `\b` + alt
+		}
+		if isWordChar(kw[len(kw)-1]) {
+			alt += `\b`
+		}
+		alternatives = append(alternatives, alt)
+	}
+	return regexp.MustCompile(`(?i)(` + strings.Join(alternatives, "|") + `)[:\s]*(.*)`)
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
 // Directories to skip
 var skipDirs = map[string]bool{
 	"node_modules": true,
@@ -47,10 +102,43 @@ var skipDirs = map[string]bool{
 	"target":       true,
 }
 
-// FindTodos scans a directory for TODO comments in source files
+// maxTodoFileSize skips files too large to be hand-written source, such as
+// minified bundles and generated code
+const maxTodoFileSize = 1 << 20
+
+// todoBatchSize is how many files are checked against .gitignore and scanned
+// at once
+const todoBatchSize = 500
+
+// todoScanWorkers bounds how many files are scanned concurrently
+const todoScanWorkers = 8
+
+// FindTodos scans a directory for TODO comments in source files. Gitignored
+// files are skipped when dir is inside a git repo. Files are scanned in
+// parallel, a batch at a time, so a few more TODOs than maxResults may be
+// found; results are sorted by file and line before trimming.
 func FindTodos(dir string, maxResults int) ([]TodoItem, error) {
 	var todos []TodoItem
-	todoPattern := regexp.MustCompile(`(?i)\b(TODO|FIXME|HACK|XXX|BUG)\b[:\s]*(.*)`)
+	checkIgnored := git.IsGitRepo(dir)
+
+	// Files are collected in batches so each batch costs one git check-ignore
+	var batch []string
+	scanBatch := func() {
+		ignored := map[string]bool{}
+		if checkIgnored {
+			if result, err := git.IgnoredPaths(dir, batch); err == nil {
+				ignored = result
+			}
+		}
+		var files []string
+		for _, relPath := range batch {
+			if !ignored[relPath] {
+				files = append(files, relPath)
+			}
+		}
+		todos = append(todos, scanFilesForTodos(dir, files)...)
+		batch = batch[:0]
+	}
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -70,31 +158,36 @@ func FindTodos(dir string, maxResults int) ([]TodoItem, error) {
 			return filepath.SkipAll
 		}
 
-		// Check file extension
+		// Check file extension and size
 		ext := strings.ToLower(filepath.Ext(path))
-		if !scanExtensions[ext] {
+		if !scanExtensions[ext] || info.Size() > maxTodoFileSize {
 			return nil
 		}
 
-		// Scan file for TODOs
-		fileTodos, err := scanFileForTodos(path, todoPattern)
+		relPath, err := filepath.Rel(dir, path)
 		if err != nil {
-			return nil // Skip files we can't read
+			return nil
 		}
-
-		// Make paths relative
-		relPath, _ := filepath.Rel(dir, path)
-		for i := range fileTodos {
-			fileTodos[i].File = relPath
+		batch = append(batch, relPath)
+		if len(batch) >= todoBatchSize {
+			scanBatch()
 		}
-
-		todos = append(todos, fileTodos...)
 		return nil
 	})
 
 	if err != nil && err != filepath.SkipAll {
 		return nil, err
 	}
+	if len(batch) > 0 && len(todos) < maxResults {
+		scanBatch()
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		if todos[i].File != todos[j].File {
+			return todos[i].File < todos[j].File
+		}
+		return todos[i].Line < todos[j].Line
+	})
 
 	// Limit results
 	if len(todos) > maxResults {
@@ -104,6 +197,43 @@ func FindTodos(dir string, maxResults int) ([]TodoItem, error) {
 	return todos, nil
 }
 
+// scanFilesForTodos scans files (relative to dir) with a pool of workers and
+// returns their TODOs with relative paths
+func scanFilesForTodos(dir string, files []string) []TodoItem {
+	results := make([][]TodoItem, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := min(todoScanWorkers, len(files))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fileTodos, err := scanFileForTodos(filepath.Join(dir, files[i]), todoPattern)
+				if err != nil {
+					continue // Skip files we can't read
+				}
+				for j := range fileTodos {
+					fileTodos[j].File = files[i]
+				}
+				results[i] = fileTodos
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var todos []TodoItem
+	for _, fileTodos := range results {
+		todos = append(todos, fileTodos...)
+	}
+	return todos
+}
+
 func scanFileForTodos(path string, pattern *regexp.Regexp) ([]TodoItem, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,203 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTree creates files (path -> content) under dir
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// restoreTodoOptions undoes SetTodoOptions at the end of a test
+func restoreTodoOptions(t *testing.T) {
+	t.Helper()
+	oldPattern := todoPattern
+	oldExtensions := make(map[string]bool, len(scanExtensions))
+	for ext := range scanExtensions {
+		oldExtensions[ext] = true
+	}
+	t.Cleanup(func() {
+		todoPattern = oldPattern
+		scanExtensions = oldExtensions
+	})
+}
+
+func TestSetTodoOptionsAddsKeywordsAndExtensions(t *testing.T) {
+	restoreTodoOptions(t)
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"App.kt":  "// NOTE: check the cache\nfun main() {}\n",
+		"view.go": "// @pending wire up\n// TODO: keep defaults\n// notebook isn't a keyword\n",
+	})
+
+	todos, err := FindTodos(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 1 || todos[0].Content != "TODO: keep defaults" {
+		t.Fatalf("defaults: got %+v, want only the TODO in view.go", todos)
+	}
+
+	SetTodoOptions([]string{"kt", " .Swift "}, []string{"NOTE:", "@pending"})
+	todos, err = FindTodos(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TodoItem{
+		{File: "App.kt", Line: 1, Content: "NOTE: check the cache"},
+		{File: "view.go", Line: 1, Content: "@pending wire up"},
+		{File: "view.go", Line: 2, Content: "TODO: keep defaults"},
+	}
+	if len(todos) != len(want) {
+		t.Fatalf("got %+v, want %+v", todos, want)
+	}
+	for i := range want {
+		if todos[i] != want[i] {
+			t.Errorf("todo %d = %+v, want %+v", i, todos[i], want[i])
+		}
+	}
+	if !scanExtensions[".swift"] {
+		t.Error("extension ' .Swift ' wasn't normalized to .swift")
+	}
+}
+
+// initRepo turns dir into a git repo, isolated from the user's git config
+func initRepo(t testing.TB, dir string) {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	if out, err := exec.Command("git", "init", "-q", "-b", "main", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+}
+
+func TestFindTodosSkipsIgnoredAndOversizedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		".gitignore":          "generated/\n*.gen.go\n",
+		"main.go":             "// TODO: real one\n",
+		"generated/api.go":    "// TODO: generated\n",
+		"zz.gen.go":           "// TODO: generated file\n",
+		"node_modules/x/a.js": "// TODO: dependency\n",
+		"bundle.min.js":       "// TODO: minified\n" + strings.Repeat("x", maxTodoFileSize),
+	})
+	initRepo(t, dir)
+
+	todos, err := FindTodos(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 1 || todos[0].File != "main.go" {
+		t.Errorf("got %+v, want only main.go's TODO", todos)
+	}
+}
+
+// todoFixture writes dirs*files Go files with a few TODOs each
+func todoFixture(tb testing.TB, dirs, files int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for d := 0; d < dirs; d++ {
+		sub := filepath.Join(dir, fmt.Sprintf("d%02d", d))
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		for f := 0; f < files; f++ {
+			var sb strings.Builder
+			for line := 0; line < 40; line++ {
+				if line%13 == f%13 {
+					fmt.Fprintf(&sb, "// TODO: d%d f%d line %d\n", d, f, line)
+				} else {
+					sb.WriteString("func noop() {}\n")
+				}
+			}
+			if err := os.WriteFile(filepath.Join(sub, fmt.Sprintf("f%03d.go", f)), []byte(sb.String()), 0644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+	return dir
+}
+
+// serialTodos is the straightforward one-file-at-a-time scan FindTodos must
+// agree with
+func serialTodos(t *testing.T, dir string) []TodoItem {
+	t.Helper()
+	var todos []TodoItem
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !scanExtensions[filepath.Ext(path)] {
+			return err
+		}
+		fileTodos, err := scanFileForTodos(path, todoPattern)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(dir, path)
+		for _, todo := range fileTodos {
+			todo.File = relPath
+			todos = append(todos, todo)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return todos
+}
+
+func TestFindTodosMatchesSerialScan(t *testing.T) {
+	// More files than one batch, so several batches and workers are involved
+	dir := todoFixture(t, 6, 120)
+	want := serialTodos(t, dir)
+
+	for _, maxResults := range []int{10, 700, len(want) + 100} {
+		limit := min(maxResults, len(want))
+		for run := 0; run < 3; run++ {
+			got, err := FindTodos(dir, maxResults)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != limit {
+				t.Fatalf("max %d: got %d todos, want %d", maxResults, len(got), limit)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("max %d run %d: todo %d = %+v, want %+v", maxResults, run, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkFindTodos(b *testing.B) {
+	plain := todoFixture(b, 10, 200)
+	repo := todoFixture(b, 10, 200)
+	initRepo(b, repo)
+
+	for _, bc := range []struct{ name, dir string }{
+		{"plain", plain},
+		{"git repo", repo}, // adds a batched check-ignore per 500 files
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FindTodos(bc.dir, 10000); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
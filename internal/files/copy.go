@@ -1,14 +1,16 @@
 package files
 
 import (
-	"bufio"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/daniil-lyalko/clade/internal/git"
 )
 
-// CommonIgnoredFiles are files commonly gitignored but needed for running
+// CommonIgnoredFiles are files commonly gitignored but needed for running.
+// Entries may be files, directories (copied recursively), or glob patterns.
 var CommonIgnoredFiles = []string{
 	".env",
 	".env.local",
@@ -19,19 +21,36 @@ var CommonIgnoredFiles = []string{
 	"config/local.yaml",
 	"config/local.yml",
 	".vscode/settings.json",
+	"certs",
+	"config/secrets",
+	"*.pem",
+	"*.key",
+}
+
+// symlink makes CopyFiles link entries back to the source repo instead of
+// copying them
+var symlink bool
+
+// SetSymlink enables or disables symlink mode. Symlinked files are shared
+// live between the source repo and every worktree.
+func SetSymlink(enabled bool) {
+	symlink = enabled
+}
+
+// IsSymlink reports whether symlink mode is enabled
+func IsSymlink() bool {
+	return symlink
 }
 
 // FindGitignored finds files that exist in repoPath but are gitignored
 func FindGitignored(repoPath string) []string {
 	var found []string
 
-	// Check common patterns
+	// Check common patterns. Globs are kept as patterns so files that
+	// match later are picked up too.
 	for _, pattern := range CommonIgnoredFiles {
-		fullPath := filepath.Join(repoPath, pattern)
-		if _, err := os.Stat(fullPath); err == nil {
-			if isGitignored(repoPath, pattern) {
-				found = append(found, pattern)
-			}
+		if len(Expand(repoPath, pattern)) > 0 {
+			found = append(found, pattern)
 		}
 	}
 
@@ -75,60 +94,174 @@ func FindGitignored(repoPath string) []string {
 	return found
 }
 
-// isGitignored checks if a file is in .gitignore
+// isGitignored checks if a file is ignored by git
 func isGitignored(repoPath, relPath string) bool {
-	gitignorePath := filepath.Join(repoPath, ".gitignore")
-	file, err := os.Open(gitignorePath)
+	return git.IsIgnored(repoPath, relPath)
+}
+
+// IsGlob reports whether an entry is a glob pattern rather than a path
+func IsGlob(entry string) bool {
+	return strings.ContainsAny(entry, "*?[")
+}
+
+// Expand resolves a copy entry to the gitignored paths it matches in srcDir,
+// relative to srcDir. Plain files and directories match themselves; globs
+// match every ignored file or directory they resolve to. Tracked matches
+// are skipped so a glob never overwrites versioned files.
+func Expand(srcDir, entry string) []string {
+	if !IsGlob(entry) {
+		if _, err := os.Stat(filepath.Join(srcDir, entry)); err != nil {
+			return nil
+		}
+		if !isGitignored(srcDir, entry) {
+			return nil
+		}
+		return []string{entry}
+	}
+
+	matches, err := filepath.Glob(filepath.Join(srcDir, entry))
 	if err != nil {
-		return false
+		return nil
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
+	var paths []string
+	for _, match := range matches {
+		relPath, err := filepath.Rel(srcDir, match)
+		if err != nil {
 			continue
 		}
-
-		// Simple pattern matching (exact match or prefix with *)
-		if line == relPath {
-			return true
-		}
-		if strings.HasPrefix(line, "*.") && strings.HasSuffix(relPath, line[1:]) {
-			return true
+		if isGitignored(srcDir, relPath) {
+			paths = append(paths, relPath)
 		}
-		if strings.HasSuffix(line, "/") && strings.HasPrefix(relPath, line) {
-			return true
+	}
+	return paths
+}
+
+// CopyFiles copies the given entries from src to dst directory. Entries may
+// be files, directories, or glob patterns (see Expand); entries that match
+// nothing are skipped. In symlink mode, ignored entries are linked instead.
+func CopyFiles(srcDir, dstDir string, entries []string) error {
+	for _, entry := range entries {
+		paths := []string{entry}
+		if IsGlob(entry) {
+			paths = Expand(srcDir, entry)
 		}
-		// Handle patterns like .env*
-		if strings.HasSuffix(line, "*") {
-			prefix := strings.TrimSuffix(line, "*")
-			if strings.HasPrefix(relPath, prefix) {
-				return true
+
+		for _, relPath := range paths {
+			srcPath := filepath.Join(srcDir, relPath)
+			dstPath := filepath.Join(dstDir, relPath)
+
+			info, err := os.Stat(srcPath)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			// Only link ignored paths - a link in place of a tracked
+			// file would show up as a change in the worktree
+			if symlink && isGitignored(srcDir, relPath) {
+				if err := linkFile(srcPath, dstPath); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if info.IsDir() {
+				if err := CopyDir(srcPath, dstPath); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Ensure destination directory exists
+			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+				return err
+			}
+
+			if err := copyFile(srcPath, dstPath); err != nil {
+				return err
 			}
 		}
 	}
-
-	return false
+	return nil
 }
 
-// CopyFiles copies specified files from src to dst directory
-func CopyFiles(srcDir, dstDir string, files []string) error {
-	for _, relPath := range files {
-		srcPath := filepath.Join(srcDir, relPath)
-		dstPath := filepath.Join(dstDir, relPath)
-
-		// Ensure destination directory exists
-		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+// CopyDir recursively copies a directory tree. Symlinks are recreated as
+// links rather than followed, and nested .git entries are skipped.
+func CopyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
 			return err
 		}
 
-		if err := copyFile(srcPath, dstPath); err != nil {
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
 			return err
 		}
+		dstPath := filepath.Join(dst, relPath)
+
+		if info.Name() == ".git" && path != src {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(dstPath, info.Mode())
+		case info.Mode()&os.ModeSymlink != 0:
+			return copySymlink(path, dstPath)
+		}
+		return copyFile(path, dstPath)
+	})
+}
+
+// copySymlink recreates the symlink src at dst with the same target
+func copySymlink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
 	}
-	return nil
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Symlink(target, dst)
+}
+
+// linkFile replaces dst with a relative symlink to src. If src is itself a
+// symlink, the link points at its final target.
+func linkFile(src, dst string) error {
+	target, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	// Resolve the link's directory too so the relative path holds when
+	// either side lives under a symlinked directory
+	dstDir, err := filepath.EvalSymlinks(filepath.Dir(dst))
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(dstDir, target)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Symlink(rel, dst)
 }
 
 func copyFile(src, dst string) error {
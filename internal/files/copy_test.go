@@ -0,0 +1,191 @@
+package files
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newIgnoreRepo creates a git repo holding files (path -> content)
+func newIgnoreRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestIsGitignoredMatchesGit(t *testing.T) {
+	repo := newIgnoreRepo(t, map[string]string{
+		".gitignore":        "*.key\n!public.key\nbuild/\n",
+		"config/.gitignore": "local.json\n",
+		"private.key":       "secret",
+		"public.key":        "shared",
+		"build/out.txt":     "artifact",
+		"config/local.json": "{}",
+		"config/app.json":   "{}",
+		"local.json":        "{}",
+	})
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"private.key", true},
+		{"public.key", false},       // negated
+		{"build/out.txt", true},     // directory pattern
+		{"config/local.json", true}, // nested .gitignore
+		{"config/app.json", false},
+		{"local.json", false}, // nested pattern doesn't apply above its dir
+	}
+	for _, tt := range tests {
+		if got := isGitignored(repo, tt.path); got != tt.want {
+			t.Errorf("isGitignored(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+		gitSays := exec.Command("git", "-C", repo, "check-ignore", "-q", "--", tt.path).Run() == nil
+		if gitSays != tt.want {
+			t.Errorf("git check-ignore disagrees on %s: %v", tt.path, gitSays)
+		}
+	}
+}
+
+func TestFindGitignoredConfirmsCandidatesThroughGit(t *testing.T) {
+	repo := newIgnoreRepo(t, map[string]string{
+		".gitignore":        ".env*\n!.env.example\n*.pem\n",
+		"config/.gitignore": "local.yml\n",
+		".env":              "A=1",
+		".env.example":      "A=",
+		"config/local.yml":  "debug: true",
+		"config/local.json": "{}",
+		"public.key":        "not ignored",
+	})
+
+	found := FindGitignored(repo)
+	for _, want := range []string{".env", "config/local.yml"} {
+		if !contains(found, want) {
+			t.Errorf("FindGitignored missed %s: %v", want, found)
+		}
+	}
+	for _, unwanted := range []string{".env.example", "config/local.json", "*.key", "*.pem"} {
+		if contains(found, unwanted) {
+			t.Errorf("FindGitignored reported %s, which git doesn't ignore or doesn't exist: %v", unwanted, found)
+		}
+	}
+}
+
+func TestCopyFilesCopiesDirectoriesAndGlobs(t *testing.T) {
+	src := newIgnoreRepo(t, map[string]string{
+		".gitignore":         "certs/\n*.pem\n",
+		"certs/ca.crt":       "ca",
+		"certs/nested/a.crt": "nested",
+		"server.pem":         "server",
+		"client.pem":         "client",
+		"tracked.pem":        "versioned",
+	})
+	if out, err := exec.Command("git", "-C", src, "add", "-f", "tracked.pem").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v\n%s", err, out)
+	}
+	dst := t.TempDir()
+
+	if err := CopyFiles(src, dst, []string{"certs", "*.pem", "missing.key"}); err != nil {
+		t.Fatalf("CopyFiles: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		"certs/ca.crt":       "ca",
+		"certs/nested/a.crt": "nested",
+		"server.pem":         "server",
+		"client.pem":         "client",
+	} {
+		got, err := os.ReadFile(filepath.Join(dst, path))
+		if err != nil {
+			t.Errorf("%s wasn't copied: %v", path, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+	// Globs never pick up tracked files
+	if _, err := os.Stat(filepath.Join(dst, "tracked.pem")); err == nil {
+		t.Error("tracked.pem matched the glob and was copied")
+	}
+}
+
+func TestExpandKeepsGlobRelativeToSource(t *testing.T) {
+	src := newIgnoreRepo(t, map[string]string{
+		".gitignore": "*.pem\n",
+		"a.pem":      "a",
+		"keys/b.pem": "b",
+		"notes.txt":  "not a match",
+	})
+	got := Expand(src, "*.pem")
+	if len(got) != 1 || got[0] != "a.pem" {
+		t.Errorf("Expand(*.pem) = %q, want [a.pem]", got)
+	}
+	got = Expand(src, "keys/*.pem")
+	if len(got) != 1 || got[0] != filepath.Join("keys", "b.pem") {
+		t.Errorf("Expand(keys/*.pem) = %q, want [keys/b.pem]", got)
+	}
+}
+
+func TestCopyDirPreservesSymlinksAndSkipsGit(t *testing.T) {
+	src := filepath.Join(t.TempDir(), ".claude")
+	for path, content := range map[string]string{
+		"shared/settings.json": `{"hooks":{}}`,
+		"commands/review.md":   "review",
+		"vendor/.git/HEAD":     "ref: refs/heads/main\n",
+		"vendor/tool.md":       "tool",
+	} {
+		full := filepath.Join(src, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join("shared", "settings.json"), filepath.Join(src, "settings.json")); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(t.TempDir(), ".claude")
+
+	if err := CopyDir(src, dst); err != nil {
+		t.Fatalf("CopyDir: %v", err)
+	}
+
+	link := filepath.Join(dst, "settings.json")
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("settings.json wasn't copied: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatal("settings.json was copied as a regular file, want a symlink")
+	}
+	if target, _ := os.Readlink(link); target != filepath.Join("shared", "settings.json") {
+		t.Errorf("settings.json links to %q, want shared/settings.json", target)
+	}
+	if got, err := os.ReadFile(link); err != nil || string(got) != `{"hooks":{}}` {
+		t.Errorf("settings.json resolves to %q, %v", got, err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dst, "vendor", "tool.md")); err != nil || string(got) != "tool" {
+		t.Errorf("vendor/tool.md = %q, %v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "vendor", ".git")); !os.IsNotExist(err) {
+		t.Errorf("nested .git was copied: %v", err)
+	}
+}
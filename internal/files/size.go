@@ -0,0 +1,25 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirSize returns the total size in bytes of the files under dir.
+// Symlinks are not followed and unreadable entries are skipped.
+func DirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -1,12 +1,16 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// preflightWorkers bounds how many repos PreflightCheck fetches at once
+const preflightWorkers = 4
+
 // BranchStatus represents where a branch exists
 type BranchStatus int
 
@@ -25,16 +29,36 @@ type BranchInfo struct {
 	Diverged     bool
 }
 
-// CheckBranch checks if a branch exists locally and/or on remote
-func CheckBranch(repoPath, branch string) BranchInfo {
+// CheckBranch checks if a branch exists locally and/or on the given remote
+func CheckBranch(repoPath, remote, branch string) BranchInfo {
 	info := BranchInfo{Status: BranchNotFound}
 
 	localExists := branchExistsLocal(repoPath, branch)
-	remoteExists := branchExistsRemote(repoPath, branch)
+	remoteExists := !offline && branchExistsRemote(repoPath, remote, branch)
 
 	if localExists && remoteExists {
 		info.Status = BranchBoth
-		info.LocalAhead, info.RemoteBehind, info.Diverged = getBranchDivergence(repoPath, branch)
+		info.LocalAhead, info.RemoteBehind, info.Diverged = getBranchDivergence(repoPath, remote, branch)
+	} else if localExists {
+		info.Status = BranchLocalOnly
+	} else if remoteExists {
+		info.Status = BranchRemoteOnly
+	}
+
+	return info
+}
+
+// CheckBranchLocal is CheckBranch without the network: the remote side comes
+// from the remote-tracking ref as of the last fetch
+func CheckBranchLocal(repoPath, remote, branch string) BranchInfo {
+	info := BranchInfo{Status: BranchNotFound}
+
+	localExists := branchExistsLocal(repoPath, branch)
+	remoteExists := RefExists(repoPath, "refs/remotes/"+remote+"/"+branch)
+
+	if localExists && remoteExists {
+		info.Status = BranchBoth
+		info.LocalAhead, info.RemoteBehind, info.Diverged = getBranchDivergence(repoPath, remote, branch)
 	} else if localExists {
 		info.Status = BranchLocalOnly
 	} else if remoteExists {
@@ -46,16 +70,13 @@ func CheckBranch(repoPath, branch string) BranchInfo {
 
 // branchExistsLocal checks if branch exists locally
 func branchExistsLocal(repoPath, branch string) bool {
-	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
-	cmd.Dir = repoPath
-	return cmd.Run() == nil
+	_, err := runGit(context.Background(), repoPath, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
 }
 
-// branchExistsRemote checks if branch exists on origin
-func branchExistsRemote(repoPath, branch string) bool {
-	cmd := exec.Command("git", "ls-remote", "--heads", "origin", branch)
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+// branchExistsRemote checks if branch exists on the remote
+func branchExistsRemote(repoPath, remote, branch string) bool {
+	output, err := runGit(context.Background(), repoPath, "ls-remote", "--heads", remote, branch)
 	if err != nil {
 		return false
 	}
@@ -63,101 +84,196 @@ func branchExistsRemote(repoPath, branch string) bool {
 }
 
 // getBranchDivergence returns local ahead, remote ahead, and whether diverged
-func getBranchDivergence(repoPath, branch string) (localAhead, remoteAhead int, diverged bool) {
-	cmd := exec.Command("git", "rev-list", "--left-right", "--count", branch+"...origin/"+branch)
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+func getBranchDivergence(repoPath, remote, branch string) (localAhead, remoteAhead int, diverged bool) {
+	localAhead, remoteAhead, err := AheadBehind(repoPath, remote+"/"+branch, branch)
 	if err != nil {
 		return 0, 0, false
 	}
+	return localAhead, remoteAhead, localAhead > 0 && remoteAhead > 0
+}
+
+// AheadBehind counts the commits branch has that base doesn't (ahead) and
+// the commits base has that branch doesn't (behind)
+func AheadBehind(repoPath, base, branch string) (ahead, behind int, err error) {
+	output, err := runGit(context.Background(), repoPath, "rev-list", "--left-right", "--count", branch+"..."+base)
+	if err != nil {
+		return 0, 0, err
+	}
 
 	parts := strings.Fields(strings.TrimSpace(string(output)))
-	if len(parts) == 2 {
-		localAhead, _ = strconv.Atoi(parts[0])
-		remoteAhead, _ = strconv.Atoi(parts[1])
-		diverged = localAhead > 0 && remoteAhead > 0
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	ahead, _ = strconv.Atoi(parts[0])
+	behind, _ = strconv.Atoi(parts[1])
+	return ahead, behind, nil
+}
+
+// ListLocalBranches returns the names of all local branches
+func ListLocalBranches(repoPath string) ([]string, error) {
+	output, err := runGit(context.Background(), repoPath, "for-each-ref", "--format=%(refname)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if branch, ok := strings.CutPrefix(line, "refs/heads/"); ok {
+			branches = append(branches, branch)
+		}
 	}
-	return
+	return branches, nil
 }
 
-// Fetch fetches from origin
-func Fetch(repoPath string) error {
-	cmd := exec.Command("git", "fetch", "origin")
-	cmd.Dir = repoPath
-	return cmd.Run()
+// Fetch fetches from the remote. Callers treat failures (including
+// timeouts, see IsTimeout) as being offline.
+func Fetch(repoPath, remote string) error {
+	if offline {
+		return nil
+	}
+	_, err := runGit(context.Background(), repoPath, "fetch", remote)
+	return err
 }
 
-// CreateWorktreeNew creates a new worktree with a new branch from origin's default
+// UnmergedCommitCount returns how many commits on branch have no equivalent
+// in target. Commits merged directly, by rebase, or by a single-commit squash
+// don't count.
+func UnmergedCommitCount(repoPath, branch, target string) (int, error) {
+	// "git cherry" marks commits with no equivalent in target with "+"
+	output, err := runGit(context.Background(), repoPath, "cherry", target, branch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compare %s with %s: %w", branch, target, err)
+	}
+	count := 0
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "+") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RefExists reports whether a ref (branch, remote branch, tag) resolves
+func RefExists(repoPath, ref string) bool {
+	_, err := runGit(context.Background(), repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// Push pushes a branch to the remote and sets it as upstream
+func Push(repoPath, remote, branch string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "push", "-u", remote, branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// CreateWorktreeNew creates a new worktree with a new branch from the remote's default
 // Returns error if branch already exists anywhere
-func CreateWorktreeNew(repoPath, worktreePath, branch string) error {
+func CreateWorktreeNew(repoPath, remote, worktreePath, branch string) error {
 	// Fetch first
-	Fetch(repoPath) // Ignore error - might be offline
+	Fetch(repoPath, remote) // Ignore error - might be offline
 
 	// Check if branch exists anywhere
-	info := CheckBranch(repoPath, branch)
+	info := CheckBranch(repoPath, remote, branch)
 	if info.Status != BranchNotFound {
 		return fmt.Errorf("branch '%s' already exists", branch)
 	}
 
-	// Check if remote exists
-	hasRemote := hasOriginRemote(repoPath)
-
-	var cmd *exec.Cmd
-	if hasRemote {
-		// Create new branch from origin's default branch
-		defaultBranch := GetDefaultBranch(repoPath)
-		cmd = exec.Command("git", "worktree", "add", "-b", branch, worktreePath, "origin/"+defaultBranch)
-	} else {
-		// No remote - create from HEAD
-		cmd = exec.Command("git", "worktree", "add", "-b", branch, worktreePath, "HEAD")
+	base := newBranchBase(repoPath, remote)
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "add", "-b", branch, worktreePath, base); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create worktree: %s: %w", string(output), err)
+	return nil
+}
+
+// newBranchBase returns the ref new branches start from: the remote's
+// default branch, or HEAD when there's no remote, we're offline, or the
+// remote isn't tracked (as in a bare clone, whose HEAD is the default branch)
+func newBranchBase(repoPath, remote string) string {
+	if !hasRemote(repoPath, remote) || offline {
+		return "HEAD"
 	}
+	base := remote + "/" + GetDefaultBranch(repoPath, remote)
+	if !RefExists(repoPath, base) {
+		return "HEAD"
+	}
+	return base
+}
+
+// hasRemote checks if the repo has the given remote configured
+func hasRemote(repoPath, remote string) bool {
+	_, err := runGit(context.Background(), repoPath, "remote", "get-url", remote)
+	return err == nil
+}
 
+// CreateWorktreeFromBase creates a worktree with a new branch starting at base
+func CreateWorktreeFromBase(repoPath, worktreePath, branch, base string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "add", "-b", branch, worktreePath, base); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
 	return nil
 }
 
-// hasOriginRemote checks if the repo has an origin remote configured
-func hasOriginRemote(repoPath string) bool {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
-	cmd.Dir = repoPath
-	return cmd.Run() == nil
+// ResolveBaseRef finds ref locally or on the remote and returns the ref to
+// branch from. Local refs (branches, tags, commits) win over remote branches.
+func ResolveBaseRef(repoPath, remote, ref string) (string, error) {
+	if RefExists(repoPath, ref) {
+		return ref, nil
+	}
+	if RefExists(repoPath, remote+"/"+ref) {
+		return remote + "/" + ref, nil
+	}
+	return "", fmt.Errorf("base '%s' not found locally or on %s", ref, remote)
 }
 
 // CreateWorktreeFromBranch creates a worktree from an existing local branch
 func CreateWorktreeFromBranch(repoPath, worktreePath, branch string) error {
-	cmd := exec.Command("git", "worktree", "add", worktreePath, branch)
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create worktree: %s: %w", string(output), err)
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "add", worktreePath, branch); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 	return nil
 }
 
 // CreateWorktreeTrackRemote creates a worktree tracking a remote branch
-func CreateWorktreeTrackRemote(repoPath, worktreePath, branch string) error {
+func CreateWorktreeTrackRemote(repoPath, remote, worktreePath, branch string) error {
 	// Create local branch tracking remote
-	cmd := exec.Command("git", "worktree", "add", "--track", "-b", branch, worktreePath, "origin/"+branch)
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create worktree: %s: %w", string(output), err)
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "add", "--track", "-b", branch, worktreePath, remote+"/"+branch); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 	return nil
 }
 
-// PreflightCheck checks branch status for multiple repos
+// PreflightCheck checks branch status for multiple repos, fetching them
+// concurrently. branches maps each repo path to the branch to check.
 // Returns a map of repo path -> BranchInfo
-func PreflightCheck(repos []string, branch string) map[string]BranchInfo {
+func PreflightCheck(branches map[string]string, remote string) map[string]BranchInfo {
 	results := make(map[string]BranchInfo)
-	for _, repo := range repos {
-		Fetch(repo) // Fetch each repo first
-		results[repo] = CheckBranch(repo, branch)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan string)
+	for i := 0; i < preflightWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				// A failed or timed-out fetch still yields a result from local refs
+				Fetch(repo, remote)
+				info := CheckBranch(repo, remote, branches[repo])
+
+				mu.Lock()
+				results[repo] = info
+				mu.Unlock()
+			}
+		}()
 	}
+
+	for repo := range branches {
+		jobs <- repo
+	}
+	close(jobs)
+	wg.Wait()
+
 	return results
 }
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateWorktreeNewBasesOffConfiguredRemote(t *testing.T) {
+	upstream := newTestRepo(t)
+	work := t.TempDir()
+
+	// A fork with its own commit, cloned before upstream moved on
+	fork := filepath.Join(work, "fork")
+	gitT(t, work, "clone", "-q", upstream, fork)
+	writeFile(t, filepath.Join(fork, "fork.txt"), "fork\n")
+	gitT(t, fork, "add", "-A")
+	gitT(t, fork, "commit", "-q", "-m", "fork work")
+
+	local := filepath.Join(work, "local")
+	gitT(t, work, "clone", "-q", "--origin", "upstream", upstream, local)
+	gitT(t, local, "remote", "add", "origin", fork)
+	gitT(t, local, "fetch", "-q", "origin")
+
+	// Upstream gets a commit the local clone hasn't fetched yet
+	writeFile(t, filepath.Join(upstream, "new.txt"), "new\n")
+	gitT(t, upstream, "add", "-A")
+	gitT(t, upstream, "commit", "-q", "-m", "upstream work")
+	want := gitT(t, upstream, "rev-parse", "HEAD")
+
+	if got := GetDefaultBranch(local, "upstream"); got != "main" {
+		t.Errorf("GetDefaultBranch(upstream) = %q, want main", got)
+	}
+
+	wt := filepath.Join(work, "wt")
+	if err := CreateWorktreeNew(local, "upstream", wt, "exp/try"); err != nil {
+		t.Fatalf("CreateWorktreeNew: %v", err)
+	}
+	if got := gitT(t, wt, "rev-parse", "HEAD"); got != want {
+		t.Errorf("worktree starts at %s, want upstream/main %s", got, want)
+	}
+	if got := gitT(t, wt, "rev-parse", "--abbrev-ref", "HEAD"); got != "exp/try" {
+		t.Errorf("worktree is on %s, want exp/try", got)
+	}
+}
+
+// traceGit passes calls through to git and records each one's args
+func traceGit(t *testing.T) *[][]string {
+	t.Helper()
+	var calls [][]string
+	old := gitRunner
+	gitRunner = func(ctx context.Context, dir string, input []byte, limit time.Duration, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		return old(ctx, dir, input, limit, args...)
+	}
+	t.Cleanup(func() { gitRunner = old })
+	return &calls
+}
+
+func usedLsRemote(calls [][]string) bool {
+	for _, args := range calls {
+		if len(args) > 0 && args[0] == "ls-remote" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckBranchLocalSkipsLsRemote(t *testing.T) {
+	origin := newTestRepo(t)
+	gitT(t, origin, "branch", "exp/shared")
+	clone := filepath.Join(t.TempDir(), "clone")
+	gitT(t, origin, "clone", "-q", origin, clone)
+	gitT(t, clone, "branch", "exp/shared", "origin/exp/shared")
+	gitT(t, clone, "checkout", "-q", "exp/shared")
+	writeFile(t, filepath.Join(clone, "local.txt"), "local\n")
+	gitT(t, clone, "add", "-A")
+	gitT(t, clone, "commit", "-q", "-m", "local work")
+	gitT(t, clone, "branch", "exp/local-only")
+
+	calls := traceGit(t)
+	info := CheckBranchLocal(clone, "origin", "exp/shared")
+	if info.Status != BranchBoth || info.LocalAhead != 1 || info.RemoteBehind != 0 {
+		t.Errorf("exp/shared: got %+v, want both with 1 ahead", info)
+	}
+	if info := CheckBranchLocal(clone, "origin", "exp/local-only"); info.Status != BranchLocalOnly {
+		t.Errorf("exp/local-only: got status %v, want local only", info.Status)
+	}
+	if usedLsRemote(*calls) {
+		t.Errorf("CheckBranchLocal hit the network: %q", *calls)
+	}
+
+	SetOffline(true)
+	t.Cleanup(func() { SetOffline(false) })
+	*calls = nil
+	CheckBranch(clone, "origin", "exp/shared")
+	if usedLsRemote(*calls) {
+		t.Errorf("CheckBranch ran ls-remote while offline: %q", *calls)
+	}
+
+	SetOffline(false)
+	*calls = nil
+	CheckBranch(clone, "origin", "exp/shared")
+	if !usedLsRemote(*calls) {
+		t.Error("CheckBranch didn't ask the remote when online")
+	}
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CommandRunner runs a command in dir and returns its combined output.
+// Swapped out in tests to avoid calling the real gh binary.
+type CommandRunner func(dir, name string, args ...string) ([]byte, error)
+
+// runCommand is the CommandRunner used for gh invocations
+var runCommand CommandRunner = func(dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// lookPath finds an executable on PATH (overridable in tests)
+var lookPath = exec.LookPath
+
+// PullRequest holds the options for creating a pull request
+type PullRequest struct {
+	Base  string
+	Head  string
+	Title string
+	Body  string
+	Draft bool
+}
+
+// GHInstalled checks if the GitHub CLI is available
+func GHInstalled() bool {
+	_, err := lookPath("gh")
+	return err == nil
+}
+
+// CreatePullRequest opens a pull request with `gh pr create` from repoPath
+// and returns the URL printed by gh
+func CreatePullRequest(repoPath string, pr PullRequest) (string, error) {
+	if !GHInstalled() {
+		return "", fmt.Errorf("gh CLI not found (install from https://cli.github.com)")
+	}
+
+	args := []string{"pr", "create", "--base", pr.Base, "--head", pr.Head, "--title", pr.Title, "--body", pr.Body}
+	if pr.Draft {
+		args = append(args, "--draft")
+	}
+
+	output, err := runCommand(repoPath, "gh", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pull request: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	// gh prints the PR URL as the last line
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
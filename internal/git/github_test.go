@@ -0,0 +1,68 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeGH swaps in a gh found on PATH whose invocations are recorded and
+// answered with output and err
+func fakeGH(t *testing.T, output string, err error) *[][]string {
+	t.Helper()
+	var calls [][]string
+	oldRun, oldLook := runCommand, lookPath
+	runCommand = func(dir, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{dir, name}, args...))
+		return []byte(output), err
+	}
+	lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	t.Cleanup(func() { runCommand, lookPath = oldRun, oldLook })
+	return &calls
+}
+
+func TestCreatePullRequestArgs(t *testing.T) {
+	calls := fakeGH(t, "Creating pull request for exp/try into main\n\nhttps://github.com/me/api/pull/7\n", nil)
+
+	url, err := CreatePullRequest("/src/api", PullRequest{Base: "main", Head: "exp/try", Title: "Try redis", Body: "", Draft: true})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if url != "https://github.com/me/api/pull/7" {
+		t.Errorf("url = %q, want the last line gh printed", url)
+	}
+	want := [][]string{{"/src/api", "gh", "pr", "create", "--base", "main", "--head", "exp/try", "--title", "Try redis", "--body", "", "--draft"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("ran %q, want %q", *calls, want)
+	}
+
+	*calls = nil
+	if _, err := CreatePullRequest("/src/api", PullRequest{Base: "main", Head: "exp/try", Title: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	if args := (*calls)[0]; args[len(args)-1] == "--draft" {
+		t.Errorf("non-draft PR passed --draft: %q", args)
+	}
+}
+
+func TestCreatePullRequestErrors(t *testing.T) {
+	fakeGH(t, "a pull request for branch \"exp/try\" already exists\n", errors.New("exit status 1"))
+	_, err := CreatePullRequest("/src/api", PullRequest{Base: "main", Head: "exp/try", Title: "t"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("err = %v, want gh's output in it", err)
+	}
+
+	calls := fakeGH(t, "", nil)
+	lookPath = func(file string) (string, error) { return "", exec.ErrNotFound }
+	if GHInstalled() {
+		t.Error("GHInstalled() = true with no gh on PATH")
+	}
+	if _, err := CreatePullRequest("/src/api", PullRequest{}); err == nil || !strings.Contains(err.Error(), "gh CLI not found") {
+		t.Errorf("err = %v, want gh reported missing", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("ran gh without it installed: %q", *calls)
+	}
+}
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the default limit for a single git command
+const DefaultTimeout = 30 * time.Second
+
+// ErrTimeout is returned (wrapped) when a git command exceeds the timeout
+var ErrTimeout = errors.New("git command timed out")
+
+// timeout limits how long a single read-only or network query may run.
+// Commands that change the repo are not limited, see runGitMutating.
+var timeout = DefaultTimeout
+
+// offline disables all network access (fetches and remote probes)
+var offline bool
+
+// SetOffline enables or disables offline mode. When offline, Fetch is a
+// no-op, CheckBranch only looks at local branches (so divergence info is
+// unavailable), and CreateWorktreeNew branches from local HEAD.
+func SetOffline(enabled bool) {
+	offline = enabled
+}
+
+// IsOffline reports whether offline mode is enabled
+func IsOffline() bool {
+	return offline
+}
+
+// verbose logs every git invocation to stderr
+var verbose bool
+
+// SetVerbose enables or disables logging of git commands and their duration
+// to stderr. Stdout is left untouched so piped output stays clean.
+func SetVerbose(enabled bool) {
+	verbose = enabled
+}
+
+// SetTimeout changes the limit for git queries (zero or negative restores the default)
+func SetTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultTimeout
+	}
+	timeout = d
+}
+
+// runGit runs a read-only or network query (status, rev-parse, fetch,
+// ls-remote, ...) in dir, bounded by the configured timeout.
+// Returns stdout; on failure the error includes git's stderr.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	return gitRunner(ctx, dir, nil, timeout, args...)
+}
+
+// runGitWithInput is runGit with stdin fed from input
+func runGitWithInput(ctx context.Context, dir string, input []byte, args ...string) ([]byte, error) {
+	return gitRunner(ctx, dir, input, timeout, args...)
+}
+
+// runGitMutating runs a git command that changes the repo (worktree add,
+// merge, rebase, push, ...) without a timeout. These can legitimately take
+// long on big checkouts or slow hooks, and killing one midway would leave a
+// half-made worktree or a merge in progress behind.
+func runGitMutating(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	return gitRunner(ctx, dir, nil, 0, args...)
+}
+
+// GitRunner runs git with args in dir, feeding it input and killing it after
+// limit (no limit if zero). Returns stdout.
+type GitRunner func(ctx context.Context, dir string, input []byte, limit time.Duration, args ...string) ([]byte, error)
+
+// gitRunner is the GitRunner behind every git invocation.
+// Swapped out in tests to check the exact args passed to git.
+var gitRunner GitRunner = execGit
+
+// execGit runs git with args in dir, killing it after limit (no limit if zero)
+func execGit(ctx context.Context, dir string, input []byte, limit time.Duration, args ...string) ([]byte, error) {
+	if limit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limit)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "+ git %s (in %s)\n", strings.Join(args, " "), dir)
+		start := time.Now()
+		defer func() {
+			fmt.Fprintf(os.Stderr, "  git %s took %s\n", args[0], time.Since(start).Round(time.Millisecond))
+		}()
+	}
+
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("git %s: %w after %s", args[0], ErrTimeout, limit)
+	}
+	if err != nil {
+		return output, fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	return output, nil
+}
+
+// IsTimeout reports whether err came from a git command that timed out
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
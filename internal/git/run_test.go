@@ -0,0 +1,20 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeoutOnlyLimitsQueries(t *testing.T) {
+	SetTimeout(time.Nanosecond)
+	t.Cleanup(func() { SetTimeout(DefaultTimeout) })
+	dir := t.TempDir()
+
+	if _, err := runGit(context.Background(), dir, "version"); !IsTimeout(err) {
+		t.Errorf("query: got %v, want a timeout", err)
+	}
+	if _, err := runGitMutating(context.Background(), dir, "init", "-q"); err != nil {
+		t.Errorf("mutating command hit the query timeout: %v", err)
+	}
+}
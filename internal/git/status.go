@@ -1,24 +1,35 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
 // Status represents the git status of a repository
 type Status struct {
-	Clean             bool
-	ModifiedFiles     []string
-	UntrackedFiles    []string
-	StagedFiles       []string
-	UncommittedCount  int
+	Clean            bool          `json:"clean"`
+	ModifiedFiles    []string      `json:"modified,omitempty"`
+	UntrackedFiles   []string      `json:"untracked,omitempty"`
+	StagedFiles      []string      `json:"staged,omitempty"`
+	DeletedFiles     []string      `json:"deleted,omitempty"`
+	RenamedFiles     []RenamedFile `json:"renamed,omitempty"`
+	UncommittedCount int           `json:"uncommitted_count"`
+}
+
+// RenamedFile is a rename (or copy) reported by git status
+type RenamedFile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // GetStatus returns the git status for a repository
 func GetStatus(repoPath string) (*Status, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+	// -z gives NUL-separated, unquoted paths so names with spaces or
+	// non-ASCII characters come through exactly
+	output, err := runGit(context.Background(), repoPath, "status", "--porcelain=v1", "-z")
 	if err != nil {
 		return nil, err
 	}
@@ -27,21 +38,28 @@ func GetStatus(repoPath string) (*Status, error) {
 		Clean: true,
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if line == "" {
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
 			continue
 		}
 		status.Clean = false
 		status.UncommittedCount++
 
-		if len(line) < 3 {
-			continue
-		}
+		indexStatus := entry[0]
+		workTreeStatus := entry[1]
+		file := entry[3:]
 
-		indexStatus := line[0]
-		workTreeStatus := line[1]
-		file := strings.TrimSpace(line[3:])
+		// Renames and copies are followed by the original path as its own entry
+		if indexStatus == 'R' || indexStatus == 'C' || workTreeStatus == 'R' || workTreeStatus == 'C' {
+			if i+1 < len(entries) {
+				i++
+				if indexStatus == 'R' || workTreeStatus == 'R' {
+					status.RenamedFiles = append(status.RenamedFiles, RenamedFile{From: entries[i], To: file})
+				}
+			}
+		}
 
 		// Staged files
 		if indexStatus != ' ' && indexStatus != '?' {
@@ -53,6 +71,11 @@ func GetStatus(repoPath string) (*Status, error) {
 			status.ModifiedFiles = append(status.ModifiedFiles, file)
 		}
 
+		// Deleted (staged or in work tree)
+		if indexStatus == 'D' || workTreeStatus == 'D' {
+			status.DeletedFiles = append(status.DeletedFiles, file)
+		}
+
 		// Untracked
 		if indexStatus == '?' {
 			status.UntrackedFiles = append(status.UntrackedFiles, file)
@@ -71,14 +94,71 @@ func HasUncommittedChanges(repoPath string) (bool, error) {
 	return !status.Clean, nil
 }
 
+// IsIgnored checks if a path (relative to repoPath) is ignored by git.
+// Uses git's own matching, so nested .gitignore files, negation patterns,
+// and global excludes are all respected.
+func IsIgnored(repoPath, relPath string) bool {
+	_, err := runGit(context.Background(), repoPath, "check-ignore", "--quiet", "--", relPath)
+	return err == nil
+}
+
+// IgnoredPaths returns which of relPaths (relative to dir, inside a git repo)
+// are ignored by git, checked with a single git check-ignore call
+func IgnoredPaths(dir string, relPaths []string) (map[string]bool, error) {
+	ignored := make(map[string]bool)
+	if len(relPaths) == 0 {
+		return ignored, nil
+	}
+
+	input := []byte(strings.Join(relPaths, "\x00") + "\x00")
+	output, err := runGitWithInput(context.Background(), dir, input, "check-ignore", "--stdin", "-z")
+	if err != nil {
+		// Exit status 1 just means nothing was ignored
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return ignored, nil
+		}
+		return nil, err
+	}
+
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			ignored[path] = true
+		}
+	}
+	return ignored, nil
+}
+
 // GetRecentCommits returns recent commit messages
 func GetRecentCommits(repoPath string, count int) ([]string, error) {
-	cmd := exec.Command("git", "log", "--oneline", "-n", string(rune('0'+count)))
-	if count > 9 {
-		cmd = exec.Command("git", "log", "--oneline", "-n", "10")
+	if count <= 0 {
+		return []string{}, nil
+	}
+	return logOneline(repoPath, count, "HEAD")
+}
+
+// GetCommitsAhead returns commits on branch that aren't on base, newest
+// first. A count of 0 or less means no limit.
+func GetCommitsAhead(repoPath, base, branch string, count int) ([]string, error) {
+	return logOneline(repoPath, count, base+".."+branch)
+}
+
+// GetBranchCommits returns the full history of branch, newest first. A count
+// of 0 or less means no limit.
+func GetBranchCommits(repoPath, branch string, count int) ([]string, error) {
+	return logOneline(repoPath, count, branch)
+}
+
+// logOneline runs "git log --oneline" over revs and returns one line per commit
+func logOneline(repoPath string, count int, revs ...string) ([]string, error) {
+	args := []string{"log", "--oneline"}
+	if count > 0 {
+		args = append(args, "-n", strconv.Itoa(count))
 	}
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+	args = append(args, revs...)
+	args = append(args, "--")
+
+	output, err := runGit(context.Background(), repoPath, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -92,3 +172,75 @@ func GetRecentCommits(repoPath string, count int) ([]string, error) {
 	}
 	return commits, nil
 }
+
+// DiffStat is the line count change for one file
+type DiffStat struct {
+	File    string `json:"file"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Binary  bool   `json:"binary,omitempty"`
+}
+
+// GetDiffStat returns per-file changes for "git diff revs". With no revs it
+// compares the working tree (staged and unstaged) against HEAD.
+func GetDiffStat(repoPath string, revs ...string) ([]DiffStat, error) {
+	if len(revs) == 0 {
+		revs = []string{"HEAD"}
+	}
+	args := append([]string{"diff", "--numstat", "-z"}, revs...)
+	args = append(args, "--")
+
+	output, err := runGit(context.Background(), repoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each entry is "added\tremoved\tpath\x00", or for renames
+	// "added\tremoved\t\x00old\x00new\x00". Binary files report "-" counts.
+	fields := strings.Split(string(output), "\x00")
+	var stats []DiffStat
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		stat := DiffStat{File: parts[2]}
+		if parts[2] == "" && i+2 < len(fields) {
+			stat.File = fields[i+1] + " => " + fields[i+2]
+			i += 2
+		}
+		if parts[0] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Added, _ = strconv.Atoi(parts[0])
+			stat.Removed, _ = strconv.Atoi(parts[1])
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// Stash is one entry of "git stash list"
+type Stash struct {
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+}
+
+// GetStashList returns the repo's stashes, newest first. Stashes are shared by
+// all worktrees of a repo.
+func GetStashList(repoPath string) ([]Stash, error) {
+	output, err := runGit(context.Background(), repoPath, "stash", "list", "--format=%gd%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []Stash
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		ref, message, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		stashes = append(stashes, Stash{Ref: ref, Message: message})
+	}
+	return stashes, nil
+}
@@ -0,0 +1,167 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// recordGit swaps in a GitRunner that records each invocation's args and
+// answers with output
+func recordGit(t *testing.T, output string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	old := gitRunner
+	gitRunner = func(ctx context.Context, dir string, input []byte, limit time.Duration, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte(output), nil
+	}
+	t.Cleanup(func() { gitRunner = old })
+	return &calls
+}
+
+func TestGetRecentCommitsPassesCountToGit(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{1, "1"},
+		{5, "5"},
+		{12, "12"},
+		{100, "100"},
+	}
+	for _, tt := range tests {
+		calls := recordGit(t, "abc1234 first\ndef5678 second\n")
+		commits, err := GetRecentCommits("/repo", tt.count)
+		if err != nil {
+			t.Fatalf("count %d: %v", tt.count, err)
+		}
+		want := [][]string{{"log", "--oneline", "-n", tt.want, "HEAD", "--"}}
+		if !reflect.DeepEqual(*calls, want) {
+			t.Errorf("count %d: git called with %q, want %q", tt.count, *calls, want)
+		}
+		if len(commits) != 2 {
+			t.Errorf("count %d: got %d commits, want 2", tt.count, len(commits))
+		}
+	}
+}
+
+func TestGetRecentCommitsNonPositiveCountSkipsGit(t *testing.T) {
+	for _, count := range []int{0, -3} {
+		calls := recordGit(t, "")
+		commits, err := GetRecentCommits("/repo", count)
+		if err != nil || commits == nil || len(commits) != 0 {
+			t.Errorf("count %d: got %v, %v; want an empty slice", count, commits, err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("count %d: git was called with %q", count, *calls)
+		}
+	}
+}
+
+func TestGetStatusParsesEachChangeType(t *testing.T) {
+	repo := newTestRepo(t)
+	for _, name := range []string{"modify.txt", "delete.txt", "old name.txt", "staged.txt"} {
+		writeFile(t, filepath.Join(repo, name), name+"\n")
+	}
+	gitT(t, repo, "add", "-A")
+	gitT(t, repo, "commit", "-q", "-m", "files")
+
+	writeFile(t, filepath.Join(repo, "modify.txt"), "changed\n")
+	writeFile(t, filepath.Join(repo, "staged.txt"), "changed\n")
+	gitT(t, repo, "add", "staged.txt")
+	gitT(t, repo, "rm", "-q", "delete.txt")
+	gitT(t, repo, "mv", "old name.txt", "new name.txt")
+	writeFile(t, filepath.Join(repo, "untracked file.txt"), "new\n")
+
+	status, err := GetStatus(repo)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.Clean {
+		t.Error("Clean = true for a dirty repo")
+	}
+	if status.UncommittedCount != 5 {
+		t.Errorf("UncommittedCount = %d, want 5", status.UncommittedCount)
+	}
+	if want := []string{"modify.txt"}; !reflect.DeepEqual(status.ModifiedFiles, want) {
+		t.Errorf("ModifiedFiles = %q, want %q", status.ModifiedFiles, want)
+	}
+	if want := []string{"untracked file.txt"}; !reflect.DeepEqual(status.UntrackedFiles, want) {
+		t.Errorf("UntrackedFiles = %q, want %q", status.UntrackedFiles, want)
+	}
+	if want := []string{"delete.txt"}; !reflect.DeepEqual(status.DeletedFiles, want) {
+		t.Errorf("DeletedFiles = %q, want %q", status.DeletedFiles, want)
+	}
+	if want := []RenamedFile{{From: "old name.txt", To: "new name.txt"}}; !reflect.DeepEqual(status.RenamedFiles, want) {
+		t.Errorf("RenamedFiles = %+v, want %+v", status.RenamedFiles, want)
+	}
+	sort.Strings(status.StagedFiles)
+	if want := []string{"delete.txt", "new name.txt", "staged.txt"}; !reflect.DeepEqual(status.StagedFiles, want) {
+		t.Errorf("StagedFiles = %q, want %q", status.StagedFiles, want)
+	}
+}
+
+func TestGetStatusCleanRepo(t *testing.T) {
+	status, err := GetStatus(newTestRepo(t))
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if !status.Clean || status.UncommittedCount != 0 {
+		t.Errorf("got %+v, want a clean status", status)
+	}
+}
+
+func TestGetStatusKeepsUnusualPathsExact(t *testing.T) {
+	repo := newTestRepo(t)
+	writeFile(t, filepath.Join(repo, "tracked.txt"), "a\n")
+	gitT(t, repo, "add", "-A")
+	gitT(t, repo, "commit", "-q", "-m", "files")
+
+	writeFile(t, filepath.Join(repo, "my file.txt"), "spaces\n")
+	writeFile(t, filepath.Join(repo, "café.txt"), "utf-8\n")
+	writeFile(t, filepath.Join(repo, `quote"d.txt`), "quote\n")
+	gitT(t, repo, "mv", "tracked.txt", "renamed -> here.txt")
+
+	status, err := GetStatus(repo)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	sort.Strings(status.UntrackedFiles)
+	if want := []string{"café.txt", "my file.txt", `quote"d.txt`}; !reflect.DeepEqual(status.UntrackedFiles, want) {
+		t.Errorf("UntrackedFiles = %q, want %q", status.UntrackedFiles, want)
+	}
+	if want := []RenamedFile{{From: "tracked.txt", To: "renamed -> here.txt"}}; !reflect.DeepEqual(status.RenamedFiles, want) {
+		t.Errorf("RenamedFiles = %+v, want %+v", status.RenamedFiles, want)
+	}
+}
+
+func TestGetDiffStatParsesRenamesAndBinaries(t *testing.T) {
+	repo := newTestRepo(t)
+	writeFile(t, filepath.Join(repo, "old name.txt"), "one\ntwo\nthree\nfour\n")
+	writeFile(t, filepath.Join(repo, "edit.txt"), "a\nb\n")
+	gitT(t, repo, "add", "-A")
+	gitT(t, repo, "commit", "-q", "-m", "files")
+
+	gitT(t, repo, "mv", "old name.txt", "new name.txt")
+	writeFile(t, filepath.Join(repo, "edit.txt"), "a\nc\nd\n")
+	writeFile(t, filepath.Join(repo, "blob.bin"), "\x00\x01\x02")
+	gitT(t, repo, "add", "-A")
+
+	stats, err := GetDiffStat(repo)
+	if err != nil {
+		t.Fatalf("GetDiffStat: %v", err)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].File < stats[j].File })
+	want := []DiffStat{
+		{File: "blob.bin", Binary: true},
+		{File: "edit.txt", Added: 2, Removed: 1},
+		{File: "old name.txt => new name.txt"},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("GetDiffStat = %+v, want %+v", stats, want)
+	}
+}
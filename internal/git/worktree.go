@@ -1,63 +1,60 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"strings"
 )
 
 // CreateWorktree creates a new git worktree with the given branch
-func CreateWorktree(repoPath, worktreePath, branch string) error {
-	// Fetch latest from origin
-	fetchCmd := exec.Command("git", "fetch", "origin")
-	fetchCmd.Dir = repoPath
-	fetchCmd.Run() // Ignore errors - might be offline
+func CreateWorktree(repoPath, remote, worktreePath, branch string) error {
+	// Fetch latest from the remote
+	Fetch(repoPath, remote) // Ignore errors - might be offline
 
 	// Check if branch already exists
-	cmd := exec.Command("git", "rev-parse", "--verify", branch)
-	cmd.Dir = repoPath
-	branchExists := cmd.Run() == nil
+	_, err := runGit(context.Background(), repoPath, "rev-parse", "--verify", branch)
+	branchExists := err == nil
 
 	var args []string
 	if branchExists {
 		// Branch exists, just checkout
 		args = []string{"worktree", "add", worktreePath, branch}
 	} else {
-		// Create new branch from origin's default branch
-		defaultBranch := GetDefaultBranch(repoPath)
-		args = []string{"worktree", "add", "-b", branch, worktreePath, "origin/" + defaultBranch}
+		// Create new branch from the remote's default branch
+		args = []string{"worktree", "add", "-b", branch, worktreePath, newBranchBase(repoPath, remote)}
 	}
 
-	cmd = exec.Command("git", args...)
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create worktree: %s: %w", string(output), err)
+	if _, err := runGitMutating(context.Background(), repoPath, args...); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
 	return nil
 }
 
 // GetDefaultBranch returns the default branch (main, master, etc.) for a repo
-func GetDefaultBranch(repoPath string) string {
-	// Try to get from origin/HEAD
-	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+func GetDefaultBranch(repoPath, remote string) string {
+	// Try to get from <remote>/HEAD
+	prefix := "refs/remotes/" + remote + "/"
+	output, err := runGit(context.Background(), repoPath, "symbolic-ref", prefix+"HEAD")
 	if err == nil {
 		// Output is like "refs/remotes/origin/main"
 		ref := strings.TrimSpace(string(output))
-		parts := strings.Split(ref, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1]
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+
+	// A bare clone has no remote-tracking branches; its HEAD names the
+	// default branch instead
+	if IsBareRepo(repoPath) {
+		if output, err := runGit(context.Background(), repoPath, "symbolic-ref", "--short", "HEAD"); err == nil {
+			return strings.TrimSpace(string(output))
 		}
 	}
 
 	// Fallback: check if main exists, otherwise master
-	cmd = exec.Command("git", "rev-parse", "--verify", "origin/main")
-	cmd.Dir = repoPath
-	if cmd.Run() == nil {
+	if _, err := runGit(context.Background(), repoPath, "rev-parse", "--verify", remote+"/main"); err == nil {
 		return "main"
 	}
 
@@ -66,20 +63,15 @@ func GetDefaultBranch(repoPath string) string {
 
 // RemoveWorktree removes a git worktree
 func RemoveWorktree(repoPath, worktreePath string) error {
-	cmd := exec.Command("git", "worktree", "remove", worktreePath, "--force")
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to remove worktree: %s: %w", string(output), err)
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "remove", worktreePath, "--force"); err != nil {
+		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
 	return nil
 }
 
 // ListWorktrees returns all worktrees for a repository
 func ListWorktrees(repoPath string) ([]string, error) {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+	output, err := runGit(context.Background(), repoPath, "worktree", "list", "--porcelain")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
@@ -94,22 +86,68 @@ func ListWorktrees(repoPath string) ([]string, error) {
 	return worktrees, nil
 }
 
+// BranchCheckedOutAt returns the path of the worktree that has branch
+// checked out, if any
+func BranchCheckedOutAt(repoPath, branch string) (string, bool) {
+	worktrees, err := WorktreeBranches(repoPath)
+	if err != nil {
+		return "", false
+	}
+	path, ok := worktrees[branch]
+	return path, ok
+}
+
+// WorktreeBranches maps each checked-out branch to its worktree path
+func WorktreeBranches(repoPath string) (map[string]string, error) {
+	output, err := runGit(context.Background(), repoPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	// Porcelain output is blank-line separated blocks of
+	// "worktree <path>", "HEAD <sha>", "branch refs/heads/<name>"
+	branches := make(map[string]string)
+	var current string
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			current = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "branch refs/heads/"):
+			branches[strings.TrimPrefix(line, "branch refs/heads/")] = current
+		}
+	}
+
+	return branches, nil
+}
+
+// PruneWorktrees removes git's records of worktrees whose directories are gone
+func PruneWorktrees(repoPath string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "prune"); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+	return nil
+}
+
 // DeleteBranch deletes a git branch
 func DeleteBranch(repoPath, branch string) error {
-	cmd := exec.Command("git", "branch", "-D", branch)
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to delete branch: %s: %w", string(output), err)
+	if _, err := runGitMutating(context.Background(), repoPath, "branch", "-D", branch); err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+	return nil
+}
+
+// DeleteMergedBranch deletes a branch with "git branch -d", which refuses
+// to delete it if git doesn't consider it merged
+func DeleteMergedBranch(repoPath, branch string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "branch", "-d", branch); err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
 	}
 	return nil
 }
 
 // GetCurrentBranch returns the current branch name
 func GetCurrentBranch(repoPath string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
+	output, err := runGit(context.Background(), repoPath, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -118,15 +156,61 @@ func GetCurrentBranch(repoPath string) (string, error) {
 
 // GetRepoRoot returns the root directory of the git repository
 func GetRepoRoot(path string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	output, err := runGit(context.Background(), path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetMainRepoRoot returns the root of the main repository that a worktree
+// belongs to. For the main worktree itself this is the same as GetRepoRoot.
+func GetMainRepoRoot(path string) (string, error) {
+	output, err := runGit(context.Background(), path, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %w", err)
+	}
+	commonDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(path, commonDir)
+	}
+	commonDir = filepath.Clean(commonDir)
+	// A bare repo's worktrees share the repo directory itself
+	if filepath.Base(commonDir) != ".git" && IsBareRepo(commonDir) {
+		return commonDir, nil
+	}
+	return filepath.Dir(commonDir), nil
+}
+
+// IsBareRepo checks if path is (inside) a bare repository
+func IsBareRepo(path string) bool {
+	output, err := runGit(context.Background(), path, "rev-parse", "--is-bare-repository")
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// GetRepoRootOrBare is GetRepoRoot, except that for a bare repository it
+// returns the repository directory, which has no working tree
+func GetRepoRootOrBare(path string) (string, error) {
+	if root, err := GetRepoRoot(path); err == nil {
+		return root, nil
+	}
+	if !IsBareRepo(path) {
+		return "", fmt.Errorf("not a git repository: %s", path)
+	}
+	output, err := runGit(context.Background(), path, "rev-parse", "--absolute-git-dir")
 	if err != nil {
 		return "", fmt.Errorf("not a git repository: %w", err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// IsSourceRepo checks if worktrees can be created from path: a regular or
+// bare repository
+func IsSourceRepo(path string) bool {
+	_, err := GetRepoRootOrBare(path)
+	return err == nil
+}
+
 // IsGitRepo checks if a path is inside a git repository
 func IsGitRepo(path string) bool {
 	_, err := GetRepoRoot(path)
@@ -137,3 +221,115 @@ func IsGitRepo(path string) bool {
 func GetRepoName(repoPath string) string {
 	return filepath.Base(repoPath)
 }
+
+// MoveWorktree moves a git worktree to a new path
+func MoveWorktree(repoPath, oldPath, newPath string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "move", oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+	return nil
+}
+
+// RepairWorktree repairs git's administrative links after a worktree
+// directory was moved outside of git
+func RepairWorktree(repoPath, worktreePath string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "worktree", "repair", worktreePath); err != nil {
+		return fmt.Errorf("failed to repair worktree: %w", err)
+	}
+	return nil
+}
+
+// RenameBranch renames a local branch
+func RenameBranch(repoPath, oldBranch, newBranch string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "branch", "-m", oldBranch, newBranch); err != nil {
+		return fmt.Errorf("failed to rename branch: %w", err)
+	}
+	return nil
+}
+
+// ConflictError is returned by MergeBranch and RebaseOnto when the operation
+// stopped on conflicts and was left in progress
+type ConflictError struct {
+	Op    string // "merge" or "rebase"
+	Ref   string
+	Files []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s of '%s' has conflicts in %d file(s)", e.Op, e.Ref, len(e.Files))
+}
+
+// Checkout switches the repo's working tree to the given branch
+func Checkout(repoPath, branch string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "checkout", branch); err != nil {
+		return fmt.Errorf("failed to checkout %s: %w", branch, err)
+	}
+	return nil
+}
+
+// FastForward fast-forwards the current branch to ref, failing if it can't
+func FastForward(repoPath, ref string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "merge", "--ff-only", ref); err != nil {
+		return fmt.Errorf("failed to fast-forward to %s: %w", ref, err)
+	}
+	return nil
+}
+
+// MergeBranch merges branch into the current branch of repoPath.
+// On conflicts the merge is left in progress and a *ConflictError is
+// returned so the caller can tell the user which files need resolving.
+func MergeBranch(repoPath, branch string, noFF bool) error {
+	args := []string{"merge", "--no-edit"}
+	if noFF {
+		args = append(args, "--no-ff")
+	}
+	args = append(args, branch)
+
+	_, err := runGitMutating(context.Background(), repoPath, args...)
+	if err == nil {
+		return nil
+	}
+
+	if conflicts := conflictedFiles(repoPath); len(conflicts) > 0 {
+		return &ConflictError{Op: "merge", Ref: branch, Files: conflicts}
+	}
+	return fmt.Errorf("failed to merge %s: %w", branch, err)
+}
+
+// RebaseOnto rebases the current branch of worktreePath onto ref.
+// On conflicts the rebase is left in progress and a *ConflictError is returned.
+func RebaseOnto(worktreePath, ref string) error {
+	_, err := runGitMutating(context.Background(), worktreePath, "rebase", ref)
+	if err == nil {
+		return nil
+	}
+
+	if conflicts := conflictedFiles(worktreePath); len(conflicts) > 0 {
+		return &ConflictError{Op: "rebase", Ref: ref, Files: conflicts}
+	}
+	return fmt.Errorf("failed to rebase onto %s: %w", ref, err)
+}
+
+// AbortMerge aborts an in-progress merge
+func AbortMerge(repoPath string) error {
+	if _, err := runGitMutating(context.Background(), repoPath, "merge", "--abort"); err != nil {
+		return fmt.Errorf("failed to abort merge: %w", err)
+	}
+	return nil
+}
+
+// conflictedFiles returns files with unresolved merge conflicts
+func conflictedFiles(repoPath string) []string {
+	output, err := runGit(context.Background(), repoPath, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
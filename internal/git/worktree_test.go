@@ -0,0 +1,70 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBranchCheckedOutAt(t *testing.T) {
+	repo := newTestRepo(t)
+	wt := filepath.Join(t.TempDir(), "second")
+	gitT(t, repo, "worktree", "add", "-q", "-b", "exp/busy", wt)
+	gitT(t, repo, "branch", "exp/free")
+	wt, _ = filepath.EvalSymlinks(wt)
+
+	tests := []struct {
+		branch string
+		want   string
+		ok     bool
+	}{
+		{"main", repo, true},
+		{"exp/busy", wt, true},
+		{"exp/free", "", false},
+		{"exp", "", false},
+	}
+	for _, tt := range tests {
+		path, ok := BranchCheckedOutAt(repo, tt.branch)
+		if ok != tt.ok || path != tt.want {
+			t.Errorf("BranchCheckedOutAt(%s) = %q, %v; want %q, %v", tt.branch, path, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Creating another worktree on a live branch is what the check prevents
+	if err := CreateWorktreeFromBranch(repo, filepath.Join(t.TempDir(), "third"), "exp/busy"); err == nil {
+		t.Error("git allowed a second worktree on exp/busy")
+	}
+}
+
+func TestBareCloneWorktrees(t *testing.T) {
+	origin := newTestRepo(t)
+	want := gitT(t, origin, "rev-parse", "HEAD")
+	bare := filepath.Join(t.TempDir(), "api.git")
+	gitT(t, origin, "clone", "-q", "--bare", origin, bare)
+	bare, _ = filepath.EvalSymlinks(bare)
+
+	if IsGitRepo(bare) {
+		t.Error("IsGitRepo accepted a bare repo, which has no working tree")
+	}
+	if root, err := GetRepoRootOrBare(bare); err != nil || root != bare {
+		t.Errorf("GetRepoRootOrBare = %q, %v; want %q", root, err, bare)
+	}
+
+	// A bare clone has origin but no origin/* refs: HEAD is the default branch
+	if base := newBranchBase(bare, "origin"); base != "HEAD" {
+		t.Errorf("newBranchBase = %q, want HEAD", base)
+	}
+
+	wt := filepath.Join(t.TempDir(), "exp")
+	if err := CreateWorktreeNew(bare, "origin", wt, "exp/try"); err != nil {
+		t.Fatalf("CreateWorktreeNew: %v", err)
+	}
+	if got := gitT(t, wt, "rev-parse", "HEAD"); got != want {
+		t.Errorf("worktree starts at %s, want %s", got, want)
+	}
+	if root, err := GetMainRepoRoot(wt); err != nil || root != bare {
+		t.Errorf("GetMainRepoRoot(worktree) = %q, %v; want the bare dir %q", root, err, bare)
+	}
+	if root, err := GetMainRepoRoot(origin); err != nil || root != origin {
+		t.Errorf("GetMainRepoRoot(regular repo) = %q, %v; want %q", root, err, origin)
+	}
+}
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 )
 
 var (
@@ -17,6 +19,34 @@ var (
 	Dim     = color.New(color.Faint).SprintFunc()
 )
 
+// SetColorMode controls whether output is colored: "always", "never", or
+// "auto" (color only when stdout is a terminal and NO_COLOR is unset)
+func SetColorMode(mode string) error {
+	switch mode {
+	case "always":
+		color.NoColor = false
+	case "never":
+		color.NoColor = true
+	case "auto", "":
+		color.NoColor = !colorSupported()
+	default:
+		return fmt.Errorf("invalid color mode '%s' (use always, never, or auto)", mode)
+	}
+	return nil
+}
+
+// colorSupported reports whether stdout should get ANSI colors by default
+func colorSupported() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 // Success prints a success message
 func Success(format string, args ...interface{}) {
 	fmt.Printf("%s %s\n", Green("✓"), fmt.Sprintf(format, args...))
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+)
+
+// assumeYes is set by --yes or CLADE_YES for scripts with no terminal
+var assumeYes bool
+
+// SetAssumeYes makes prompts take their default and prompts without one fail
+// instead of waiting for input
+func SetAssumeYes(enabled bool) {
+	assumeYes = enabled
+}
+
+// AssumeYes reports whether prompts should be skipped
+func AssumeYes() bool {
+	return assumeYes
+}
+
+// Confirm asks a yes/no question. def is the answer when the user just presses
+// enter. With --yes it returns def without asking, so destructive questions
+// (which default to no) are declined.
+func Confirm(label string, def bool) bool {
+	if assumeYes {
+		answer := "no"
+		if def {
+			answer = "yes"
+		}
+		fmt.Printf("  %s\n", Dim(fmt.Sprintf("%s? %s (default with --yes)", label, answer)))
+		return def
+	}
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	if def {
+		prompt.Default = "y"
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
+
+// Input asks for a value, offering def. With --yes it returns def, or an error
+// if there is none.
+func Input(label, def string) (string, error) {
+	if assumeYes {
+		if def == "" {
+			return "", fmt.Errorf("%s is required (can't prompt with --yes)", label)
+		}
+		return def, nil
+	}
+	prompt := promptui.Prompt{
+		Label:   label,
+		Default: def,
+	}
+	return prompt.Run()
+}
+
+// Interactive returns an error with --yes, for pickers that have no default.
+// what describes the choice, e.g. "pick a repo".
+func Interactive(what string) error {
+	if assumeYes {
+		return fmt.Errorf("can't %s with --yes", what)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package version holds build metadata, set at build time with e.g.
+//
+//	go build -ldflags "-X github.com/daniil-lyalko/clade/internal/version.Version=v1.2.0"
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set via -ldflags -X; "dev"/"unknown" when built without them
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// Info is the build metadata of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build metadata. For builds without ldflags (e.g. go
+// install) it falls back to what the Go toolchain embedded, if anything.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "unknown":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "unknown":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// String formats the metadata on one line
+func (i Info) String() string {
+	commit := i.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s)", i.Version, commit, i.Date, i.GoVersion)
+}